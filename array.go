@@ -0,0 +1,45 @@
+package rfc6979
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"hash"
+)
+
+// signArray signs the hash with priv, which must be on the curve c, and
+// writes the raw signature into dst.
+func signArray(dst []byte, c elliptic.Curve, priv *ecdsa.PrivateKey, hash []byte, alg func() hash.Hash) error {
+	if !sameCurve(priv.Curve, c) {
+		return ErrUnsupportedCurve
+	}
+	r, s := SignECDSA(priv, hash, alg)
+	return fillRaw(dst, r, s)
+}
+
+// SignECDSAP224Array is like SignECDSAP1363 for P-224 keys, but returns the
+// signature as an array. ErrUnsupportedCurve is returned for other curves.
+func SignECDSAP224Array(priv *ecdsa.PrivateKey, hash []byte, alg func() hash.Hash) (sig [56]byte, err error) {
+	err = signArray(sig[:], elliptic.P224(), priv, hash, alg)
+	return
+}
+
+// SignECDSAP256Array is like SignECDSAP1363 for P-256 keys, but returns the
+// signature as an array. ErrUnsupportedCurve is returned for other curves.
+func SignECDSAP256Array(priv *ecdsa.PrivateKey, hash []byte, alg func() hash.Hash) (sig [64]byte, err error) {
+	err = signArray(sig[:], elliptic.P256(), priv, hash, alg)
+	return
+}
+
+// SignECDSAP384Array is like SignECDSAP1363 for P-384 keys, but returns the
+// signature as an array. ErrUnsupportedCurve is returned for other curves.
+func SignECDSAP384Array(priv *ecdsa.PrivateKey, hash []byte, alg func() hash.Hash) (sig [96]byte, err error) {
+	err = signArray(sig[:], elliptic.P384(), priv, hash, alg)
+	return
+}
+
+// SignECDSAP521Array is like SignECDSAP1363 for P-521 keys, but returns the
+// signature as an array. ErrUnsupportedCurve is returned for other curves.
+func SignECDSAP521Array(priv *ecdsa.PrivateKey, hash []byte, alg func() hash.Hash) (sig [132]byte, err error) {
+	err = signArray(sig[:], elliptic.P521(), priv, hash, alg)
+	return
+}
@@ -0,0 +1,72 @@
+package rfc6979_test
+
+import (
+	"bytes"
+	"crypto/elliptic"
+	"crypto/sha256"
+	"testing"
+
+	"github.com/nspcc-dev/rfc6979"
+)
+
+func TestSignECDSAArray(t *testing.T) {
+	for _, f := range fixtures {
+		h := f.alg()
+		h.Write([]byte(f.message))
+		digest := h.Sum(nil)
+
+		expected, err := rfc6979.SignECDSAP1363(f.key.key, digest, f.alg)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var sig []byte
+		switch f.key {
+		case p224:
+			a, err := rfc6979.SignECDSAP224Array(f.key.key, digest, f.alg)
+			if err != nil {
+				t.Fatal(err)
+			}
+			sig = a[:]
+		case p256:
+			a, err := rfc6979.SignECDSAP256Array(f.key.key, digest, f.alg)
+			if err != nil {
+				t.Fatal(err)
+			}
+			sig = a[:]
+		case p384:
+			a, err := rfc6979.SignECDSAP384Array(f.key.key, digest, f.alg)
+			if err != nil {
+				t.Fatal(err)
+			}
+			sig = a[:]
+		case p521:
+			a, err := rfc6979.SignECDSAP521Array(f.key.key, digest, f.alg)
+			if err != nil {
+				t.Fatal(err)
+			}
+			sig = a[:]
+		}
+
+		if !bytes.Equal(sig, expected) {
+			t.Errorf("%s: Expected %X, got %X", f.name, expected, sig)
+		}
+	}
+
+	if _, err := rfc6979.SignECDSAP256Array(p384.key, []byte{1}, nil); err != rfc6979.ErrUnsupportedCurve {
+		t.Errorf("Expected %v, got %v", rfc6979.ErrUnsupportedCurve, err)
+	}
+
+	// The curve is identified by its parameters, not by its name.
+	digest := sha256.Sum256([]byte("sample"))
+	renamed := *p256.key
+	renamed.Curve = renamedCurve{elliptic.P256(), "P-384"}
+	if _, err := rfc6979.SignECDSAP256Array(&renamed, digest[:], sha256.New); err != nil {
+		t.Errorf("Renamed P-256: %v", err)
+	}
+	impostor := secp256k1Key("1")
+	impostor.Curve = renamedCurve{rfc6979.Secp256k1(), "P-256"}
+	if _, err := rfc6979.SignECDSAP256Array(impostor, digest[:], sha256.New); err != rfc6979.ErrUnsupportedCurve {
+		t.Errorf("Expected secp256k1 named P-256 to be rejected, got %v", err)
+	}
+}
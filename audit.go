@@ -0,0 +1,41 @@
+package rfc6979
+
+import (
+	"crypto/ecdsa"
+	"sort"
+)
+
+// DetectNonceReuse scans signatures made with the public key, pub, for pairs
+// sharing the same r over different digests, which means the same nonce was
+// used twice and the private key can be computed from them. It returns the
+// index pairs of such signatures, each ordered and the whole list sorted.
+//
+// Digests are compared after truncation to the curve order, so signatures of
+// the same message made twice, as the deterministic signer does, are not
+// reported. Signatures are not verified, entries with nil components are
+// skipped. This never triggers for signatures made by this package, it's
+// meant for auditing signature sets of mixed provenance.
+func DetectNonceReuse(pub *ecdsa.PublicKey, sigs []BatchItem) [][2]int {
+	var pairs [][2]int
+
+	byR := make(map[string][]int)
+	for i := range sigs {
+		if sigs[i].R == nil || sigs[i].S == nil {
+			continue
+		}
+
+		key := string(sigs[i].R.Bytes())
+		e := hashToInt(sigs[i].Digest, pub.Curve)
+		for _, j := range byR[key] {
+			if hashToInt(sigs[j].Digest, pub.Curve).Cmp(e) != 0 {
+				pairs = append(pairs, [2]int{j, i})
+			}
+		}
+		byR[key] = append(byR[key], i)
+	}
+
+	sort.Slice(pairs, func(a, b int) bool {
+		return pairs[a][0] < pairs[b][0] || pairs[a][0] == pairs[b][0] && pairs[a][1] < pairs[b][1]
+	})
+	return pairs
+}
@@ -0,0 +1,63 @@
+package rfc6979_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/sha256"
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/nspcc-dev/rfc6979"
+)
+
+// signWithNonce computes an ECDSA signature of the digest using the nonce k.
+func signWithNonce(priv *ecdsa.PrivateKey, digest []byte, k *big.Int) rfc6979.BatchItem {
+	N := priv.Curve.Params().N
+	r, _ := priv.Curve.ScalarBaseMult(k.Bytes())
+	r.Mod(r, N)
+
+	e := new(big.Int).SetBytes(digest)
+	s := new(big.Int).Mul(r, priv.D)
+	s.Add(s, e)
+	s.Mul(s, new(big.Int).ModInverse(k, N))
+	s.Mod(s, N)
+
+	return rfc6979.BatchItem{Digest: digest, R: r, S: s}
+}
+
+func TestDetectNonceReuse(t *testing.T) {
+	key := p256.key
+	var clean []rfc6979.BatchItem
+	for _, m := range []string{"a", "b", "c", "a"} {
+		digest := sha256.Sum256([]byte(m))
+		r, s := rfc6979.SignECDSA(key, digest[:], sha256.New)
+		clean = append(clean, rfc6979.BatchItem{Digest: digest[:], R: r, S: s})
+	}
+
+	// Signing "a" twice deterministically is not a reuse.
+	if pairs := rfc6979.DetectNonceReuse(&key.PublicKey, clean); len(pairs) != 0 {
+		t.Errorf("Expected no reuse in deterministic signatures, got %v", pairs)
+	}
+
+	k := big.NewInt(123456789)
+	var mixed []rfc6979.BatchItem
+	mixed = append(mixed, clean[0])
+	for _, m := range []string{"x", "y"} {
+		digest := sha256.Sum256([]byte(m))
+		mixed = append(mixed, signWithNonce(key, digest[:], k))
+	}
+	mixed = append(mixed, clean[1], rfc6979.BatchItem{})
+	digest := sha256.Sum256([]byte("z"))
+	mixed = append(mixed, signWithNonce(key, digest[:], k))
+
+	for _, it := range mixed[1:3] {
+		if !ecdsa.Verify(&key.PublicKey, it.Digest, it.R, it.S) {
+			t.Fatal("Invalid signature with a fixed nonce")
+		}
+	}
+
+	expected := [][2]int{{1, 2}, {1, 5}, {2, 5}}
+	if pairs := rfc6979.DetectNonceReuse(&key.PublicKey, mixed); !reflect.DeepEqual(pairs, expected) {
+		t.Errorf("Expected %v, got %v", expected, pairs)
+	}
+}
@@ -0,0 +1,485 @@
+package rfc6979
+
+import (
+	"crypto/elliptic"
+	"math/big"
+	"math/bits"
+	"sync"
+)
+
+// binaryCurve implements a curve y² + xy = x³ + ax² + b over GF(2^m) with a
+// polynomial basis, like the NIST K- and B-curves (FIPS 186-4 appendix
+// D.1.3). Field elements are given as integers whose bits are the
+// polynomial coefficients, CurveParams.P holds the reduction polynomial the
+// same way. Points are processed in affine coordinates, the arithmetic is
+// variable-time.
+type binaryCurve struct {
+	params *elliptic.CurveParams
+	m      int
+	// terms are the exponents of the reduction polynomial below m.
+	terms []int
+	a, b  gf2
+	words int
+}
+
+// gf2 is a GF(2^m) element, little-endian 64-bit words of coefficients.
+type gf2 []uint64
+
+// newBinaryCurve returns a curve with the parameters given in hex, the
+// reduction polynomial being x^m plus the terms.
+func newBinaryCurve(name string, m int, terms []int, a, b, gx, gy, n string) *binaryCurve {
+	c := &binaryCurve{m: m, terms: terms, words: m/64 + 1}
+	params := &elliptic.CurveParams{Name: name, BitSize: m}
+	params.P = new(big.Int).SetBit(new(big.Int), m, 1)
+	for _, t := range terms {
+		params.P.SetBit(params.P, t, 1)
+	}
+	params.N, _ = new(big.Int).SetString(n, 16)
+	params.B, _ = new(big.Int).SetString(b, 16)
+	params.Gx, _ = new(big.Int).SetString(gx, 16)
+	params.Gy, _ = new(big.Int).SetString(gy, 16)
+	curveA, _ := new(big.Int).SetString(a, 16)
+	c.params = params
+	c.a, c.b = c.element(curveA), c.element(params.B)
+	return c
+}
+
+// Params implements elliptic.Curve.
+func (c *binaryCurve) Params() *elliptic.CurveParams {
+	return c.params
+}
+
+// IsOnCurve implements elliptic.Curve.
+func (c *binaryCurve) IsOnCurve(x, y *big.Int) bool {
+	if !c.valid(x) || !c.valid(y) {
+		return false
+	}
+	fx, fy := c.element(x), c.element(y)
+
+	// y² + xy = x³ + ax² + b
+	lhs := c.mul(fy, fy)
+	lhs.add(c.mul(fx, fy))
+	x2 := c.mul(fx, fx)
+	rhs := c.mul(x2, fx)
+	rhs.add(c.mul(x2, c.a))
+	rhs.add(c.b)
+	return lhs.equal(rhs)
+}
+
+// Add implements elliptic.Curve.
+func (c *binaryCurve) Add(x1, y1, x2, y2 *big.Int) (x, y *big.Int) {
+	return c.affine(c.addPoints(c.point(x1, y1), c.point(x2, y2)))
+}
+
+// Double implements elliptic.Curve.
+func (c *binaryCurve) Double(x1, y1 *big.Int) (x, y *big.Int) {
+	return c.affine(c.double(c.point(x1, y1)))
+}
+
+// ScalarMult implements elliptic.Curve.
+func (c *binaryCurve) ScalarMult(x1, y1 *big.Int, k []byte) (x, y *big.Int) {
+	b := c.point(x1, y1)
+	var q binaryPoint
+	for _, octet := range k {
+		for bit := 7; bit >= 0; bit-- {
+			q = c.double(q)
+			if octet>>uint(bit)&1 == 1 {
+				q = c.addPoints(q, b)
+			}
+		}
+	}
+	return c.affine(q)
+}
+
+// ScalarBaseMult implements elliptic.Curve.
+func (c *binaryCurve) ScalarBaseMult(k []byte) (x, y *big.Int) {
+	return c.ScalarMult(c.params.Gx, c.params.Gy, k)
+}
+
+// binaryPoint is an affine point, x being nil for the point at infinity.
+type binaryPoint struct {
+	x, y gf2
+}
+
+// point converts a point, (0, 0) being the point at infinity.
+func (c *binaryCurve) point(x, y *big.Int) binaryPoint {
+	if x.Sign() == 0 && y.Sign() == 0 {
+		return binaryPoint{}
+	}
+	return binaryPoint{c.element(x), c.element(y)}
+}
+
+// affine converts a point back, returning (0, 0) for infinity.
+func (c *binaryCurve) affine(p binaryPoint) (x, y *big.Int) {
+	if p.x == nil {
+		return new(big.Int), new(big.Int)
+	}
+	return p.x.int(), p.y.int()
+}
+
+// addPoints returns p + q, see Guide to Elliptic Curve Cryptography section
+// 3.1.2.
+func (c *binaryCurve) addPoints(p, q binaryPoint) binaryPoint {
+	if p.x == nil {
+		return q
+	}
+	if q.x == nil {
+		return p
+	}
+	if p.x.equal(q.x) {
+		if p.y.equal(q.y) {
+			return c.double(p)
+		}
+		// q = -p = (x, x + y)
+		return binaryPoint{}
+	}
+
+	// λ = (y1 + y2) / (x1 + x2)
+	dx := c.clone(p.x)
+	dx.add(q.x)
+	dy := c.clone(p.y)
+	dy.add(q.y)
+	l := c.mul(dy, c.inv(dx))
+
+	// x3 = λ² + λ + x1 + x2 + a, y3 = λ(x1 + x3) + x3 + y1
+	x3 := c.mul(l, l)
+	x3.add(l)
+	x3.add(dx)
+	x3.add(c.a)
+	y3 := c.clone(p.x)
+	y3.add(x3)
+	y3 = c.mul(l, y3)
+	y3.add(x3)
+	y3.add(p.y)
+	return binaryPoint{x3, y3}
+}
+
+// double returns 2p.
+func (c *binaryCurve) double(p binaryPoint) binaryPoint {
+	if p.x == nil || p.x.isZero() {
+		return binaryPoint{}
+	}
+
+	// λ = x + y / x
+	l := c.mul(p.y, c.inv(p.x))
+	l.add(p.x)
+
+	// x3 = λ² + λ + a, y3 = x² + (λ + 1)x3
+	x3 := c.mul(l, l)
+	x3.add(l)
+	x3.add(c.a)
+	l[0] ^= 1
+	y3 := c.mul(l, x3)
+	y3.add(c.mul(p.x, p.x))
+	return binaryPoint{x3, y3}
+}
+
+// valid reports whether v is a field element.
+func (c *binaryCurve) valid(v *big.Int) bool {
+	return v.Sign() >= 0 && v.BitLen() <= c.m
+}
+
+// element converts an integer, which must be a field element.
+func (c *binaryCurve) element(v *big.Int) gf2 {
+	e := make(gf2, c.words)
+	buf := v.FillBytes(make([]byte, 8*c.words))
+	for i := range e {
+		for _, b := range buf[len(buf)-8*(i+1) : len(buf)-8*i] {
+			e[i] = e[i]<<8 | uint64(b)
+		}
+	}
+	return e
+}
+
+// int converts an element to an integer.
+func (e gf2) int() *big.Int {
+	buf := make([]byte, 0, 8*len(e))
+	for i := len(e) - 1; i >= 0; i-- {
+		for shift := 56; shift >= 0; shift -= 8 {
+			buf = append(buf, byte(e[i]>>uint(shift)))
+		}
+	}
+	return new(big.Int).SetBytes(buf)
+}
+
+func (c *binaryCurve) clone(e gf2) gf2 {
+	return append(make(gf2, 0, c.words), e...)
+}
+
+// add sets e to e + f.
+func (e gf2) add(f gf2) {
+	for i := range e {
+		e[i] ^= f[i]
+	}
+}
+
+func (e gf2) equal(f gf2) bool {
+	for i := range e {
+		if e[i] != f[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func (e gf2) isZero() bool {
+	for _, w := range e {
+		if w != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// degree returns the degree of e as a polynomial, -1 for zero.
+func (e gf2) degree() int {
+	for i := len(e) - 1; i >= 0; i-- {
+		if e[i] != 0 {
+			return 64*i + bits.Len64(e[i]) - 1
+		}
+	}
+	return -1
+}
+
+// mul returns x·y.
+func (c *binaryCurve) mul(x, y gf2) gf2 {
+	// Carry-less multiplication, one shift of y per bit position.
+	prod := make([]uint64, 2*c.words+1)
+	shifted := make([]uint64, c.words+1)
+	copy(shifted, y)
+	for j := uint(0); j < 64; j++ {
+		if j != 0 {
+			for i := c.words; i > 0; i-- {
+				shifted[i] = shifted[i]<<1 | shifted[i-1]>>63
+			}
+			shifted[0] <<= 1
+		}
+		for i, w := range x {
+			if w>>j&1 == 1 {
+				for k, s := range shifted {
+					prod[i+k] ^= s
+				}
+			}
+		}
+	}
+	return c.reduce(prod)
+}
+
+// reduce returns v modulo the reduction polynomial.
+func (c *binaryCurve) reduce(v []uint64) gf2 {
+	for i := 64*len(v) - 1; i >= c.m; i-- {
+		if v[i/64]>>uint(i%64)&1 == 0 {
+			continue
+		}
+		v[i/64] ^= 1 << uint(i%64)
+		for _, t := range c.terms {
+			j := i - c.m + t
+			v[j/64] ^= 1 << uint(j%64)
+		}
+	}
+	return gf2(v[:c.words])
+}
+
+// inv returns the inverse of a non-zero x, see Guide to Elliptic Curve
+// Cryptography algorithm 2.48.
+func (c *binaryCurve) inv(x gf2) gf2 {
+	f := c.element(c.params.P)
+	u, v := c.clone(x), c.clone(f)
+	g1, g2 := make(gf2, c.words), make(gf2, c.words)
+	g1[0] = 1
+	for !u.isOne() && !v.isOne() {
+		for u[0]&1 == 0 {
+			u.shr1()
+			if g1[0]&1 == 1 {
+				g1.add(f)
+			}
+			g1.shr1()
+		}
+		for v[0]&1 == 0 {
+			v.shr1()
+			if g2[0]&1 == 1 {
+				g2.add(f)
+			}
+			g2.shr1()
+		}
+		if u.degree() > v.degree() {
+			u.add(v)
+			g1.add(g2)
+		} else {
+			v.add(u)
+			g2.add(g1)
+		}
+	}
+	if u.isOne() {
+		return g1
+	}
+	return g2
+}
+
+func (e gf2) isOne() bool {
+	if e[0] != 1 {
+		return false
+	}
+	for _, w := range e[1:] {
+		if w != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// shr1 sets e to e/z, e being divisible by z.
+func (e gf2) shr1() {
+	for i := 0; i < len(e)-1; i++ {
+		e[i] = e[i]>>1 | e[i+1]<<63
+	}
+	e[len(e)-1] >>= 1
+}
+
+var (
+	binaryOnce sync.Once
+	k163       *binaryCurve
+	k233       *binaryCurve
+	k283       *binaryCurve
+	k409       *binaryCurve
+	k571       *binaryCurve
+	b163       *binaryCurve
+	b233       *binaryCurve
+	b283       *binaryCurve
+	b409       *binaryCurve
+	b571       *binaryCurve
+)
+
+func initBinary() {
+	k163 = newBinaryCurve("K-163", 163, []int{7, 6, 3, 0}, "1", "1",
+		"2FE13C0537BBC11ACAA07D793DE4E6D5E5C94EEE8",
+		"289070FB05D38FF58321F2E800536D538CCDAA3D9",
+		"4000000000000000000020108A2E0CC0D99F8A5EF")
+	k233 = newBinaryCurve("K-233", 233, []int{74, 0}, "0", "1",
+		"17232BA853A7E731AF129F22FF4149563A419C26BF50A4C9D6EEFAD6126",
+		"1DB537DECE819B7F70F555A67C427A8CD9BF18AEB9B56E0C11056FAE6A3",
+		"8000000000000000000000000000069D5BB915BCD46EFB1AD5F173ABDF")
+	k283 = newBinaryCurve("K-283", 283, []int{12, 7, 5, 0}, "0", "1",
+		"503213F78CA44883F1A3B8162F188E553CD265F23C1567A16876913B0C2AC2458492836",
+		"1CCDA380F1C9E318D90F95D07E5426FE87E45C0E8184698E45962364E34116177DD2259",
+		"1FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFE9AE2ED07577265DFF7F94451E061E163C61")
+	k409 = newBinaryCurve("K-409", 409, []int{87, 0}, "0", "1",
+		"60F05F658F49C1AD3AB1890F7184210EFD0987E307C84C27ACCFB8F9F67CC2C460189EB5AAAA62EE222EB1B35540CFE9023746",
+		"1E369050B7C4E42ACBA1DACBF04299C3460782F918EA427E6325165E9EA10E3DA5F6C42E9C55215AA9CA27A5863EC48D8E0286B",
+		"7FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFE5F83B2D4EA20400EC4557D5ED3E3E7CA5B4B5C83B8E01E5FCF")
+	k571 = newBinaryCurve("K-571", 571, []int{10, 5, 2, 0}, "0", "1",
+		"26EB7A859923FBC82189631F8103FE4AC9CA2970012D5D46024804801841CA44370958493B205E647DA304DB4CEB08CBBD1BA39494776FB988B47174DCA88C7E2945283A01C8972",
+		"349DC807F4FBF374F4AEADE3BCA95314DD58CEC9F307A54FFC61EFC006D8A2C9D4979C0AC44AEA74FBEBBB9F772AEDCB620B01A7BA7AF1B320430C8591984F601CD4C143EF1C7A3",
+		"20000000000000000000000000000000000000000000000000000000000000000000000131850E1F19A63E4B391A8DB917F4138B630D84BE5D639381E91DEB45CFE778F637C1001")
+	b163 = newBinaryCurve("B-163", 163, []int{7, 6, 3, 0}, "1",
+		"20A601907B8C953CA1481EB10512F78744A3205FD",
+		"3F0EBA16286A2D57EA0991168D4994637E8343E36",
+		"D51FBC6C71A0094FA2CDD545B11C5C0C797324F1",
+		"40000000000000000000292FE77E70C12A4234C33")
+	b233 = newBinaryCurve("B-233", 233, []int{74, 0}, "1",
+		"66647EDE6C332C7F8C0923BB58213B333B20E9CE4281FE115F7D8F90AD",
+		"FAC9DFCBAC8313BB2139F1BB755FEF65BC391F8B36F8F8EB7371FD558B",
+		"1006A08A41903350678E58528BEBF8A0BEFF867A7CA36716F7E01F81052",
+		"1000000000000000000000000000013E974E72F8A6922031D2603CFE0D7")
+	b283 = newBinaryCurve("B-283", 283, []int{12, 7, 5, 0}, "1",
+		"27B680AC8B8596DA5A4AF8A19A0303FCA97FD7645309FA2A581485AF6263E313B79A2F5",
+		"5F939258DB7DD90E1934F8C70B0DFEC2EED25B8557EAC9C80E2E198F8CDBECD86B12053",
+		"3676854FE24141CB98FE6D4B20D02B4516FF702350EDDB0826779C813F0DF45BE8112F4",
+		"3FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFEF90399660FC938A90165B042A7CEFADB307")
+	b409 = newBinaryCurve("B-409", 409, []int{87, 0}, "1",
+		"21A5C2C8EE9FEB5C4B9A753B7B476B7FD6422EF1F3DD674761FA99D6AC27C8A9A197B272822F6CD57A55AA4F50AE317B13545F",
+		"15D4860D088DDB3496B0C6064756260441CDE4AF1771D4DB01FFE5B34E59703DC255A868A1180515603AEAB60794E54BB7996A7",
+		"61B1CFAB6BE5F32BBFA78324ED106A7636B9C5A7BD198D0158AA4F5488D08F38514F1FDF4B4F40D2181B3681C364BA0273C706",
+		"10000000000000000000000000000000000000000000000000001E2AAD6A612F33307BE5FA47C3C9E052F838164CD37D9A21173")
+	b571 = newBinaryCurve("B-571", 571, []int{10, 5, 2, 0}, "1",
+		"2F40E7E2221F295DE297117B7F3D62F5C6A97FFCB8CEFF1CD6BA8CE4A9A18AD84FFABBD8EFA59332BE7AD6756A66E294AFD185A78FF12AA520E4DE739BACA0C7FFEFF7F2955727A",
+		"303001D34B856296C16C0D40D3CD7750A93D1D2955FA80AA5F40FC8DB7B2ABDBDE53950F4C0D293CDD711A35B67FB1499AE60038614F1394ABFA3B4C850D927E1E7769C8EEC2D19",
+		"37BF27342DA639B6DCCFFFEB73D69D78C6C27A6009CBBCA1980F8533921E8A684423E43BAB08A576291AF8F461BB2A8B3531D2F0485C19B16E2F1516E23DD3C1A4827AF1B8AC15B",
+		"3FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFE661CE18FF55987308059B186823851EC7DD9CA1161DE93D5174D66E8382E9BB2FE84E47")
+}
+
+// K163 returns an elliptic.Curve implementing the Koblitz curve K-163 (FIPS
+// 186-4 appendix D.1.3) over GF(2^163), used by RFC 6979 appendix A.2.8.
+// Coordinates are polynomials over GF(2) encoded as integers, and
+// Params().P is the reduction polynomial, not a prime, so only the methods
+// of elliptic.Curve and functions relying on them, like SignECDSA and
+// VerifyCurve, may be used with it. Its arithmetic is variable-time, so it
+// must not be used where the timing of signing can be observed. Multiple
+// invocations return the same value.
+func K163() elliptic.Curve {
+	binaryOnce.Do(initBinary)
+	return k163
+}
+
+// K233 returns an elliptic.Curve implementing the Koblitz curve K-233, see
+// K163. It is variable-time and must not be used where the timing of signing
+// can be observed.
+func K233() elliptic.Curve {
+	binaryOnce.Do(initBinary)
+	return k233
+}
+
+// K283 returns an elliptic.Curve implementing the Koblitz curve K-283, see
+// K163. It is variable-time and must not be used where the timing of signing
+// can be observed.
+func K283() elliptic.Curve {
+	binaryOnce.Do(initBinary)
+	return k283
+}
+
+// K409 returns an elliptic.Curve implementing the Koblitz curve K-409, see
+// K163. It is variable-time and must not be used where the timing of signing
+// can be observed.
+func K409() elliptic.Curve {
+	binaryOnce.Do(initBinary)
+	return k409
+}
+
+// K571 returns an elliptic.Curve implementing the Koblitz curve K-571, see
+// K163. It is variable-time and must not be used where the timing of signing
+// can be observed.
+func K571() elliptic.Curve {
+	binaryOnce.Do(initBinary)
+	return k571
+}
+
+// B163 returns an elliptic.Curve implementing the pseudo-random curve B-163
+// (FIPS 186-4 appendix D.1.3) over GF(2^163), used by RFC 6979 appendix
+// A.2.13. The restrictions of K163 apply to it as well: it is variable-time
+// and must not be used where the timing of signing can be observed.
+func B163() elliptic.Curve {
+	binaryOnce.Do(initBinary)
+	return b163
+}
+
+// B233 returns an elliptic.Curve implementing the curve B-233, see B163. It is
+// variable-time and must not be used where the timing of signing can be
+// observed.
+func B233() elliptic.Curve {
+	binaryOnce.Do(initBinary)
+	return b233
+}
+
+// B283 returns an elliptic.Curve implementing the curve B-283, see B163. It is
+// variable-time and must not be used where the timing of signing can be
+// observed.
+func B283() elliptic.Curve {
+	binaryOnce.Do(initBinary)
+	return b283
+}
+
+// B409 returns an elliptic.Curve implementing the curve B-409, see B163. It is
+// variable-time and must not be used where the timing of signing can be
+// observed.
+func B409() elliptic.Curve {
+	binaryOnce.Do(initBinary)
+	return b409
+}
+
+// B571 returns an elliptic.Curve implementing the curve B-571, see B163. It is
+// variable-time and must not be used where the timing of signing can be
+// observed.
+func B571() elliptic.Curve {
+	binaryOnce.Do(initBinary)
+	return b571
+}
@@ -0,0 +1,103 @@
+package rfc6979
+
+import (
+	"crypto/ecdsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"math/big"
+)
+
+// bitcoinMagic is the prefix of signed messages, its length included.
+const bitcoinMagic = "\x18Bitcoin Signed Message:\n"
+
+// BitcoinMessageHash returns the digest signed by Bitcoin Core's signmessage:
+// double SHA-256 of the magic "\x18Bitcoin Signed Message:\n", the length of
+// the message as a CompactSize integer and the message itself.
+func BitcoinMessageHash(message []byte) []byte {
+	var size [9]byte
+	n := 1
+	switch l := uint64(len(message)); {
+	case l < 0xfd:
+		size[0] = byte(l)
+	case l <= 0xffff:
+		size[0] = 0xfd
+		binary.LittleEndian.PutUint16(size[1:], uint16(l))
+		n = 3
+	case l <= 0xffffffff:
+		size[0] = 0xfe
+		binary.LittleEndian.PutUint32(size[1:], uint32(l))
+		n = 5
+	default:
+		size[0] = 0xff
+		binary.LittleEndian.PutUint64(size[1:], l)
+		n = 9
+	}
+
+	h := sha256.New()
+	h.Write([]byte(bitcoinMagic))
+	h.Write(size[:n])
+	h.Write(message)
+	first := h.Sum(nil)
+	second := sha256.Sum256(first)
+	return second[:]
+}
+
+// SignBitcoinMessage signs the message with the secp256k1 private key, priv,
+// like Bitcoin Core's signmessage and returns the base64 encoded 65-byte
+// compact signature header || r || s. The signature is low-S, the nonce is
+// derived with SHA-256 like libsecp256k1 does, and the header is 27 plus the
+// recovery id, plus 4 if compressed is set, which tells verifiers the
+// address is made from the compressed public key. ErrUnsupportedCurve is
+// returned for keys on other curves.
+func SignBitcoinMessage(priv *ecdsa.PrivateKey, message []byte, compressed bool) (string, error) {
+	if !IsSecp256k1(priv.Curve) {
+		return "", ErrUnsupportedCurve
+	}
+
+	r, s, recid := SignECDSARecoverable(priv, BitcoinMessageHash(message), sha256.New, &Options{LowS: true})
+	sig := make([]byte, 65)
+	sig[0] = 27 + recid
+	if compressed {
+		sig[0] += 4
+	}
+	if err := fillRaw(sig[1:], r, s); err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(sig), nil
+}
+
+// RecoverBitcoinMessage recovers the public key that signed the message from
+// a signature produced by SignBitcoinMessage, also reporting whether it's
+// meant to be compressed. Headers from 27 to 34 are accepted, malformed
+// signatures are rejected with ErrInvalidCompact, unrecoverable ones with
+// ErrRecovery.
+func RecoverBitcoinMessage(message []byte, signature string) (pub *ecdsa.PublicKey, compressed bool, err error) {
+	sig, err := base64.StdEncoding.Strict().DecodeString(signature)
+	if err != nil || len(sig) != 65 || sig[0] < 27 || sig[0] > 34 {
+		return nil, false, ErrInvalidCompact
+	}
+
+	header := sig[0] - 27
+	r := new(big.Int).SetBytes(sig[1:33])
+	s := new(big.Int).SetBytes(sig[33:])
+	pub, err = RecoverPublicKey(Secp256k1(), BitcoinMessageHash(message), r, s, header&3)
+	if err != nil {
+		return nil, false, err
+	}
+	return pub, header&4 != 0, nil
+}
+
+// VerifyBitcoinMessage verifies a signature produced by SignBitcoinMessage
+// against the public key, pub, returning ErrInvalidSignature if the key it
+// was made with is a different one.
+func VerifyBitcoinMessage(pub *ecdsa.PublicKey, message []byte, signature string) error {
+	recovered, _, err := RecoverBitcoinMessage(message, signature)
+	if err != nil {
+		return err
+	}
+	if !recovered.Equal(pub) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+package rfc6979_test
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/nspcc-dev/rfc6979"
+)
+
+// Generated with the SignCompact of github.com/decred/dcrd/dcrec/secp256k1
+// over the message hash, the key is the one of the Bitcoin wiki's WIF
+// example.
+var bitcoinMessages = []struct {
+	message, hash string
+	compressed    bool
+	signature     string
+}{
+	{"hello world", "0b6b6ce07bc55ee4aeba0098a5e5d2c8986cab228a54199723f9962316633733", false, "HILeL03QidcL4qm9GtsIRLLsitFeo0d/RVwSFtwIQXv1XnEj/bvCbd2JKJ9tC00lIyp1+ggiVjNu6J47cJoDXvM="},
+	{"hello world", "0b6b6ce07bc55ee4aeba0098a5e5d2c8986cab228a54199723f9962316633733", true, "IILeL03QidcL4qm9GtsIRLLsitFeo0d/RVwSFtwIQXv1XnEj/bvCbd2JKJ9tC00lIyp1+ggiVjNu6J47cJoDXvM="},
+	{"", "80e795d4a4caadd7047af389d9f7f220562feb6196032e2131e10563352c4bcc", false, "G2Ijf+Lv7QjnQEkV1h7mWrdyKRZ8yx2Cp0c2VSMP9R6RPtZQlL3qqtdj/eOYIuPIBKdmOAvIKRBqhttQDPU3n30="},
+	{"", "80e795d4a4caadd7047af389d9f7f220562feb6196032e2131e10563352c4bcc", true, "H2Ijf+Lv7QjnQEkV1h7mWrdyKRZ8yx2Cp0c2VSMP9R6RPtZQlL3qqtdj/eOYIuPIBKdmOAvIKRBqhttQDPU3n30="},
+	{"Lorem ipsum", "c0377cadd16f9337aef96643c2aa47c0ef24b15b46e01ee07171f6ecb6a58dc6", true, "ICkEbDSB7tYpCJVE2BBxqc+pvO7V/pCYJT7TX5S9vWQRBvuw47XJvIpx0fZ6Ig7+oUMOEwbSluLiUuNJvkDFjB8="},
+}
+
+func TestBitcoinMessage(t *testing.T) {
+	key := secp256k1Key("0C28FCA386C7A227600B2FE50B7CAE11EC86D3BF1FBE471BE89827E19D72AA1D")
+	for _, v := range bitcoinMessages {
+		if h := hex.EncodeToString(rfc6979.BitcoinMessageHash([]byte(v.message))); h != v.hash {
+			t.Errorf("%q: Expected hash %s, got %s", v.message, v.hash, h)
+		}
+
+		sig, err := rfc6979.SignBitcoinMessage(key, []byte(v.message), v.compressed)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if sig != v.signature {
+			t.Errorf("%q: Expected %s, got %s", v.message, v.signature, sig)
+		}
+
+		pub, compressed, err := rfc6979.RecoverBitcoinMessage([]byte(v.message), sig)
+		if err != nil || !pub.Equal(&key.PublicKey) || compressed != v.compressed {
+			t.Errorf("%q: Expected the key to be recovered, got %v", v.message, err)
+		}
+		if err := rfc6979.VerifyBitcoinMessage(&key.PublicKey, []byte(v.message), sig); err != nil {
+			t.Errorf("%q: %v", v.message, err)
+		}
+		if err := rfc6979.VerifyBitcoinMessage(&key.PublicKey, []byte(v.message+"!"), sig); err == nil {
+			t.Errorf("%q: Expected a different message to fail", v.message)
+		}
+	}
+
+	other := secp256k1Key(secp256k1Vectors[1].d)
+	if err := rfc6979.VerifyBitcoinMessage(&other.PublicKey, []byte("hello world"), bitcoinMessages[0].signature); err != rfc6979.ErrInvalidSignature {
+		t.Errorf("Expected %v, got %v", rfc6979.ErrInvalidSignature, err)
+	}
+
+	sig := bitcoinMessages[0].signature
+	for _, bad := range []string{"", sig[4:], "j" + sig[1:], strings.Replace(sig, "/", "_", 1)} {
+		if _, _, err := rfc6979.RecoverBitcoinMessage([]byte("hello world"), bad); err != rfc6979.ErrInvalidCompact {
+			t.Errorf("%s: Expected %v, got %v", bad, rfc6979.ErrInvalidCompact, err)
+		}
+	}
+
+	priv := secp256k1Key(secp256k1Vectors[1].d)
+	expected, _ := rfc6979.SignBitcoinMessage(priv, []byte("hello world"), true)
+	otherKey := *priv
+	otherKey.Curve = otherSecp256k1{priv.Curve}
+	if sig, err := rfc6979.SignBitcoinMessage(&otherKey, []byte("hello world"), true); err != nil || sig != expected {
+		t.Errorf("Other secp256k1 implementation: expected %s, got %s (%v)", expected, sig, err)
+	}
+
+	if _, err := rfc6979.SignBitcoinMessage(p256.key, nil, true); err != rfc6979.ErrUnsupportedCurve {
+		t.Errorf("Expected %v, got %v", rfc6979.ErrUnsupportedCurve, err)
+	}
+}
+
+func TestBitcoinMessageHashLength(t *testing.T) {
+	for _, v := range []struct {
+		n      int
+		prefix string
+	}{
+		{252, "fc"},
+		{253, "fdfd00"},
+		{65535, "fdffff"},
+		{65536, "fe00000100"},
+	} {
+		m := []byte(strings.Repeat("a", v.n))
+		prefix, _ := hex.DecodeString(v.prefix)
+		first := sha256.Sum256(append(append([]byte("\x18Bitcoin Signed Message:\n"), prefix...), m...))
+		expected := sha256.Sum256(first[:])
+		if h := rfc6979.BitcoinMessageHash(m); !bytes.Equal(h, expected[:]) {
+			t.Errorf("%d: Expected %x, got %x", v.n, expected, h)
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package rfc6979
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"hash"
+	"math/big"
+)
+
+// blindingFactor derives a scalar in [1, N-1] from the blinding input with
+// the RFC 6979 nonce generator seeded with blind alone.
+func blindingFactor(N *big.Int, blind []byte, alg func() hash.Hash) *big.Int {
+	var b *big.Int
+	nextSecret(newDRBG(alg, nil, blind), N, func(v *big.Int) bool {
+		b = v
+		return true
+	})
+	return b
+}
+
+// BlindDigest returns the hash blinded for the curve c as SignECDSABlinded
+// does: the hash is converted to an integer e like SignECDSA does, a scalar b
+// is derived deterministically from blind with alg and b·e mod N is encoded
+// in the leftmost bits of a digest as long as the curve order, so the usual
+// truncation yields it back.
+func BlindDigest(c elliptic.Curve, hash []byte, blind []byte, alg func() hash.Hash) []byte {
+	N := c.Params().N
+	e := hashToInt(hash, c)
+	e.Mul(e, blindingFactor(N, blind, alg))
+	e.Mod(e, N)
+	e.Lsh(e, uint(8*OrderSize(c)-N.BitLen()))
+	return scalarBytes(c, e)
+}
+
+// SignECDSABlinded is an EXPERIMENTAL construction producing signatures that
+// can't be linked to the hash or to each other unless the blinding input is
+// known. It signs BlindDigest(priv.Curve, hash, blind, alg) using the private
+// key, priv, passing blind to the nonce generator as the additional data k'
+// of SignECDSAWithEntropy.
+//
+// Signatures are deterministic for a given key, hash and blind, while
+// different blinds give unrelated signatures. They verify with the public
+// key of priv, but over the blinded digest: a verifier knowing the hash and
+// blind unblinds by recomputing BlindDigest(pub.Curve, hash, blind, alg) and
+// verifying the signature over it as usual, e.g. with ecdsa.Verify.
+func SignECDSABlinded(priv *ecdsa.PrivateKey, hash []byte, alg func() hash.Hash, blind []byte) (r, s *big.Int) {
+	return SignECDSAWithEntropy(priv, BlindDigest(priv.Curve, hash, blind, alg), alg, blind)
+}
@@ -0,0 +1,55 @@
+package rfc6979_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/sha256"
+	"crypto/sha512"
+	"hash"
+	"testing"
+
+	"github.com/nspcc-dev/rfc6979"
+)
+
+func TestSignECDSABlinded(t *testing.T) {
+	for _, tc := range []struct {
+		key *ecdsaKey
+		alg func() hash.Hash
+	}{
+		{p224, sha256.New},
+		{p256, sha256.New},
+		{p521, sha512.New},
+	} {
+		key := tc.key.key
+		name := key.Curve.Params().Name
+		h := tc.alg()
+		h.Write([]byte("sample"))
+		digest := h.Sum(nil)
+
+		r1, s1 := rfc6979.SignECDSABlinded(key, digest, tc.alg, []byte("blind #1"))
+		r2, s2 := rfc6979.SignECDSABlinded(key, digest, tc.alg, []byte("blind #1"))
+		r3, _ := rfc6979.SignECDSABlinded(key, digest, tc.alg, []byte("blind #2"))
+
+		if r1.Cmp(r2) != 0 || s1.Cmp(s2) != 0 {
+			t.Errorf("%s: Expected blinded signature to be deterministic", name)
+		}
+
+		if r1.Cmp(r3) == 0 {
+			t.Errorf("%s: Expected different blinds to give unrelated signatures", name)
+		}
+
+		// Unblinding: recompute the blinded digest from the hash.
+		blinded := rfc6979.BlindDigest(key.Curve, digest, []byte("blind #1"), tc.alg)
+		if !ecdsa.Verify(&key.PublicKey, blinded, r1, s1) {
+			t.Errorf("%s: Invalid blinded signature", name)
+		}
+
+		other := rfc6979.BlindDigest(key.Curve, digest, []byte("blind #2"), tc.alg)
+		if ecdsa.Verify(&key.PublicKey, other, r1, s1) {
+			t.Errorf("%s: Expected blinded signature not to verify with another blind", name)
+		}
+
+		if ecdsa.Verify(&key.PublicKey, digest, r1, s1) {
+			t.Errorf("%s: Expected blinded signature not to verify over the plain hash", name)
+		}
+	}
+}
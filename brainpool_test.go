@@ -0,0 +1,168 @@
+package rfc6979_test
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"math/big"
+	"testing"
+
+	"github.com/nspcc-dev/rfc6979"
+	"github.com/nspcc-dev/rfc6979/internal/ecdsatest"
+)
+
+// curveKey returns the key with the private scalar d, given in hex, on
+// the curve c.
+func curveKey(c elliptic.Curve, d string) *ecdsa.PrivateKey {
+	priv := &ecdsa.PrivateKey{D: ecdsaLoadInt(d)}
+	priv.PublicKey.Curve = c
+	priv.PublicKey.X, priv.PublicKey.Y = c.ScalarBaseMult(priv.D.Bytes())
+	return priv
+}
+
+func TestBrainpool(t *testing.T) {
+	for _, c := range []elliptic.Curve{
+		rfc6979.BrainpoolP256r1(), rfc6979.BrainpoolP384r1(), rfc6979.BrainpoolP512r1(),
+		rfc6979.BrainpoolP256t1(), rfc6979.BrainpoolP384t1(), rfc6979.BrainpoolP512t1(),
+	} {
+		params := c.Params()
+		name := params.Name
+
+		if !c.IsOnCurve(params.Gx, params.Gy) {
+			t.Fatalf("%s: Expected generator to be on the curve", name)
+		}
+		if x, y := c.ScalarBaseMult(params.N.Bytes()); x.Sign() != 0 || y.Sign() != 0 {
+			t.Errorf("%s: Expected N·G to be the point at infinity, got (%X, %X)", name, x, y)
+		}
+
+		nMinus1 := new(big.Int).Sub(params.N, big.NewInt(1))
+		x, y := c.ScalarBaseMult(nMinus1.Bytes())
+		if x.Cmp(params.Gx) != 0 || new(big.Int).Add(y, params.Gy).Cmp(params.P) != 0 {
+			t.Errorf("%s: Expected (N-1)·G to be -G", name)
+		}
+
+		dx, dy := c.Double(params.Gx, params.Gy)
+		ax, ay := c.Add(params.Gx, params.Gy, params.Gx, params.Gy)
+		tx, ty := c.ScalarBaseMult([]byte{2})
+		if dx.Cmp(ax) != 0 || dy.Cmp(ay) != 0 || dx.Cmp(tx) != 0 || dy.Cmp(ty) != 0 || !c.IsOnCurve(dx, dy) {
+			t.Errorf("%s: Expected 2·G to be consistent", name)
+		}
+	}
+
+	// RFC 7027 appendix A.1, dA.
+	key := curveKey(rfc6979.BrainpoolP256r1(), "81DB1EE100150FF2EA338D708271BE38300CB54241D79950F77B063039804F1D")
+	if key.X.Cmp(ecdsaLoadInt("44106E913F92BC02A1705D9953A8414DB95E1AAA49E81D9E85F929A8E3100BE5")) != 0 ||
+		key.Y.Cmp(ecdsaLoadInt("8AB4846F11CACCB73CE49CBDD120F5A900A69FD32C272223F789EF10EB089BDC")) != 0 {
+		t.Errorf("Unexpected public key (%X, %X)", key.X, key.Y)
+	}
+}
+
+func TestBrainpoolTwisted(t *testing.T) {
+	for _, pair := range [][2]elliptic.Curve{
+		{rfc6979.BrainpoolP256r1(), rfc6979.BrainpoolP256t1()},
+		{rfc6979.BrainpoolP384r1(), rfc6979.BrainpoolP384t1()},
+		{rfc6979.BrainpoolP512r1(), rfc6979.BrainpoolP512t1()},
+	} {
+		r1, t1 := pair[0], pair[1]
+		name := t1.Params().Name
+
+		// CurveParams implements the a = -3 arithmetic generically.
+		generic := *t1.Params()
+		k := []byte("some fixed scalar")
+		x, y := t1.ScalarBaseMult(k)
+		gx, gy := generic.ScalarBaseMult(k)
+		if x.Cmp(gx) != 0 || y.Cmp(gy) != 0 {
+			t.Errorf("%s: Expected a = -3", name)
+		}
+
+		if t1.Params().N.Cmp(r1.Params().N) != 0 || t1.Params().P.Cmp(r1.Params().P) != 0 {
+			t.Errorf("%s: Expected the field and order of %s", name, r1.Params().Name)
+		}
+		rx, ry := r1.ScalarBaseMult(k)
+		if t1.IsOnCurve(rx, ry) || r1.IsOnCurve(x, y) {
+			t.Errorf("%s: Expected points not to be shared with %s", name, r1.Params().Name)
+		}
+	}
+}
+
+// brainpoolVectors are signatures made with keys generated by OpenSSL 3.0,
+// Sig being a signature of OpenSSL over "sample\n". R and S were computed
+// by libgcrypt 1.10.1 with (flags rfc6979) and the curves named as in
+// (private-key (ecc (curve brainpoolP256r1) (q …) (d …))).
+var brainpoolVectors = []ecdsatest.Vector{
+	{
+		Curve:   rfc6979.BrainpoolP256r1,
+		Hash:    crypto.SHA256,
+		D:       "9464A36A5F0682BC3F318741B9834CD6F512C8F5F594CFA614FB815912290BDD",
+		Pub:     "048C9DCA876E57B797FE657D77D0300BA08597CA91A1CE94D8E90F81DE877B7C27810D67DA89DF6EFAFD1E886E7EC0ADA9F312C22C10E08F1D416990B831D671DF",
+		Sig:     "3044022068256F0335B78517169FFE0D9B12A50F8CC6253D9148B09556640AC723413C430220451212BF36E95ABAFAE63C5C6DDC025870CE84325FA055C2AE1B881B21E82326",
+		Message: "sample\n",
+		R:       "A4569DC90347A5BAE47AA85EE52CF42A9896F04B0F05555FD81B544B8BD04F03",
+		S:       "5A838D5CF27CB5BAEE3DB8541052F68BFD83B793BA8F4FB940A567DD28FD4BE2",
+	},
+	{
+		Curve:   rfc6979.BrainpoolP384r1,
+		Hash:    crypto.SHA384,
+		D:       "41B468FFF3ED8F8DD8003F7EF7F8B35C67806DB7F5B4057C9D6DD59F8B822B9522C90F17C0C05AE96B1F11AA12C24B26",
+		Pub:     "045608B02C260AA2C7A78303C68DFB55AF7E5B68C54F8E4A46B44325676D1B71445A459074443D64D410741B60A40FB29E5FCC1A96C86CE5E329D3B3167170062348CD908FD4D43E140E4016FE8A7CAF15A090CBBB7DD7ABA0A392735081D0DD3A",
+		Sig:     "3064023029355A0E79C66F8F449AFD0E36A82D3EE0084645D3D952E96D109CB17CDCA6ED4DB4E8F968D65C62B4BC9E91E0A9555402301A61A6724A9E56E3CB00B7B58E41556A030D5FCB796AEDD9983E504947468A715A3A4680D9284BA140D50661976A75E1",
+		Message: "sample\n",
+		R:       "47820A438D0E71A39CAAFDA537476B9992A73D56C8763D34A2289FE48908495C0817DE5ABBB5A778878BE7A5D205688E",
+		S:       "3C820650565585B2D3ECA772C97C42D7CB68CD8248F308A296EE5DB21EBDC58050568BAA175BCBF91313C6F537B82039",
+	},
+	{
+		Curve:   rfc6979.BrainpoolP512r1,
+		Hash:    crypto.SHA512,
+		D:       "9B06C13EE631904784D22DFE03EC37684DBDCB1CD5D4B79CE25A79CD885E1F4ED217C050823C3EF5F59C5BC7BECCCBDA29A0A9286EB8FC50AE988C0B21265408",
+		Pub:     "0443A5691824829D6268ED9B458CAA25D00340F19E40E7052712F44B64E1749C1186E38B5AB34946AC2729247F9E1618917249CE5232500D0429B914294C5D2B132D84BCD7F10FEF76CE34478A0A676280F0FEA8FF1B4C148A8764FA70FD6A1C8E4204D0F0AD4148829163D94F40E7B48AA69332204DD46856B731D1732AEA8EFE",
+		Sig:     "308184024064EC2BEFEFB782894B9257828BAAF9FAAEE948884787B81F84A3B58CE69EB7FC27EA7BAC5B0328A62B893CD7060D825AF4BBB541983B3A9CBBBDBEE3220364DA024000C120AE52FDB6C02A913B1B122DF6FA98F440BFDE54545A340E60104D22BAECACA19D67DFC260119096ED8309A71024414B1EEA23A7BCA3DEC2B02102F87A64",
+		Message: "sample\n",
+		R:       "229ACC0BE4318D8DAA24CEB29E3564D53D3828840826297122077562E4C6737276FF8C9FBBFF669CAC90E0D522D38C3E8FE67A996B65CC003A54FFF29AEE4145",
+		S:       "1B0BE2C6507713A97D2EBCDFADFBD067165672C26FE6B88235FE745DD4BAF97DABAB1DA19AD509C94B72511A7A004ACB7FBD267F4C6C3E3F8500B0254EDDC34C",
+	},
+}
+
+// brainpoolTwistedVectors are signatures made with keys generated by OpenSSL
+// 3.0, Sig being a signature of OpenSSL over "sample\n". R and S were
+// computed by libgcrypt 1.10.1 with (flags rfc6979); it doesn't know the t1
+// curves, so they were given by their RFC 5639 parameters as in
+// (private-key (ecc (p …) (a …) (b …) (g …) (n …) (h #01#) (q …) (d …))).
+var brainpoolTwistedVectors = []ecdsatest.Vector{
+	{
+		Curve:   rfc6979.BrainpoolP256t1,
+		Hash:    crypto.SHA256,
+		D:       "861AB8573DCAB0FB50AF60547B2A1A479B7E65135E15BFA065F10F96EF88F93B",
+		Pub:     "0408D117A083C8F8F9EE62FA9C99513783E93C9886D9283CDDA45A8A173D7333E5813F8E06A4592CC339F488BB7C6B6BF240513AC17F837BC0DB6627EF4310BC4D",
+		Sig:     "30440220008CB0A9C4F6D08F1CE02D32FDB3E0D4818E36C826D873EC0A946A9D7277B35402201172D7185EA7D1EFBA3B9D21A37B4FC0E4433A19A23FBD26291EC60C06611CBF",
+		Message: "sample\n",
+		R:       "3D24F0F0CBB1376631E385F4BD2D72606CE8FA99CE6DFEA0E935870EAB2C4208",
+		S:       "861C32AAD73CA3C3FF3586BC499D15218E85CBE6FED4E1CEBD08075528BCAF31",
+	},
+	{
+		Curve:   rfc6979.BrainpoolP384t1,
+		Hash:    crypto.SHA384,
+		D:       "7DEB85FB2EA36CC7D341BBA08CB54B19143FAC9760097C6461D134F9009956B02EFCF0D06FE0B7CCC3D5743509D52EBB",
+		Pub:     "043ACB953C3D8C6A038FCC204C5CC36BAF3ADB8D53AC563D01199E204B59367F0C1A1F65566A3520BF18F374B2434238A97385DADC8DD0BD67D393BDDA13519FBFF54A08B222C3C72229A295202BBCA4BADAB09A77A5ABEBDFF067A1AF91F32F77",
+		Sig:     "3064023040C81AB8E4B1B71BF42493E86E178AE0A687F0B42BCE646373970433256DA1DEA07F2CCA9BE565B9ECCFA22A8D36B66D023070F2BD68DC5C494B225EAEE34A1FEDB2B1AF2343829DCA822D4EB37707DD6390F5BFF76B6558462DEADC98CDC4C55A27",
+		Message: "sample\n",
+		R:       "808F7034C58438DD11CB7447CC04B5E083B450FEAE49A2EC396A66C155DFB6576C3C54F3C47D4E3421F0DEA3A602FC40",
+		S:       "840C7DF3175410ADFFC6F488B47740BB8ACE882BFE353C0074DD4090360D4CE6267855AF0F101F89079CF7AE84D3A490",
+	},
+	{
+		Curve:   rfc6979.BrainpoolP512t1,
+		Hash:    crypto.SHA512,
+		D:       "12AE36BEAF50597553FC80F783F25BEE3C582498C3314114E2DB2BD5078E39B94A7D31E6AB6317B6F9897A58D4D79D25592D2A05AB3AC58DB102195070C48B5B",
+		Pub:     "044D485D54321C1F06DC8354674FE5CB5EDF945341BD40989CECF5BE4A7835B3CF179D1E2393EC8212FEF1BC9044A9EFFCEB6EEAB89081213455CE792686715FC8A3C36A332BEB8F9C49764B7638779EA1C7FC02CB73249F84019C53AFF4609903A1456B0D8BAA233268C0DA0784B059F479B2B4B36CD487F8E0D9A8DD49B79BCF",
+		Sig:     "308184024053E6D5C0A2A5EB082F838E1E385AF8413F5FD4749C42EC13B86C5A615E05CF1F87D453CFEEE58E54633623ED1F74F4904D78BAA099CF46F01A397A58B760FD1702405C8D40E1CFD66EB08BAEB8A679C200B6D1D3C8FF08442D1F2FF11D136E1BB2CE24C68887F0FFD23DFAD35F92D11415362185B349E9741E26D02D90BB3B9EAB67",
+		Message: "sample\n",
+		R:       "852100B38139422DB8B8112275420A9247306FB976177C9904DF6E6C3842664DD250BB1F0642D61EC07C2D54FDDE59DA773AFF8C066CE8EA2016EFBD276E1B8A",
+		S:       "9F55308274AC6AD5E9F3A9FF2670D6A2C1AD45F10BB31D9F1FF5E09C017AE1542CA94B12155B3F5BEB17C59F57F555F19B6EEC925B15AD248BAB998B66FA1340",
+	},
+}
+
+func TestBrainpoolVectors(t *testing.T) {
+	ecdsatest.Check(t, brainpoolVectors)
+	ecdsatest.CheckRecovery(t, brainpoolVectors)
+	ecdsatest.Check(t, brainpoolTwistedVectors)
+	ecdsatest.CheckRecovery(t, brainpoolTwistedVectors)
+}
@@ -0,0 +1,29 @@
+package rfc6979
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"hash"
+)
+
+// ErrDuplicateCurve is returned by SignECDSABundle when several keys share
+// a curve.
+var ErrDuplicateCurve = errors.New("rfc6979: duplicate curve in key bundle")
+
+// SignECDSABundle signs the message with every key of the bundle, which must
+// be on distinct curves, as SignECDSAMessage does. It returns the signatures
+// keyed by curve name (e.g. "P-256"), which is useful for dual-signing while
+// migrating from one curve to another.
+func SignECDSABundle(keys []*ecdsa.PrivateKey, message []byte, alg func() hash.Hash) (map[string]Signature, error) {
+	sigs := make(map[string]Signature, len(keys))
+	for _, k := range keys {
+		name := k.Curve.Params().Name
+		if _, ok := sigs[name]; ok {
+			return nil, ErrDuplicateCurve
+		}
+
+		r, s := SignECDSAMessage(k, message, alg)
+		sigs[name] = Signature{R: r, S: s, Curve: k.Curve}
+	}
+	return sigs, nil
+}
@@ -0,0 +1,53 @@
+package rfc6979_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/sha512"
+	"testing"
+
+	"github.com/nspcc-dev/rfc6979"
+)
+
+func TestSignECDSABundle(t *testing.T) {
+	message := []byte("sample")
+	keys := []*ecdsa.PrivateKey{p256.key, p384.key}
+
+	sigs, err := rfc6979.SignECDSABundle(keys, message, sha512.New)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(sigs) != 2 {
+		t.Fatalf("Expected 2 signatures, got %d", len(sigs))
+	}
+
+	// RFC 6979 appendix A.2.5 and A.2.6, SHA-512 with message "sample".
+	expected := map[string][2]string{
+		"P-256": {
+			"8496A60B5E9B47C825488827E0495B0E3FA109EC4568FD3F8D1097678EB97F00",
+			"2362AB1ADBE2B8ADF9CB9EDAB740EA6049C028114F2460F96554F61FAE3302FE",
+		},
+		"P-384": {
+			"ED0959D5880AB2D869AE7F6C2915C6D60F96507F9CB3E047C0046861DA4A799CFE30F35CC900056D7C99CD7882433709",
+			"512C8CCEEE3890A84058CE1E22DBC2198F42323CE8ACA9135329F03C068E5112DC7CC3EF3446DEFCEB01A45C2667FDD5",
+		},
+	}
+
+	digest := sha512.Sum512(message)
+	for i, k := range keys {
+		name := k.Curve.Params().Name
+		sig := sigs[name]
+
+		if sig.R.Cmp(ecdsaLoadInt(expected[name][0])) != 0 || sig.S.Cmp(ecdsaLoadInt(expected[name][1])) != 0 {
+			t.Errorf("%s: Expected the RFC vector, got %X, %X", name, sig.R, sig.S)
+		}
+
+		if !ecdsa.Verify(&keys[i].PublicKey, digest[:], sig.R, sig.S) {
+			t.Errorf("%s: Invalid signature", name)
+		}
+	}
+
+	if _, err := rfc6979.SignECDSABundle([]*ecdsa.PrivateKey{p256.key, p256.key}, message, sha512.New); err != rfc6979.ErrDuplicateCurve {
+		t.Errorf("Expected %v, got %v", rfc6979.ErrDuplicateCurve, err)
+	}
+}
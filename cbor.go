@@ -0,0 +1,163 @@
+package rfc6979
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"errors"
+	"math/big"
+
+	"github.com/nspcc-dev/rfc6979/internal/cbor"
+)
+
+// ErrInvalidCBOR is returned when CBOR data can't be decoded as a signature
+// or a COSE key.
+var ErrInvalidCBOR = errors.New("rfc6979: invalid CBOR")
+
+// COSE key labels and values, RFC 9052 section 7 and RFC 9053 section 7.1.
+const (
+	coseKeyKty = 1
+	coseKeyCrv = -1
+	coseKeyX   = -2
+	coseKeyY   = -3
+
+	coseKtyEC2 = 2
+)
+
+// coseCurves maps the COSE Elliptic Curves registry values to curves, P-256,
+// P-384 and P-521 are registered by RFC 9053, secp256k1 by RFC 8812.
+var coseCurves = map[int64]func() elliptic.Curve{
+	1: elliptic.P256,
+	2: elliptic.P384,
+	3: elliptic.P521,
+	8: Secp256k1,
+}
+
+// coseCurveID returns the COSE identifier of c or 0 if there's none.
+func coseCurveID(c elliptic.Curve) int64 {
+	for id, newCurve := range coseCurves {
+		if newCurve().Params().Name == c.Params().Name {
+			return id
+		}
+	}
+	return 0
+}
+
+// MarshalCBOR encodes the signature as a CBOR byte string holding r || s,
+// both left-padded to the byte length of the curve order, which is the
+// signature format of COSE (RFC 9053 section 2.1).
+func (sig Signature) MarshalCBOR() ([]byte, error) {
+	if sig.Curve == nil {
+		return nil, ErrInvalidCBOR
+	}
+	raw, err := MarshalCompact(sig.Curve, sig.R, sig.S)
+	if err != nil {
+		return nil, err
+	}
+	return cbor.AppendBytes(nil, raw), nil
+}
+
+// UnmarshalCBOR decodes the output of MarshalCBOR. The COSE format doesn't
+// carry the curve, which is known from the key or the algorithm, so Curve
+// must be set beforehand. The byte string must be exactly as long as
+// MarshalCBOR makes it and both components within [1, N-1].
+func (sig *Signature) UnmarshalCBOR(data []byte) error {
+	if sig.Curve == nil {
+		return ErrInvalidCBOR
+	}
+	raw, rest, ok := cbor.ReadBytes(data)
+	if !ok || len(rest) != 0 {
+		return ErrInvalidCBOR
+	}
+	r, s, err := UnmarshalCompact(sig.Curve, raw)
+	if err != nil {
+		return err
+	}
+	sig.R, sig.S = r, s
+	return nil
+}
+
+// MarshalCOSEKey encodes the public key as a COSE_Key of type EC2 (RFC 9053
+// section 7.1.1) with the kty, crv, x and y parameters, in the core
+// deterministic encoding of RFC 8949 section 4.2.1. Only P-256, P-384, P-521
+// and secp256k1 have COSE identifiers.
+func MarshalCOSEKey(pub *ecdsa.PublicKey) ([]byte, error) {
+	crv := coseCurveID(pub.Curve)
+	if crv == 0 {
+		return nil, ErrUnsupportedCurve
+	}
+	size := CoordinateSize(pub.Curve)
+	if pub.X.Sign() < 0 || pub.Y.Sign() < 0 || pub.X.BitLen() > 8*size || pub.Y.BitLen() > 8*size {
+		return nil, ErrInvalidCBOR
+	}
+
+	// Labels are sorted by their encoding: 1, -1, -2, -3.
+	out := cbor.AppendHead(nil, cbor.Map, 4)
+	out = cbor.AppendInt(out, coseKeyKty)
+	out = cbor.AppendInt(out, coseKtyEC2)
+	out = cbor.AppendInt(out, coseKeyCrv)
+	out = cbor.AppendInt(out, crv)
+	out = cbor.AppendInt(out, coseKeyX)
+	out = cbor.AppendBytes(out, pub.X.FillBytes(make([]byte, size)))
+	out = cbor.AppendInt(out, coseKeyY)
+	out = cbor.AppendBytes(out, pub.Y.FillBytes(make([]byte, size)))
+	return out, nil
+}
+
+// ParseCOSEKey decodes an EC2 COSE_Key public key. The kty, crv, x and y
+// parameters are required, x and y must be byte strings as long as the
+// coordinates of the curve and the point must be on the curve; compressed
+// points, given by a boolean y, aren't supported. Other parameters, like kid
+// or alg, are ignored.
+func ParseCOSEKey(data []byte) (*ecdsa.PublicKey, error) {
+	n, rest, ok := cbor.ReadHead(data, cbor.Map)
+	if !ok {
+		return nil, ErrInvalidCBOR
+	}
+
+	var (
+		kty, crv int64
+		x, y     []byte
+		seen     = make(map[int64]bool)
+	)
+	for i := uint64(0); i < n; i++ {
+		var label int64
+		if label, rest, ok = cbor.ReadInt(rest); !ok || seen[label] {
+			return nil, ErrInvalidCBOR
+		}
+		seen[label] = true
+
+		switch label {
+		case coseKeyKty:
+			kty, rest, ok = cbor.ReadInt(rest)
+		case coseKeyCrv:
+			crv, rest, ok = cbor.ReadInt(rest)
+		case coseKeyX:
+			x, rest, ok = cbor.ReadBytes(rest)
+		case coseKeyY:
+			y, rest, ok = cbor.ReadBytes(rest)
+		default:
+			rest, ok = cbor.Skip(rest)
+		}
+		if !ok {
+			return nil, ErrInvalidCBOR
+		}
+	}
+	if len(rest) != 0 || kty != coseKtyEC2 {
+		return nil, ErrInvalidCBOR
+	}
+
+	newCurve, ok := coseCurves[crv]
+	if !ok {
+		return nil, ErrUnsupportedCurve
+	}
+	c := newCurve()
+	size := CoordinateSize(c)
+	if len(x) != size || len(y) != size {
+		return nil, ErrInvalidCBOR
+	}
+	pub := &ecdsa.PublicKey{Curve: c, X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}
+	if !c.IsOnCurve(pub.X, pub.Y) {
+		return nil, ErrInvalidCBOR
+	}
+	return pub, nil
+}
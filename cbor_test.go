@@ -0,0 +1,135 @@
+package rfc6979_test
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"encoding/hex"
+	"testing"
+
+	"github.com/nspcc-dev/rfc6979"
+)
+
+func TestSignatureCBOR(t *testing.T) {
+	for _, f := range fixtures {
+		c := f.key.key.Curve
+		sig := rfc6979.Signature{R: ecdsaLoadInt(f.r), S: ecdsaLoadInt(f.s), Curve: c}
+
+		data, err := sig.MarshalCBOR()
+		if err != nil {
+			t.Fatalf("%s: %v", f.name, err)
+		}
+		raw, _ := rfc6979.EncodeP1363(c, sig.R, sig.S)
+		if !bytes.Equal(data[len(data)-len(raw):], raw) || data[0]>>5 != 2 {
+			t.Errorf("%s: Expected a byte string of r || s, got %X", f.name, data)
+		}
+
+		got := rfc6979.Signature{Curve: c}
+		if err := got.UnmarshalCBOR(data); err != nil || got.R.Cmp(sig.R) != 0 || got.S.Cmp(sig.S) != 0 {
+			t.Errorf("%s: Expected round trip, got %v", f.name, err)
+		}
+
+		for _, bad := range [][]byte{nil, data[:len(data)-1], append(data, 0), append([]byte{0x5f}, data[1:]...)} {
+			got := rfc6979.Signature{Curve: c}
+			if err := got.UnmarshalCBOR(bad); err == nil {
+				t.Errorf("%s: Expected %X to be rejected", f.name, bad)
+			}
+		}
+	}
+
+	// 64 bytes take a one-byte length.
+	sig := rfc6979.Signature{R: ecdsaLoadInt("01"), S: ecdsaLoadInt("02"), Curve: elliptic.P256()}
+	data, err := sig.MarshalCBOR()
+	if err != nil || len(data) != 66 || data[0] != 0x58 || data[1] != 64 {
+		t.Errorf("Unexpected %X, %v", data, err)
+	}
+	if err := new(rfc6979.Signature).UnmarshalCBOR(data); err != rfc6979.ErrInvalidCBOR {
+		t.Errorf("Expected %v without a curve, got %v", rfc6979.ErrInvalidCBOR, err)
+	}
+	// Same, but with a two-byte length.
+	long := append([]byte{0x59, 0, 64}, data[2:]...)
+	if err := (&rfc6979.Signature{Curve: elliptic.P256()}).UnmarshalCBOR(long); err != rfc6979.ErrInvalidCBOR {
+		t.Errorf("Expected %v for a non-shortest length, got %v", rfc6979.ErrInvalidCBOR, err)
+	}
+}
+
+// The public key of RFC 9052 appendix C.7.1.
+const (
+	coseKeyX   = "65eda5a12577c2bae829437fe338701a10aaa375e1bb5b5de108de439c08551d"
+	coseKeyY   = "1e52ed75701163f7f9e40ddf9f341b3dc9ba860af7e0ca7ca7e9eecd0084d19c"
+	coseKeyKid = "6d65726961646f632e6272616e64796275636b406275636b6c616e642e6578616d706c65"
+
+	// kty: EC2, crv: P-256, x, y.
+	coseKty  = "0102"
+	coseCrv  = "2001"
+	coseX    = "215820" + coseKeyX
+	coseY    = "225820" + coseKeyY
+	coseKeyP = "a4" + coseKty + coseCrv + coseX + coseY
+)
+
+func TestCOSEKey(t *testing.T) {
+	// The example also has a kid, which is skipped.
+	data, _ := hex.DecodeString("a5" + coseKty + "025824" + coseKeyKid + coseCrv + coseX + coseY)
+	pub, err := rfc6979.ParseCOSEKey(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pub.Curve != elliptic.P256() || pub.X.Cmp(ecdsaLoadInt(coseKeyX)) != 0 || pub.Y.Cmp(ecdsaLoadInt(coseKeyY)) != 0 {
+		t.Errorf("Unexpected key %+v", pub)
+	}
+
+	out, err := rfc6979.MarshalCOSEKey(pub)
+	expected, _ := hex.DecodeString(coseKeyP)
+	if err != nil || !bytes.Equal(out, expected) {
+		t.Errorf("Expected %X, got %X, %v", expected, out, err)
+	}
+
+	keys := []*ecdsa.PrivateKey{p256.key, p384.key, p521.key, secp256k1Key(secp256k1Vectors[1].d)}
+	for _, key := range keys {
+		name := key.Curve.Params().Name
+		out, err := rfc6979.MarshalCOSEKey(&key.PublicKey)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		pub, err := rfc6979.ParseCOSEKey(out)
+		if err != nil || pub.Curve != key.Curve || pub.X.Cmp(key.X) != 0 || pub.Y.Cmp(key.Y) != 0 {
+			t.Errorf("%s: Expected round trip, got %v", name, err)
+		}
+	}
+
+	if _, err := rfc6979.MarshalCOSEKey(&p224.key.PublicKey); err != rfc6979.ErrUnsupportedCurve {
+		t.Errorf("Expected %v for P-224, got %v", rfc6979.ErrUnsupportedCurve, err)
+	}
+}
+
+func TestParseCOSEKeyInvalid(t *testing.T) {
+	for name, v := range map[string]string{
+		"empty":           "",
+		"array":           "84" + coseKty + coseCrv,
+		"OKP":             "a4" + "0101" + coseCrv + coseX + coseY,
+		"no kty":          "a3" + coseCrv + coseX + coseY,
+		"duplicate label": "a5" + coseKty + coseCrv + coseX + coseX + coseY,
+		"unknown curve":   "a4" + coseKty + "2004" + coseX + coseY,
+		"short x":         "a4" + coseKty + coseCrv + "21581f" + coseKeyX[2:] + coseY,
+		"compressed":      "a4" + coseKty + coseCrv + coseX + "22f5",
+		"not on curve":    "a4" + coseKty + coseCrv + coseX + "225820" + coseKeyX,
+		"trailing data":   coseKeyP + "00",
+		"missing entry":   "a5" + coseKty + coseCrv + coseX + coseY,
+		"long form kty":   "a4" + "011802" + coseCrv + coseX + coseY,
+		"indefinite map":  "bf" + coseKty + coseCrv + coseX + coseY + "ff",
+		"tagged kid":      "a5" + coseKty + "02c140" + coseCrv + coseX + coseY,
+	} {
+		data, err := hex.DecodeString(v)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if _, err := rfc6979.ParseCOSEKey(data); err == nil {
+			t.Errorf("%s: Expected an error", name)
+		}
+	}
+
+	data, _ := hex.DecodeString(coseKeyP)
+	if _, err := rfc6979.ParseCOSEKey(data); err != nil {
+		t.Errorf("Expected the valid key to be accepted, got %v", err)
+	}
+}
@@ -0,0 +1,352 @@
+/*
+Package cms wraps content into CMS SignedData structures (RFC 5652) signed
+with ECDSA (RFC 5753) using RFC 6979 deterministic signatures, as used by
+S/MIME and code signing. The signature covers the signed attributes, which
+hold the content type, the content digest and optionally the signing time,
+so structures are identical for the same inputs.
+*/
+package cms
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	_ "crypto/sha256" // P-256
+	_ "crypto/sha512" // P-384, P-521
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"errors"
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/nspcc-dev/rfc6979"
+)
+
+var (
+	oidData          = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 7, 1}
+	oidSignedData    = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 7, 2}
+	oidContentType   = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 3}
+	oidMessageDigest = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 4}
+	oidSigningTime   = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 5}
+)
+
+var (
+	// ErrInvalidKey is returned for keys on curves other than P-256, P-384
+	// and P-521 and for keys not matching the certificate.
+	ErrInvalidKey = errors.New("cms: invalid key")
+
+	// ErrInvalidMessage is returned for malformed SignedData structures
+	// and ones this package can't verify.
+	ErrInvalidMessage = errors.New("cms: invalid message")
+)
+
+// algorithm describes the digest and signature algorithms used with a
+// curve, RFC 5753 section 7.1.
+type algorithm struct {
+	curve     func() elliptic.Curve
+	hash      crypto.Hash
+	digest    asn1.ObjectIdentifier
+	signature asn1.ObjectIdentifier
+}
+
+// algorithms lists the supported curves.
+var algorithms = []algorithm{
+	{elliptic.P256, crypto.SHA256, asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 2, 1}, asn1.ObjectIdentifier{1, 2, 840, 10045, 4, 3, 2}},
+	{elliptic.P384, crypto.SHA384, asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 2, 2}, asn1.ObjectIdentifier{1, 2, 840, 10045, 4, 3, 3}},
+	{elliptic.P521, crypto.SHA512, asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 2, 3}, asn1.ObjectIdentifier{1, 2, 840, 10045, 4, 3, 4}},
+}
+
+// lookup returns the algorithm of the curve c.
+func lookup(c elliptic.Curve) (algorithm, error) {
+	for _, a := range algorithms {
+		if a.curve().Params().Name == c.Params().Name {
+			return a, nil
+		}
+	}
+	return algorithm{}, ErrInvalidKey
+}
+
+// ASN.1 structures of RFC 5652.
+type (
+	contentInfo struct {
+		ContentType asn1.ObjectIdentifier
+
+		// Content is the [0] EXPLICIT content, the tag is set by Sign.
+		Content asn1.RawValue
+	}
+
+	signedData struct {
+		Version          int
+		DigestAlgorithms []pkix.AlgorithmIdentifier `asn1:"set"`
+		EncapContentInfo encapContentInfo
+		Certificates     asn1.RawValue `asn1:"optional"`
+		SignerInfos      []signerInfo  `asn1:"set"`
+	}
+
+	encapContentInfo struct {
+		EContentType asn1.ObjectIdentifier
+		EContent     []byte `asn1:"explicit,optional,tag:0"`
+	}
+
+	signerInfo struct {
+		Version            int
+		SID                issuerAndSerialNumber
+		DigestAlgorithm    pkix.AlgorithmIdentifier
+		SignedAttrs        asn1.RawValue `asn1:"optional"`
+		SignatureAlgorithm pkix.AlgorithmIdentifier
+		Signature          []byte
+	}
+
+	issuerAndSerialNumber struct {
+		Issuer       asn1.RawValue
+		SerialNumber *big.Int
+	}
+
+	attribute struct {
+		Type   asn1.ObjectIdentifier
+		Values []asn1.RawValue `asn1:"set"`
+	}
+)
+
+// Options configure Sign, nil means the defaults.
+type Options struct {
+	// Detached leaves the content out of the structure, it has to be
+	// supplied to verifiers separately.
+	Detached bool
+
+	// SigningTime is put into the signing-time attribute unless it's zero,
+	// leaving it out keeps the structure dependent on the content and the
+	// key only.
+	SigningTime time.Time
+}
+
+// Sign returns a DER encoded ContentInfo holding a SignedData structure over
+// the content signed with the private key, priv, which must match the
+// certificate. The certificate is included and identifies the signer by
+// its issuer and serial number. The content is hashed with the hash of the
+// key's curve: SHA-256 for P-256, SHA-384 for P-384 and SHA-512 for P-521.
+func Sign(content []byte, cert *x509.Certificate, priv *ecdsa.PrivateKey, opts *Options) ([]byte, error) {
+	if opts == nil {
+		opts = new(Options)
+	}
+	a, err := lookup(priv.Curve)
+	if err != nil {
+		return nil, err
+	}
+	if pub, ok := cert.PublicKey.(*ecdsa.PublicKey); !ok || !pub.Equal(&priv.PublicKey) {
+		return nil, ErrInvalidKey
+	}
+
+	attrs := []attribute{
+		{Type: oidContentType, Values: []asn1.RawValue{mustRaw(oidData)}},
+		{Type: oidMessageDigest, Values: []asn1.RawValue{mustRaw(digest(a.hash, content))}},
+	}
+	if !opts.SigningTime.IsZero() {
+		attrs = append(attrs, attribute{Type: oidSigningTime, Values: []asn1.RawValue{mustRaw(opts.SigningTime.UTC())}})
+	}
+	attrsBody, err := marshalAttributes(attrs)
+	if err != nil {
+		return nil, err
+	}
+	// The [0] IMPLICIT tag of SignerInfo is replaced by the SET one for
+	// signing, RFC 5652 section 5.4.
+	signedAttrs := append(appendSetHeader(nil, len(attrsBody)), attrsBody...)
+
+	r, s := rfc6979.SignECDSA(priv, digest(a.hash, signedAttrs), a.hash.New)
+	sig, err := rfc6979.MarshalSignatureDER(r, s)
+	if err != nil {
+		return nil, err
+	}
+
+	digestAlg := pkix.AlgorithmIdentifier{Algorithm: a.digest}
+	sd := signedData{
+		Version:          1,
+		DigestAlgorithms: []pkix.AlgorithmIdentifier{digestAlg},
+		EncapContentInfo: encapContentInfo{EContentType: oidData},
+		Certificates:     contextTag(cert.Raw),
+		SignerInfos: []signerInfo{{
+			Version:            1,
+			SID:                issuerAndSerialNumber{Issuer: asn1.RawValue{FullBytes: cert.RawIssuer}, SerialNumber: cert.SerialNumber},
+			DigestAlgorithm:    digestAlg,
+			SignedAttrs:        contextTag(attrsBody),
+			SignatureAlgorithm: pkix.AlgorithmIdentifier{Algorithm: a.signature},
+			Signature:          sig,
+		}},
+	}
+	if !opts.Detached {
+		sd.EncapContentInfo.EContent = append([]byte{}, content...)
+	}
+	inner, err := asn1.Marshal(sd)
+	if err != nil {
+		return nil, err
+	}
+	return asn1.Marshal(contentInfo{ContentType: oidSignedData, Content: contextTag(inner)})
+}
+
+// Verify verifies the first signer of a DER encoded SignedData structure
+// made by Sign or a compatible implementation, e.g. OpenSSL, and returns
+// the content and the signer's certificate. detached is the content if
+// it's not in the structure, it must be nil otherwise. The certificate is
+// only used for its key: its validity and chain are up to the caller.
+// Signer infos without signed attributes aren't supported.
+func Verify(der, detached []byte) (content []byte, cert *x509.Certificate, err error) {
+	var ci contentInfo
+	if rest, err := asn1.Unmarshal(der, &ci); err != nil || len(rest) != 0 || !ci.ContentType.Equal(oidSignedData) || !isContextTag(ci.Content) {
+		return nil, nil, ErrInvalidMessage
+	}
+	var sd signedData
+	if rest, err := asn1.Unmarshal(ci.Content.Bytes, &sd); err != nil || len(rest) != 0 || len(sd.SignerInfos) == 0 {
+		return nil, nil, ErrInvalidMessage
+	}
+	content = sd.EncapContentInfo.EContent
+	if (content == nil) == (detached == nil) {
+		return nil, nil, ErrInvalidMessage
+	}
+	if content == nil {
+		content = detached
+	}
+
+	si := sd.SignerInfos[0]
+	certs, err := x509.ParseCertificates(sd.Certificates.Bytes)
+	if err != nil || !isContextTag(sd.Certificates) {
+		return nil, nil, ErrInvalidMessage
+	}
+	for _, c := range certs {
+		if bytes.Equal(c.RawIssuer, si.SID.Issuer.FullBytes) && c.SerialNumber.Cmp(si.SID.SerialNumber) == 0 {
+			cert = c
+			break
+		}
+	}
+	if cert == nil {
+		return nil, nil, ErrInvalidMessage
+	}
+	pub, ok := cert.PublicKey.(*ecdsa.PublicKey)
+	if !ok {
+		return nil, nil, ErrInvalidKey
+	}
+
+	var a algorithm
+	for _, alg := range algorithms {
+		if alg.digest.Equal(si.DigestAlgorithm.Algorithm) {
+			a = alg
+		}
+	}
+	if a.hash == 0 || !isContextTag(si.SignedAttrs) {
+		return nil, nil, ErrInvalidMessage
+	}
+	signedAttrs := append(appendSetHeader(nil, len(si.SignedAttrs.Bytes)), si.SignedAttrs.Bytes...)
+	if err := checkAttributes(signedAttrs, sd.EncapContentInfo.EContentType, digest(a.hash, content)); err != nil {
+		return nil, nil, err
+	}
+
+	r, s, err := rfc6979.ParseDERStrict(si.Signature)
+	if err != nil {
+		return nil, nil, ErrInvalidMessage
+	}
+	if !rfc6979.VerifyECDSA(pub, digest(a.hash, signedAttrs), r, s) {
+		return nil, nil, rfc6979.ErrInvalidSignature
+	}
+	return content, cert, nil
+}
+
+// marshalAttributes returns the DER encoded attributes in the order of a
+// SET OF, without the SET header.
+func marshalAttributes(attrs []attribute) ([]byte, error) {
+	encoded := make([][]byte, len(attrs))
+	for i := range attrs {
+		b, err := asn1.Marshal(attrs[i])
+		if err != nil {
+			return nil, err
+		}
+		encoded[i] = b
+	}
+	sort.Slice(encoded, func(i, j int) bool { return bytes.Compare(encoded[i], encoded[j]) < 0 })
+	return bytes.Join(encoded, nil), nil
+}
+
+// checkAttributes checks that the signed attributes hold the content type
+// and the message digest, once each.
+func checkAttributes(signedAttrs []byte, contentType asn1.ObjectIdentifier, md []byte) error {
+	var attrs []attribute
+	if rest, err := asn1.UnmarshalWithParams(signedAttrs, &attrs, "set"); err != nil || len(rest) != 0 {
+		return ErrInvalidMessage
+	}
+
+	var seenType, seenDigest bool
+	for _, attr := range attrs {
+		switch {
+		case attr.Type.Equal(oidContentType):
+			var ct asn1.ObjectIdentifier
+			if seenType || len(attr.Values) != 1 || unmarshal(attr.Values[0], &ct) != nil || !ct.Equal(contentType) {
+				return ErrInvalidMessage
+			}
+			seenType = true
+		case attr.Type.Equal(oidMessageDigest):
+			var d []byte
+			if seenDigest || len(attr.Values) != 1 || unmarshal(attr.Values[0], &d) != nil {
+				return ErrInvalidMessage
+			}
+			if !bytes.Equal(d, md) {
+				return rfc6979.ErrInvalidSignature
+			}
+			seenDigest = true
+		}
+	}
+	if !seenType || !seenDigest {
+		return ErrInvalidMessage
+	}
+	return nil
+}
+
+// unmarshal decodes a raw value, rejecting trailing data.
+func unmarshal(raw asn1.RawValue, v interface{}) error {
+	rest, err := asn1.Unmarshal(raw.FullBytes, v)
+	if err == nil && len(rest) != 0 {
+		err = ErrInvalidMessage
+	}
+	return err
+}
+
+// contextTag returns the content as [0], which is EXPLICIT or IMPLICIT
+// depending on whether it's a single element or the body of a SET.
+func contextTag(content []byte) asn1.RawValue {
+	return asn1.RawValue{Class: asn1.ClassContextSpecific, Tag: 0, IsCompound: true, Bytes: content}
+}
+
+// isContextTag reports whether the value is a constructed [0].
+func isContextTag(v asn1.RawValue) bool {
+	return v.Class == asn1.ClassContextSpecific && v.Tag == 0 && v.IsCompound
+}
+
+// mustRaw returns the DER encoding of an attribute value, which can't fail
+// for the types used here.
+func mustRaw(v interface{}) asn1.RawValue {
+	b, err := asn1.Marshal(v)
+	if err != nil {
+		panic(err)
+	}
+	return asn1.RawValue{FullBytes: b}
+}
+
+// appendSetHeader appends the DER header of a SET with n bytes of content.
+func appendSetHeader(b []byte, n int) []byte {
+	b = append(b, 0x31)
+	if n < 0x80 {
+		return append(b, byte(n))
+	}
+	var l []byte
+	for ; n > 0; n >>= 8 {
+		l = append([]byte{byte(n)}, l...)
+	}
+	return append(append(b, 0x80|byte(len(l))), l...)
+}
+
+// digest hashes the data with h.
+func digest(h crypto.Hash, data []byte) []byte {
+	d := h.New()
+	d.Write(data)
+	return d.Sum(nil)
+}
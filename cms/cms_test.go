@@ -0,0 +1,145 @@
+package cms_test
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nspcc-dev/rfc6979"
+	"github.com/nspcc-dev/rfc6979/cms"
+)
+
+// newCert returns a self-signed certificate for a key derived from the
+// name, both deterministic.
+func newCert(t *testing.T, c elliptic.Curve, name string) (*x509.Certificate, *ecdsa.PrivateKey) {
+	priv := &ecdsa.PrivateKey{D: new(big.Int).SetBytes([]byte(name))}
+	priv.Curve = c
+	priv.X, priv.Y = c.ScalarBaseMult(priv.D.Bytes())
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    time.Unix(1700000000, 0),
+		NotAfter:     time.Unix(1900000000, 0),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageEmailProtection},
+	}
+	der, err := x509.CreateCertificate(nil, tmpl, tmpl, &priv.PublicKey, rfc6979.NewSigner(priv))
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cert, priv
+}
+
+var content = []byte("hello, world\n")
+
+func TestSign(t *testing.T) {
+	for _, c := range []elliptic.Curve{elliptic.P256(), elliptic.P384(), elliptic.P521()} {
+		name := c.Params().Name
+		cert, priv := newCert(t, c, "cms test "+name)
+
+		for _, opts := range []*cms.Options{nil, {SigningTime: time.Unix(1700000000, 0)}} {
+			der, err := cms.Sign(content, cert, priv, opts)
+			if err != nil {
+				t.Fatalf("%s: %v", name, err)
+			}
+			again, _ := cms.Sign(content, cert, priv, opts)
+			if !bytes.Equal(der, again) {
+				t.Errorf("%s: Expected a deterministic structure", name)
+			}
+			got, signer, err := cms.Verify(der, nil)
+			if err != nil || !bytes.Equal(got, content) || !signer.Equal(cert) {
+				t.Errorf("%s: Expected the content, got %q, %v", name, got, err)
+			}
+			if _, _, err := cms.Verify(der, content); err != cms.ErrInvalidMessage {
+				t.Errorf("%s: Expected %v, got %v", name, cms.ErrInvalidMessage, err)
+			}
+		}
+
+		der, err := cms.Sign(content, cert, priv, &cms.Options{Detached: true})
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if bytes.Contains(der, content) {
+			t.Errorf("%s: Expected the content to be left out", name)
+		}
+		if _, _, err := cms.Verify(der, content); err != nil {
+			t.Errorf("%s: %v", name, err)
+		}
+		if _, _, err := cms.Verify(der, []byte("hello")); err != rfc6979.ErrInvalidSignature {
+			t.Errorf("%s: Expected %v, got %v", name, rfc6979.ErrInvalidSignature, err)
+		}
+		if _, _, err := cms.Verify(der, nil); err != cms.ErrInvalidMessage {
+			t.Errorf("%s: Expected %v, got %v", name, cms.ErrInvalidMessage, err)
+		}
+	}
+
+	// Empty content is still attached.
+	cert, priv := newCert(t, elliptic.P256(), "empty")
+	der, err := cms.Sign(nil, cert, priv, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, _, err := cms.Verify(der, nil); err != nil || len(got) != 0 {
+		t.Errorf("Expected empty content, got %q, %v", got, err)
+	}
+
+	_, other := newCert(t, elliptic.P256(), "other")
+	if _, err := cms.Sign(content, cert, other, nil); err != cms.ErrInvalidKey {
+		t.Errorf("Expected %v, got %v", cms.ErrInvalidKey, err)
+	}
+	p224 := &ecdsa.PrivateKey{PublicKey: ecdsa.PublicKey{Curve: elliptic.P224()}, D: big.NewInt(1)}
+	if _, err := cms.Sign(content, cert, p224, nil); err != cms.ErrInvalidKey {
+		t.Errorf("Expected %v, got %v", cms.ErrInvalidKey, err)
+	}
+}
+
+func TestVerify(t *testing.T) {
+	// Made with "openssl cms -sign -binary -nodetach", it has the signing
+	// time and S/MIME capabilities attributes.
+	der, _ := base64.StdEncoding.DecodeString(strings.Join([]string{
+		"MIIDAQYJKoZIhvcNAQcCoIIC8jCCAu4CAQExDTALBglghkgBZQMEAgEwHAYJKoZIhvcNAQcBoA8E",
+		"DWhlbGxvLCB3b3JsZAqgggFIMIIBRDCB6qADAgECAgEBMAoGCCqGSM49BAMCMBcxFTATBgNVBAMT",
+		"DGNtcyB0ZXN0IDI1NjAeFw0yMzExMTQyMjEzMjBaFw0zMDAzMTcxNzQ2NDBaMBcxFTATBgNVBAMT",
+		"DGNtcyB0ZXN0IDI1NjBZMBMGByqGSM49AgEGCCqGSM49AwEHA0IABJHpZX9wq2wq0XHfG3uCUxch",
+		"KgN2QEvYohJRNjs3RdmnH67A0sUVJ8+VRtplVFisOIZGKztoOMnXBwtZb4tL60ijJzAlMA4GA1Ud",
+		"DwEB/wQEAwIHgDATBgNVHSUEDDAKBggrBgEFBQcDBDAKBggqhkjOPQQDAgNJADBGAiEA9SRSgajh",
+		"mWIBTX9PzxcinZj34gxn6u5yOFVmfo3D8ioCIQDMFk4J7serdqBcfJk04UyRiAW2Y5Im2Ne/omvq",
+		"IATEZTGCAW4wggFqAgEBMBwwFzEVMBMGA1UEAxMMY21zIHRlc3QgMjU2AgEBMAsGCWCGSAFlAwQC",
+		"AaCB5DAYBgkqhkiG9w0BCQMxCwYJKoZIhvcNAQcBMBwGCSqGSIb3DQEJBTEPFw0yNjEwMTYxMDEw",
+		"MjBaMC8GCSqGSIb3DQEJBDEiBCCFP/k3YqBt2/cixOvp3dZtj2Pdrql/Uhw+zCDafJdgIDB5Bgkq",
+		"hkiG9w0BCQ8xbDBqMAsGCWCGSAFlAwQBKjALBglghkgBZQMEARYwCwYJYIZIAWUDBAECMAoGCCqG",
+		"SIb3DQMHMA4GCCqGSIb3DQMCAgIAgDANBggqhkiG9w0DAgIBQDAHBgUrDgMCBzANBggqhkiG9w0D",
+		"AgIBKDAKBggqhkjOPQQDAgRHMEUCIQCZ9WWb2w11ymdYiajU8WZGCgQkI2Z/3FPQKMPkeboJkAIg",
+		"TMGUHQI2tg9P8hKOYYsF0SwbOlH7+w1zUXUoP16Jnc4=",
+	}, ""))
+	got, cert, err := cms.Verify(der, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, content) || cert.Subject.CommonName != "cms test 256" {
+		t.Errorf("Unexpected content %q or signer %s", got, cert.Subject)
+	}
+
+	// The content is signed indirectly, through its digest.
+	tampered := bytes.Replace(der, content, []byte("hello, World\n"), 1)
+	if _, _, err := cms.Verify(tampered, nil); err != rfc6979.ErrInvalidSignature {
+		t.Errorf("Expected %v, got %v", rfc6979.ErrInvalidSignature, err)
+	}
+	if _, _, err := cms.Verify(der[:len(der)-1], nil); err != cms.ErrInvalidMessage {
+		t.Errorf("Expected %v, got %v", cms.ErrInvalidMessage, err)
+	}
+	if _, _, err := cms.Verify(append(der, 0), nil); err != cms.ErrInvalidMessage {
+		t.Errorf("Expected %v, got %v", cms.ErrInvalidMessage, err)
+	}
+}
@@ -0,0 +1,35 @@
+package rfc6979
+
+import (
+	"crypto/ecdsa"
+	"encoding/binary"
+	"hash"
+	"math/big"
+)
+
+// SignECDSACommitted signs the digest like SignECDSA does and also returns
+// CommitSignature(alg, r, s, counter), binding the signature to a
+// caller-supplied monotonic counter or timestamp for append-only logs. The
+// commitment is a hash, not a signature: it proves nothing about who
+// assigned the counter, only that the log entry wasn't changed afterwards.
+func SignECDSACommitted(priv *ecdsa.PrivateKey, digest []byte, alg func() hash.Hash, counter uint64) (r, s *big.Int, commitment []byte) {
+	r, s = SignECDSA(priv, digest, alg)
+	return r, s, CommitSignature(alg, r, s, counter)
+}
+
+// CommitSignature returns the hash of the signature (r, s) and the counter.
+// r and s are each prefixed with their 2-byte big-endian length, the counter
+// is appended as 8 big-endian bytes.
+func CommitSignature(alg func() hash.Hash, r, s *big.Int, counter uint64) []byte {
+	h := alg()
+	var buf [8]byte
+	for _, v := range []*big.Int{r, s} {
+		b := v.Bytes()
+		binary.BigEndian.PutUint16(buf[:2], uint16(len(b)))
+		h.Write(buf[:2])
+		h.Write(b)
+	}
+	binary.BigEndian.PutUint64(buf[:], counter)
+	h.Write(buf[:])
+	return h.Sum(nil)
+}
@@ -0,0 +1,34 @@
+package rfc6979_test
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"testing"
+
+	"github.com/nspcc-dev/rfc6979"
+)
+
+func TestSignECDSACommitted(t *testing.T) {
+	key := p256.key
+	digest := sha256.Sum256([]byte("sample"))
+
+	r, s, c1 := rfc6979.SignECDSACommitted(key, digest[:], sha256.New, 1)
+	_, _, again := rfc6979.SignECDSACommitted(key, digest[:], sha256.New, 1)
+	_, _, c2 := rfc6979.SignECDSACommitted(key, digest[:], sha256.New, 2)
+
+	if !bytes.Equal(c1, again) {
+		t.Errorf("Expected commitment to be reproducible")
+	}
+
+	if !bytes.Equal(c1, rfc6979.CommitSignature(sha256.New, r, s, 1)) {
+		t.Errorf("Expected commitment to be recomputable from the signature")
+	}
+
+	if bytes.Equal(c1, c2) {
+		t.Errorf("Expected commitment to depend on the counter")
+	}
+
+	if bytes.Equal(rfc6979.CommitSignature(sha256.New, r, s, 1), rfc6979.CommitSignature(sha256.New, s, r, 1)) {
+		t.Errorf("Expected commitment to depend on the order of r and s")
+	}
+}
@@ -0,0 +1,75 @@
+package rfc6979
+
+import (
+	"crypto/elliptic"
+	"errors"
+	"math/big"
+)
+
+// ErrInvalidCompact is returned for compact signatures of the wrong length,
+// with components outside [1, N-1] or with an invalid recovery id.
+var ErrInvalidCompact = errors.New("rfc6979: invalid compact signature")
+
+// MarshalCompact encodes the signature (r, s) made on the curve c as r || s,
+// both big-endian and left-padded with zeros to the byte length of the curve
+// order, that is 64 bytes for P-256 and secp256k1. Components outside
+// [1, N-1] are rejected with ErrInvalidCompact.
+func MarshalCompact(c elliptic.Curve, r, s *big.Int) ([]byte, error) {
+	if !inOrder(c, r) || !inOrder(c, s) {
+		return nil, ErrInvalidCompact
+	}
+	return encodeRaw(r, s, OrderSize(c))
+}
+
+// UnmarshalCompact decodes a signature produced by MarshalCompact for the
+// curve c. The length must be exactly twice the byte length of the curve
+// order and both components must be within [1, N-1].
+func UnmarshalCompact(c elliptic.Curve, data []byte) (r, s *big.Int, err error) {
+	size := OrderSize(c)
+	if len(data) != 2*size {
+		return nil, nil, ErrInvalidCompact
+	}
+
+	r = new(big.Int).SetBytes(data[:size])
+	s = new(big.Int).SetBytes(data[size:])
+	if !inOrder(c, r) || !inOrder(c, s) {
+		return nil, nil, ErrInvalidCompact
+	}
+	return r, s, nil
+}
+
+// MarshalCompactRecoverable is like MarshalCompact, but prepends the recovery
+// id returned by SignECDSARecoverable, giving recid || r || s, 65 bytes for
+// P-256 and secp256k1. The recovery id must be within [0, 3].
+func MarshalCompactRecoverable(c elliptic.Curve, r, s *big.Int, recid byte) ([]byte, error) {
+	if recid > 3 || !inOrder(c, r) || !inOrder(c, s) {
+		return nil, ErrInvalidCompact
+	}
+
+	out := make([]byte, 1+2*OrderSize(c))
+	out[0] = recid
+	if err := fillRaw(out[1:], r, s); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// UnmarshalCompactRecoverable decodes a signature produced by
+// MarshalCompactRecoverable for the curve c, validating it like
+// UnmarshalCompact does and checking the recovery id.
+func UnmarshalCompactRecoverable(c elliptic.Curve, data []byte) (r, s *big.Int, recid byte, err error) {
+	if len(data) == 0 || data[0] > 3 {
+		return nil, nil, 0, ErrInvalidCompact
+	}
+
+	r, s, err = UnmarshalCompact(c, data[1:])
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	return r, s, data[0], nil
+}
+
+// inOrder reports whether v is within [1, N-1] for the curve c.
+func inOrder(c elliptic.Curve, v *big.Int) bool {
+	return v != nil && v.Sign() > 0 && v.Cmp(c.Params().N) < 0
+}
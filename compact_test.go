@@ -0,0 +1,79 @@
+package rfc6979_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/sha256"
+	"math/big"
+	"testing"
+
+	"github.com/nspcc-dev/rfc6979"
+)
+
+func TestCompact(t *testing.T) {
+	digest := sha256.Sum256([]byte("sample"))
+	keys := []*ecdsa.PrivateKey{p256.key, secp256k1Key(secp256k1Vectors[1].d), p521.key}
+
+	for _, key := range keys {
+		c := key.Curve
+		name := c.Params().Name
+		size := rfc6979.OrderSize(c)
+		r, s, recid := rfc6979.SignECDSARecoverable(key, digest[:], sha256.New, nil)
+
+		data, err := rfc6979.MarshalCompact(c, r, s)
+		if err != nil || len(data) != 2*size {
+			t.Fatalf("%s: Unexpected %d bytes, %v", name, len(data), err)
+		}
+		r2, s2, err := rfc6979.UnmarshalCompact(c, data)
+		if err != nil || r2.Cmp(r) != 0 || s2.Cmp(s) != 0 {
+			t.Errorf("%s: Expected round trip, got %v", name, err)
+		}
+
+		data, err = rfc6979.MarshalCompactRecoverable(c, r, s, recid)
+		if err != nil || len(data) != 1+2*size || data[0] != recid {
+			t.Fatalf("%s: Unexpected %X, %v", name, data, err)
+		}
+		r2, s2, recid2, err := rfc6979.UnmarshalCompactRecoverable(c, data)
+		if err != nil || r2.Cmp(r) != 0 || s2.Cmp(s) != 0 || recid2 != recid {
+			t.Errorf("%s: Expected round trip, got %v", name, err)
+		}
+		pub, err := rfc6979.RecoverPublicKey(c, digest[:], r2, s2, recid2)
+		if err != nil || pub.X.Cmp(key.X) != 0 {
+			t.Errorf("%s: Expected the signer's key to be recovered", name)
+		}
+
+		for _, bad := range [][]byte{nil, data[:2*size], append(data, 0), append([]byte{4}, data[1:]...)} {
+			if _, _, _, err := rfc6979.UnmarshalCompactRecoverable(c, bad); err != rfc6979.ErrInvalidCompact {
+				t.Errorf("%s: %X: Expected %v, got %v", name, bad, rfc6979.ErrInvalidCompact, err)
+			}
+		}
+		if _, _, err := rfc6979.UnmarshalCompact(c, data); err != rfc6979.ErrInvalidCompact {
+			t.Errorf("%s: Expected %v for the recoverable form, got %v", name, rfc6979.ErrInvalidCompact, err)
+		}
+	}
+}
+
+func TestCompactRange(t *testing.T) {
+	c := p256.key.Curve
+	N := c.Params().N
+	one := big.NewInt(1)
+
+	for _, v := range []*big.Int{nil, new(big.Int), big.NewInt(-1), N, new(big.Int).Lsh(N, 8)} {
+		if _, err := rfc6979.MarshalCompact(c, v, one); err != rfc6979.ErrInvalidCompact {
+			t.Errorf("%v: Expected %v, got %v", v, rfc6979.ErrInvalidCompact, err)
+		}
+		if _, err := rfc6979.MarshalCompactRecoverable(c, one, v, 0); err != rfc6979.ErrInvalidCompact {
+			t.Errorf("%v: Expected %v, got %v", v, rfc6979.ErrInvalidCompact, err)
+		}
+	}
+	if _, err := rfc6979.MarshalCompactRecoverable(c, one, one, 4); err != rfc6979.ErrInvalidCompact {
+		t.Errorf("Expected %v for recovery id 4, got %v", rfc6979.ErrInvalidCompact, err)
+	}
+
+	zero := make([]byte, 64)
+	order := N.FillBytes(make([]byte, 64))[32:]
+	for _, data := range [][]byte{zero, append(order, order...)} {
+		if _, _, err := rfc6979.UnmarshalCompact(c, data); err != rfc6979.ErrInvalidCompact {
+			t.Errorf("%X: Expected %v, got %v", data, rfc6979.ErrInvalidCompact, err)
+		}
+	}
+}
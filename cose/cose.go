@@ -0,0 +1,214 @@
+/*
+Package cose produces COSE_Sign1 messages (RFC 9052 section 4.2) with the
+ECDSA algorithms of RFC 9053 section 2.1, using RFC 6979 deterministic
+signatures, and CBOR Web Tokens (RFC 8392) on top of them. Messages over the
+same payload and headers are identical, so they may be cached and compared.
+*/
+package cose
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	_ "crypto/sha256" // ES256
+	_ "crypto/sha512" // ES384, ES512
+	"errors"
+
+	"github.com/nspcc-dev/rfc6979"
+	"github.com/nspcc-dev/rfc6979/internal/cbor"
+)
+
+// Algorithm identifiers, RFC 9053 section 2.1.
+const (
+	AlgES256 = -7
+	AlgES384 = -35
+	AlgES512 = -36
+)
+
+// Header labels, RFC 9052 section 3.1.
+const (
+	HeaderAlg  = 1
+	HeaderCrit = 2
+	HeaderKID  = 4
+)
+
+// TagSign1 is the CBOR tag of COSE_Sign1 messages.
+const TagSign1 = 18
+
+var (
+	// ErrInvalidAlgorithm is returned for keys on curves without an
+	// algorithm and for messages whose algorithm doesn't match the key.
+	ErrInvalidAlgorithm = errors.New("cose: invalid algorithm")
+
+	// ErrInvalidMessage is returned for malformed messages.
+	ErrInvalidMessage = errors.New("cose: invalid message")
+)
+
+// algorithm describes a COSE signature algorithm.
+type algorithm struct {
+	id    int64
+	curve func() elliptic.Curve
+	hash  crypto.Hash
+}
+
+// algorithms lists the supported algorithms.
+var algorithms = []algorithm{
+	{AlgES256, elliptic.P256, crypto.SHA256},
+	{AlgES384, elliptic.P384, crypto.SHA384},
+	{AlgES512, elliptic.P521, crypto.SHA512},
+}
+
+// lookup returns the algorithm of the curve c.
+func lookup(c elliptic.Curve) (algorithm, error) {
+	for _, a := range algorithms {
+		if a.curve().Params().Name == c.Params().Name {
+			return a, nil
+		}
+	}
+	return algorithm{}, ErrInvalidAlgorithm
+}
+
+// Sign1 returns a tagged COSE_Sign1 message over the payload signed with the
+// private key, priv, using the algorithm of the key's curve: ES256 for
+// P-256, ES384 for P-384 and ES512 for P-521. The algorithm is the only
+// protected header; kid, unless nil, is put into the unprotected one.
+// externalAAD is bound to the signature without being part of the message,
+// it may be nil.
+func Sign1(priv *ecdsa.PrivateKey, payload, externalAAD, kid []byte) ([]byte, error) {
+	a, err := lookup(priv.Curve)
+	if err != nil {
+		return nil, err
+	}
+
+	protected := cbor.AppendHead(nil, cbor.Map, 1)
+	protected = cbor.AppendInt(protected, HeaderAlg)
+	protected = cbor.AppendInt(protected, a.id)
+
+	r, s := rfc6979.SignECDSA(priv, digest(a, protected, externalAAD, payload), a.hash.New)
+	sig, err := rfc6979.EncodeP1363(priv.Curve, r, s)
+	if err != nil {
+		return nil, err
+	}
+
+	msg := cbor.AppendHead(nil, cbor.Tag, TagSign1)
+	msg = cbor.AppendHead(msg, cbor.Array, 4)
+	msg = cbor.AppendBytes(msg, protected)
+	if kid != nil {
+		msg = cbor.AppendHead(msg, cbor.Map, 1)
+		msg = cbor.AppendInt(msg, HeaderKID)
+		msg = cbor.AppendBytes(msg, kid)
+	} else {
+		msg = cbor.AppendHead(msg, cbor.Map, 0)
+	}
+	msg = cbor.AppendBytes(msg, payload)
+	return cbor.AppendBytes(msg, sig), nil
+}
+
+// Verify1 verifies a COSE_Sign1 message, tagged or not, with the public key,
+// pub, and returns its payload. The protected header must hold the
+// algorithm of the key's curve and no critical headers, the payload must be
+// attached and the signature must be r || s as long as the algorithm
+// prescribes. Unprotected headers are ignored.
+func Verify1(pub *ecdsa.PublicKey, msg, externalAAD []byte) ([]byte, error) {
+	a, err := lookup(pub.Curve)
+	if err != nil {
+		return nil, err
+	}
+
+	if tag, rest, ok := cbor.ReadHead(msg, cbor.Tag); ok {
+		if tag != TagSign1 {
+			return nil, ErrInvalidMessage
+		}
+		msg = rest
+	}
+	n, rest, ok := cbor.ReadHead(msg, cbor.Array)
+	if !ok || n != 4 {
+		return nil, ErrInvalidMessage
+	}
+	protected, rest, ok := cbor.ReadBytes(rest)
+	if !ok {
+		return nil, ErrInvalidMessage
+	}
+	if len(rest) == 0 || rest[0]>>5 != cbor.Map {
+		return nil, ErrInvalidMessage
+	}
+	if rest, ok = cbor.Skip(rest); !ok {
+		return nil, ErrInvalidMessage
+	}
+	payload, rest, ok := cbor.ReadBytes(rest)
+	if !ok {
+		return nil, ErrInvalidMessage
+	}
+	sig, rest, ok := cbor.ReadBytes(rest)
+	if !ok || len(rest) != 0 {
+		return nil, ErrInvalidMessage
+	}
+
+	alg, err := protectedAlg(protected)
+	if err != nil {
+		return nil, err
+	}
+	if alg != a.id {
+		return nil, ErrInvalidAlgorithm
+	}
+	r, s, err := rfc6979.DecodeP1363(pub.Curve, sig)
+	if err != nil {
+		return nil, ErrInvalidMessage
+	}
+	if !rfc6979.VerifyECDSA(pub, digest(a, protected, externalAAD, payload), r, s) {
+		return nil, rfc6979.ErrInvalidSignature
+	}
+	return payload, nil
+}
+
+// protectedAlg decodes the protected header and returns its algorithm.
+// Critical headers aren't understood and are rejected, other ones are
+// skipped.
+func protectedAlg(protected []byte) (int64, error) {
+	n, rest, ok := cbor.ReadHead(protected, cbor.Map)
+	if !ok {
+		return 0, ErrInvalidMessage
+	}
+
+	var (
+		alg  int64
+		seen = make(map[int64]bool)
+	)
+	for i := uint64(0); i < n; i++ {
+		var label int64
+		if label, rest, ok = cbor.ReadInt(rest); !ok || seen[label] || label == HeaderCrit {
+			return 0, ErrInvalidMessage
+		}
+		seen[label] = true
+
+		if label == HeaderAlg {
+			alg, rest, ok = cbor.ReadInt(rest)
+		} else {
+			rest, ok = cbor.Skip(rest)
+		}
+		if !ok {
+			return 0, ErrInvalidMessage
+		}
+	}
+	if len(rest) != 0 || !seen[HeaderAlg] {
+		return 0, ErrInvalidMessage
+	}
+	return alg, nil
+}
+
+// sigStructure returns the Sig_structure of a COSE_Sign1 message, RFC 9052
+// section 4.4, which is what gets signed.
+func sigStructure(protected, externalAAD, payload []byte) []byte {
+	out := cbor.AppendHead(nil, cbor.Array, 4)
+	out = cbor.AppendText(out, "Signature1")
+	out = cbor.AppendBytes(out, protected)
+	out = cbor.AppendBytes(out, externalAAD)
+	return cbor.AppendBytes(out, payload)
+}
+
+// digest hashes the Sig_structure with the hash of the algorithm.
+func digest(a algorithm, protected, externalAAD, payload []byte) []byte {
+	h := a.hash.New()
+	h.Write(sigStructure(protected, externalAAD, payload))
+	return h.Sum(nil)
+}
@@ -0,0 +1,125 @@
+package cose_test
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/nspcc-dev/rfc6979"
+	"github.com/nspcc-dev/rfc6979/cose"
+)
+
+func fromHex(s string) []byte {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+func newKey(c elliptic.Curve, d, x, y string) *ecdsa.PrivateKey {
+	return &ecdsa.PrivateKey{
+		PublicKey: ecdsa.PublicKey{
+			Curve: c,
+			X:     new(big.Int).SetBytes(fromHex(x)),
+			Y:     new(big.Int).SetBytes(fromHex(y)),
+		},
+		D: new(big.Int).SetBytes(fromHex(d)),
+	}
+}
+
+// key "11" of RFC 9052 appendix C.7.1.
+var key11 = newKey(elliptic.P256(),
+	"57c92077664146e876760c9520d054aa93c3afb04e306705db6090308507b4d3",
+	"bac5b11cad8f99f9c72b05cf4b9e26d244dc189f745228255a219a86d6a09eff",
+	"20138bf82dc1b6d562be0fa54ab7804a3a64b6d72ccfed6b6fb6ed28bbfc117e",
+)
+
+func TestVerify1(t *testing.T) {
+	// RFC 9052 appendix C.2.1.
+	msg := fromHex("D28443A10126A10442313154546869732069732074686520636F6E74656E742E58408EB33E4CA31D1C465AB05AAC34CC6B23D58FEF5C083106C4D25A91AEF0B0117E2AF9A291AA32E14AB834DC56ED2A223444547E01F11D3B0916E5A4C345CACB36")
+	payload, err := cose.Verify1(&key11.PublicKey, msg, nil)
+	if err != nil || string(payload) != "This is the content." {
+		t.Fatalf("Expected the payload, got %q, %v", payload, err)
+	}
+	if _, err := cose.Verify1(&key11.PublicKey, msg, []byte("aad")); err != rfc6979.ErrInvalidSignature {
+		t.Errorf("Expected %v, got %v", rfc6979.ErrInvalidSignature, err)
+	}
+	if _, err := cose.Verify1(&key11.PublicKey, msg[1:], nil); err != nil {
+		t.Errorf("Untagged: %v", err)
+	}
+
+	for name, bad := range map[string]string{
+		"other tag":   "D1" + "8443A10126A10442313154546869732069732074686520636F6E74656E742E4100",
+		"short array": "D28343A10126A10442313154546869732069732074686520636F6E74656E742E",
+		"trailing":    hex.EncodeToString(msg) + "00",
+		"detached":    "D28443A10126A10442313158408EB33E4CA31D1C465AB05AAC34CC6B23D58FEF5C083106C4D25A91AEF0B0117E2AF9A291AA32E14AB834DC56ED2A223444547E01F11D3B0916E5A4C345CACB36F6",
+		"crit":        "D28447A201260281182AA054546869732069732074686520636F6E74656E742E4100",
+		"no alg":      "D28441A0A054546869732069732074686520636F6E74656E742E4100",
+		"short sig":   "D28443A10126A054546869732069732074686520636F6E74656E742E4100",
+	} {
+		if _, err := cose.Verify1(&key11.PublicKey, fromHex(bad), nil); err != cose.ErrInvalidMessage {
+			t.Errorf("%s: Expected %v, got %v", name, cose.ErrInvalidMessage, err)
+		}
+	}
+
+	// ES384 in the protected header.
+	es384 := fromHex("D28444A1013822A054546869732069732074686520636F6E74656E742E4100")
+	if _, err := cose.Verify1(&key11.PublicKey, es384, nil); err != cose.ErrInvalidAlgorithm {
+		t.Errorf("Expected %v, got %v", cose.ErrInvalidAlgorithm, err)
+	}
+}
+
+func TestSign1(t *testing.T) {
+	payload := []byte("This is the content.")
+	msg, err := cose.Sign1(key11, payload, nil, []byte("11"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Same headers as RFC 9052 appendix C.2.1, the signature differs as the
+	// example uses a random nonce.
+	header := fromHex("D28443A10126A104423131")
+	if !bytes.HasPrefix(msg, header) {
+		t.Errorf("Expected the %X prefix, got %X", header, msg)
+	}
+	again, _ := cose.Sign1(key11, payload, nil, []byte("11"))
+	if !bytes.Equal(msg, again) {
+		t.Errorf("Expected a deterministic message, got %X and %X", msg, again)
+	}
+	if got, err := cose.Verify1(&key11.PublicKey, msg, nil); err != nil || !bytes.Equal(got, payload) {
+		t.Errorf("Expected the payload, got %q, %v", got, err)
+	}
+
+	aad := []byte("external")
+	for _, c := range []elliptic.Curve{elliptic.P256(), elliptic.P384(), elliptic.P521()} {
+		priv, err := ecdsa.GenerateKey(c, rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		msg, err := cose.Sign1(priv, payload, aad, nil)
+		if err != nil {
+			t.Fatalf("%s: %v", c.Params().Name, err)
+		}
+		if _, err := cose.Verify1(&priv.PublicKey, msg, aad); err != nil {
+			t.Errorf("%s: %v", c.Params().Name, err)
+		}
+		if _, err := cose.Verify1(&priv.PublicKey, msg, nil); err != rfc6979.ErrInvalidSignature {
+			t.Errorf("%s: Expected %v, got %v", c.Params().Name, rfc6979.ErrInvalidSignature, err)
+		}
+		if _, err := cose.Verify1(&key11.PublicKey, msg, aad); err == nil {
+			t.Errorf("%s: Expected an error for another key", c.Params().Name)
+		}
+	}
+
+	priv, err := ecdsa.GenerateKey(elliptic.P224(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cose.Sign1(priv, payload, nil, nil); err != cose.ErrInvalidAlgorithm {
+		t.Errorf("Expected %v, got %v", cose.ErrInvalidAlgorithm, err)
+	}
+}
@@ -0,0 +1,126 @@
+package cose
+
+import (
+	"crypto/ecdsa"
+
+	"github.com/nspcc-dev/rfc6979/internal/cbor"
+)
+
+// Claim keys, RFC 8392 section 4.
+const (
+	ClaimIss = 1
+	ClaimSub = 2
+	ClaimAud = 3
+	ClaimExp = 4
+	ClaimNbf = 5
+	ClaimIat = 6
+	ClaimCti = 7
+)
+
+// Claims are the registered claims of a CBOR Web Token. Zero values are
+// omitted, times are NumericDate seconds.
+type Claims struct {
+	Issuer     string
+	Subject    string
+	Audience   string
+	Expiration int64
+	NotBefore  int64
+	IssuedAt   int64
+	ID         []byte
+}
+
+// marshal encodes the claims as a CBOR map, its keys in ascending order.
+func (c *Claims) marshal() []byte {
+	var (
+		n    uint64
+		body []byte
+	)
+	for _, t := range []struct {
+		key  int64
+		text string
+	}{{ClaimIss, c.Issuer}, {ClaimSub, c.Subject}, {ClaimAud, c.Audience}} {
+		if t.text != "" {
+			body = cbor.AppendText(cbor.AppendInt(body, t.key), t.text)
+			n++
+		}
+	}
+	for _, t := range []struct {
+		key  int64
+		time int64
+	}{{ClaimExp, c.Expiration}, {ClaimNbf, c.NotBefore}, {ClaimIat, c.IssuedAt}} {
+		if t.time != 0 {
+			body = cbor.AppendInt(cbor.AppendInt(body, t.key), t.time)
+			n++
+		}
+	}
+	if len(c.ID) != 0 {
+		body = cbor.AppendBytes(cbor.AppendInt(body, ClaimCti), c.ID)
+		n++
+	}
+	return append(cbor.AppendHead(nil, cbor.Map, n), body...)
+}
+
+// unmarshal decodes a claims map. Unregistered claims are skipped, floating
+// point times aren't supported.
+func (c *Claims) unmarshal(data []byte) error {
+	n, rest, ok := cbor.ReadHead(data, cbor.Map)
+	if !ok {
+		return ErrInvalidMessage
+	}
+
+	seen := make(map[int64]bool)
+	for i := uint64(0); i < n; i++ {
+		var key int64
+		if key, rest, ok = cbor.ReadInt(rest); !ok || seen[key] {
+			return ErrInvalidMessage
+		}
+		seen[key] = true
+
+		switch key {
+		case ClaimIss:
+			c.Issuer, rest, ok = cbor.ReadText(rest)
+		case ClaimSub:
+			c.Subject, rest, ok = cbor.ReadText(rest)
+		case ClaimAud:
+			c.Audience, rest, ok = cbor.ReadText(rest)
+		case ClaimExp:
+			c.Expiration, rest, ok = cbor.ReadInt(rest)
+		case ClaimNbf:
+			c.NotBefore, rest, ok = cbor.ReadInt(rest)
+		case ClaimIat:
+			c.IssuedAt, rest, ok = cbor.ReadInt(rest)
+		case ClaimCti:
+			c.ID, rest, ok = cbor.ReadBytes(rest)
+		default:
+			rest, ok = cbor.Skip(rest)
+		}
+		if !ok {
+			return ErrInvalidMessage
+		}
+	}
+	if len(rest) != 0 {
+		return ErrInvalidMessage
+	}
+	return nil
+}
+
+// SignCWT returns a CBOR Web Token holding the claims, signed like Sign1
+// does. Validating the claims, e.g. that the token isn't expired, is up to
+// the caller.
+func SignCWT(priv *ecdsa.PrivateKey, claims *Claims, kid []byte) ([]byte, error) {
+	return Sign1(priv, claims.marshal(), nil, kid)
+}
+
+// VerifyCWT verifies a CBOR Web Token with the public key, pub, like Verify1
+// does, and returns its claims. The times aren't checked against the clock.
+func VerifyCWT(pub *ecdsa.PublicKey, token []byte) (*Claims, error) {
+	payload, err := Verify1(pub, token, nil)
+	if err != nil {
+		return nil, err
+	}
+	claims := new(Claims)
+	if err := claims.unmarshal(payload); err != nil {
+		return nil, err
+	}
+	return claims, nil
+}
@@ -0,0 +1,70 @@
+package cose_test
+
+import (
+	"bytes"
+	"crypto/elliptic"
+	"reflect"
+	"testing"
+
+	"github.com/nspcc-dev/rfc6979/cose"
+)
+
+// key of RFC 8392 appendix A.2.3.
+var cwtKey = newKey(elliptic.P256(),
+	"6c1382765aec5358f117733d281c1c7bdc39884d04a45a1e6c67c858bc206c19",
+	"143329cce7868e416927599cf65a34f3ce2ffda55a7eca69ed8919a394d42f0f",
+	"60f7f1a780d8a783bfb7a2dd6b2796e8128dbbcef9d3d168db9529971a36e7b9",
+)
+
+// claims of RFC 8392 appendix A.1.
+var cwtClaims = &cose.Claims{
+	Issuer:     "coap://as.example.com",
+	Subject:    "erikw",
+	Audience:   "coap://light.example.com",
+	Expiration: 1444064944,
+	NotBefore:  1443944944,
+	IssuedAt:   1443944944,
+	ID:         []byte{0x0b, 0x71},
+}
+
+func TestVerifyCWT(t *testing.T) {
+	// RFC 8392 appendix A.3.
+	token := fromHex("d28443a10126a104524173796d6d657472696345434453413235365850a70175636f61703a2f2f61732e6578616d706c652e636f6d02656572696b77037818636f61703a2f2f6c696768742e6578616d706c652e636f6d041a5612aeb0051a5610d9f0061a5610d9f007420b7158405427c1ff28d23fbad1f29c4c7c6a555e601d6fa29f9179bc3d7438bacaca5acd08c8d4d4f96131680c429a01f85951ecee743a52b9b63632c57209120e1c9e30")
+	claims, err := cose.VerifyCWT(&cwtKey.PublicKey, token)
+	if err != nil || !reflect.DeepEqual(claims, cwtClaims) {
+		t.Errorf("Expected %+v, got %+v, %v", cwtClaims, claims, err)
+	}
+}
+
+func TestSignCWT(t *testing.T) {
+	kid := []byte("AsymmetricECDSA256")
+	token, err := cose.SignCWT(cwtKey, cwtClaims, kid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The claims are encoded like in RFC 8392 appendix A.1, so the message
+	// matches A.3 up to the signature, which was made with a random nonce.
+	prefix := fromHex("d28443a10126a104524173796d6d657472696345434453413235365850a70175636f61703a2f2f61732e6578616d706c652e636f6d02656572696b77037818636f61703a2f2f6c696768742e6578616d706c652e636f6d041a5612aeb0051a5610d9f0061a5610d9f007420b715840")
+	if !bytes.HasPrefix(token, prefix) || len(token) != len(prefix)+64 {
+		t.Errorf("Expected the %X prefix, got %X", prefix, token)
+	}
+	claims, err := cose.VerifyCWT(&cwtKey.PublicKey, token)
+	if err != nil || !reflect.DeepEqual(claims, cwtClaims) {
+		t.Errorf("Expected %+v, got %+v, %v", cwtClaims, claims, err)
+	}
+
+	// Empty claims are an empty map.
+	token, _ = cose.SignCWT(cwtKey, &cose.Claims{}, nil)
+	if !bytes.HasPrefix(token, fromHex("d28443a10126a041a0")) {
+		t.Errorf("Expected an empty claims map, got %X", token)
+	}
+	if claims, err := cose.VerifyCWT(&cwtKey.PublicKey, token); err != nil || !reflect.DeepEqual(claims, &cose.Claims{}) {
+		t.Errorf("Expected no claims, got %+v, %v", claims, err)
+	}
+
+	// A text cti isn't valid.
+	token, _ = cose.Sign1(cwtKey, fromHex("a1076130"), nil, nil)
+	if _, err := cose.VerifyCWT(&cwtKey.PublicKey, token); err != cose.ErrInvalidMessage {
+		t.Errorf("Expected %v, got %v", cose.ErrInvalidMessage, err)
+	}
+}
@@ -0,0 +1,21 @@
+package rfc6979
+
+import (
+	"crypto/ecdsa"
+	"crypto/sha256"
+)
+
+// SignECDSACosmos signs the digest with the secp256k1 private key, priv, the
+// way the Cosmos SDK and Tendermint/CometBFT do. The digest must be the
+// SHA-256 hash of the sign bytes, the nonce is derived with SHA-256 as well.
+// The signature is low-S normalized and returned as 64 bytes: r followed by
+// s, both big-endian and left-padded with zeros to 32 bytes, with no recovery
+// byte. ErrUnsupportedCurve is returned for keys on other curves.
+func SignECDSACosmos(priv *ecdsa.PrivateKey, digest []byte) ([]byte, error) {
+	if !IsSecp256k1(priv.Curve) {
+		return nil, ErrUnsupportedCurve
+	}
+
+	r, s := SignECDSAWithOptions(priv, digest, sha256.New, &Options{LowS: true})
+	return encodeRaw(r, s, 32)
+}
@@ -0,0 +1,47 @@
+package rfc6979_test
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/sha256"
+	"encoding/hex"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/nspcc-dev/rfc6979"
+)
+
+func TestSignECDSACosmos(t *testing.T) {
+	for _, v := range secp256k1Vectors {
+		priv := secp256k1Key(v.d)
+		digest := sha256.Sum256([]byte(v.message))
+
+		sig, err := rfc6979.SignECDSACosmos(priv, digest[:])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if expected := v.r + v.s; strings.ToUpper(hex.EncodeToString(sig)) != expected {
+			t.Errorf("%s/%q: Expected %s, got %X", v.d, v.message, expected, sig)
+		}
+
+		r := new(big.Int).SetBytes(sig[:32])
+		s := new(big.Int).SetBytes(sig[32:])
+		if !rfc6979.IsLowS(priv.Curve, s) || !ecdsa.Verify(&priv.PublicKey, digest[:], r, s) {
+			t.Errorf("%s/%q: Invalid signature", v.d, v.message)
+		}
+	}
+
+	digest := sha256.Sum256([]byte("sample"))
+	priv := secp256k1Key(secp256k1Vectors[1].d)
+	expected, _ := rfc6979.SignECDSACosmos(priv, digest[:])
+	other := *priv
+	other.Curve = otherSecp256k1{priv.Curve}
+	if sig, err := rfc6979.SignECDSACosmos(&other, digest[:]); err != nil || !bytes.Equal(sig, expected) {
+		t.Errorf("Other secp256k1 implementation: expected %X, got %X (%v)", expected, sig, err)
+	}
+
+	if _, err := rfc6979.SignECDSACosmos(p256.key, digest[:]); err != rfc6979.ErrUnsupportedCurve {
+		t.Errorf("Expected %v, got %v", rfc6979.ErrUnsupportedCurve, err)
+	}
+}
@@ -0,0 +1,51 @@
+package rfc6979
+
+import (
+	"crypto/elliptic"
+	"math/big"
+)
+
+// VerifyCurve verifies the signature (r, s) of the hash with the public key
+// point (x, y) on the curve c. Unlike ecdsa.Verify it only relies on the
+// elliptic.Curve interface and math/big, so its semantics don't depend on
+// the Go version: the hash is truncated as in SignECDSA and no other checks
+// beyond SEC 1 section 4.1.4 are made.
+func VerifyCurve(c elliptic.Curve, x, y *big.Int, hash []byte, r, s *big.Int) bool {
+	return verifyCurve(c, x, y, hash, r, s) == ""
+}
+
+// verifyCurve implements VerifyCurve, returning the reason of failure or an
+// empty string if the signature is valid.
+func verifyCurve(c elliptic.Curve, x, y *big.Int, hash []byte, r, s *big.Int) string {
+	N := c.Params().N
+	if r == nil || r.Sign() <= 0 || r.Cmp(N) >= 0 {
+		return "r out of range"
+	}
+	if s == nil || s.Sign() <= 0 || s.Cmp(N) >= 0 {
+		return "s out of range"
+	}
+	if x == nil || y == nil || !c.IsOnCurve(x, y) {
+		return "public key not on curve"
+	}
+
+	e := hashToInt(hash, c)
+	w := new(big.Int).ModInverse(s, N)
+
+	u1 := e.Mul(e, w)
+	u1.Mod(u1, N)
+	u2 := w.Mul(r, w)
+	u2.Mod(u2, N)
+
+	x1, y1 := c.ScalarBaseMult(u1.Bytes())
+	x2, y2 := c.ScalarMult(x, y, u2.Bytes())
+	rx, ry := c.Add(x1, y1, x2, y2)
+	if rx.Sign() == 0 && ry.Sign() == 0 {
+		return "computed point at infinity"
+	}
+
+	rx.Mod(rx, N)
+	if rx.Cmp(r) != 0 {
+		return "computed point mismatch"
+	}
+	return ""
+}
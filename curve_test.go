@@ -0,0 +1,52 @@
+package rfc6979_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"math/big"
+	"testing"
+
+	"github.com/nspcc-dev/rfc6979"
+)
+
+func TestVerifyCurveFixtures(t *testing.T) {
+	for _, f := range fixtures {
+		h := f.alg()
+		h.Write([]byte(f.message))
+		digest := h.Sum(nil)
+
+		pub := &f.key.key.PublicKey
+		r, s := ecdsaLoadInt(f.r), ecdsaLoadInt(f.s)
+		if !rfc6979.VerifyCurve(pub.Curve, pub.X, pub.Y, digest, r, s) {
+			t.Errorf("%s: Invalid signature", f.name)
+		}
+	}
+}
+
+func TestVerifyCurveRandom(t *testing.T) {
+	for _, c := range []elliptic.Curve{elliptic.P224(), elliptic.P256(), elliptic.P384(), elliptic.P521()} {
+		for i := 0; i < 16; i++ {
+			k, err := ecdsa.GenerateKey(c, rand.Reader)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var digest [32]byte
+			if _, err := rand.Read(digest[:]); err != nil {
+				t.Fatal(err)
+			}
+
+			r, s := rfc6979.SignECDSA(k, digest[:], sha256.New)
+			if i%2 == 1 {
+				s.Add(s, big.NewInt(1))
+			}
+
+			expected := ecdsa.Verify(&k.PublicKey, digest[:], r, s)
+			if actual := rfc6979.VerifyCurve(c, k.X, k.Y, digest[:], r, s); actual != expected {
+				t.Errorf("%s #%d: Expected %t, got %t", c.Params().Name, i, expected, actual)
+			}
+		}
+	}
+}
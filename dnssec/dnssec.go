@@ -0,0 +1,186 @@
+/*
+Package dnssec signs DNS RRsets with the ECDSA algorithms of RFC 6605,
+ECDSAP256SHA256 and ECDSAP384SHA384, using RFC 6979 deterministic signatures,
+so re-signing an unchanged zone with the same validity period yields the
+same RRSIG records. It deals with the signature field only: building the
+RRSIG RDATA and the canonical RRset is left to the DNS library in use.
+*/
+package dnssec
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	_ "crypto/sha256" // ECDSAP256SHA256
+	_ "crypto/sha512" // ECDSAP384SHA384
+	"errors"
+	"math/big"
+
+	"github.com/nspcc-dev/rfc6979"
+)
+
+// DNSSEC algorithm numbers, RFC 6605 section 2.
+const (
+	AlgECDSAP256SHA256 = 13
+	AlgECDSAP384SHA384 = 14
+)
+
+var (
+	// ErrInvalidAlgorithm is returned for keys on curves other than P-256
+	// and P-384 and for RRSIG RDATA naming another algorithm.
+	ErrInvalidAlgorithm = errors.New("dnssec: invalid algorithm")
+
+	// ErrInvalidRData is returned for malformed RDATA.
+	ErrInvalidRData = errors.New("dnssec: invalid RDATA")
+)
+
+// algorithm describes a DNSSEC ECDSA algorithm.
+type algorithm struct {
+	id    byte
+	curve func() elliptic.Curve
+	hash  crypto.Hash
+}
+
+// algorithms lists the supported algorithms.
+var algorithms = []algorithm{
+	{AlgECDSAP256SHA256, elliptic.P256, crypto.SHA256},
+	{AlgECDSAP384SHA384, elliptic.P384, crypto.SHA384},
+}
+
+// lookup returns the algorithm of the curve c.
+func lookup(c elliptic.Curve) (algorithm, error) {
+	for _, a := range algorithms {
+		if a.curve().Params().Name == c.Params().Name {
+			return a, nil
+		}
+	}
+	return algorithm{}, ErrInvalidAlgorithm
+}
+
+// rrsigHeaderLen is the length of the fixed part of RRSIG RDATA: type
+// covered, algorithm, labels, original TTL, expiration, inception and key
+// tag.
+const rrsigHeaderLen = 18
+
+// SignRRSIG returns the Signature field of an RRSIG record made with the
+// private key, priv. rrsig is the RRSIG RDATA without the signature, i.e.
+// up to and including the signer's name in canonical form, its algorithm
+// must be the one of the key's curve. rrset is the RRset in canonical form
+// and order (RFC 4034 section 6), concatenated. The signature is r || s, 64
+// bytes for P-256 and 96 for P-384 (RFC 6605 section 4).
+func SignRRSIG(priv *ecdsa.PrivateKey, rrsig, rrset []byte) ([]byte, error) {
+	a, err := check(priv.Curve, rrsig)
+	if err != nil {
+		return nil, err
+	}
+	r, s := rfc6979.SignECDSA(priv, digest(a, rrsig, rrset), a.hash.New)
+	return rfc6979.EncodeP1363(priv.Curve, r, s)
+}
+
+// VerifyRRSIG verifies the Signature field of an RRSIG record made for the
+// RRset with the public key, pub, the arguments are the ones of SignRRSIG.
+// The validity period isn't checked against the clock.
+func VerifyRRSIG(pub *ecdsa.PublicKey, rrsig, rrset, sig []byte) error {
+	a, err := check(pub.Curve, rrsig)
+	if err != nil {
+		return err
+	}
+	r, s, err := rfc6979.DecodeP1363(pub.Curve, sig)
+	if err != nil {
+		return err
+	}
+	if !rfc6979.VerifyECDSA(pub, digest(a, rrsig, rrset), r, s) {
+		return rfc6979.ErrInvalidSignature
+	}
+	return nil
+}
+
+// check returns the algorithm of the curve c, checking that the RRSIG
+// RDATA names it and ends with the signer's name.
+func check(c elliptic.Curve, rrsig []byte) (algorithm, error) {
+	a, err := lookup(c)
+	if err != nil {
+		return algorithm{}, err
+	}
+	if len(rrsig) <= rrsigHeaderLen || nameLen(rrsig[rrsigHeaderLen:]) != len(rrsig)-rrsigHeaderLen {
+		return algorithm{}, ErrInvalidRData
+	}
+	if rrsig[2] != a.id {
+		return algorithm{}, ErrInvalidAlgorithm
+	}
+	return a, nil
+}
+
+// nameLen returns the length of the uncompressed domain name at the
+// beginning of b or -1 if there's none.
+func nameLen(b []byte) int {
+	for i := 0; i < len(b); i += int(b[i]) + 1 {
+		if b[i] == 0 {
+			return i + 1
+		}
+		if b[i] > 63 {
+			return -1
+		}
+	}
+	return -1
+}
+
+// digest hashes the signed data, RFC 4034 section 3.1.8.1.
+func digest(a algorithm, rrsig, rrset []byte) []byte {
+	h := a.hash.New()
+	h.Write(rrsig)
+	h.Write(rrset)
+	return h.Sum(nil)
+}
+
+// PublicKey returns the Public Key field of a DNSKEY record for the key,
+// pub: x || y, 64 bytes for P-256 and 96 for P-384 (RFC 6605 section 4).
+func PublicKey(pub *ecdsa.PublicKey) ([]byte, error) {
+	if _, err := lookup(pub.Curve); err != nil {
+		return nil, err
+	}
+	size := rfc6979.CoordinateSize(pub.Curve)
+	if pub.X.Sign() < 0 || pub.Y.Sign() < 0 || pub.X.BitLen() > 8*size || pub.Y.BitLen() > 8*size {
+		return nil, ErrInvalidRData
+	}
+	key := make([]byte, 2*size)
+	pub.X.FillBytes(key[:size])
+	pub.Y.FillBytes(key[size:])
+	return key, nil
+}
+
+// ParsePublicKey decodes the Public Key field of a DNSKEY record with the
+// algorithm alg. The point must be on the curve.
+func ParsePublicKey(alg byte, key []byte) (*ecdsa.PublicKey, error) {
+	for _, a := range algorithms {
+		if a.id != alg {
+			continue
+		}
+		c := a.curve()
+		size := rfc6979.CoordinateSize(c)
+		if len(key) != 2*size {
+			return nil, ErrInvalidRData
+		}
+		pub := &ecdsa.PublicKey{Curve: c, X: new(big.Int).SetBytes(key[:size]), Y: new(big.Int).SetBytes(key[size:])}
+		if !c.IsOnCurve(pub.X, pub.Y) {
+			return nil, ErrInvalidRData
+		}
+		return pub, nil
+	}
+	return nil, ErrInvalidAlgorithm
+}
+
+// KeyTag returns the key tag of a DNSKEY record with the given RDATA, RFC
+// 4034 appendix B, for the Key Tag field of the RRSIG RDATA.
+func KeyTag(dnskey []byte) uint16 {
+	var ac uint32
+	for i, b := range dnskey {
+		if i&1 == 0 {
+			ac += uint32(b) << 8
+		} else {
+			ac += uint32(b)
+		}
+	}
+	ac += ac >> 16 & 0xffff
+	return uint16(ac)
+}
@@ -0,0 +1,170 @@
+package dnssec_test
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/nspcc-dev/rfc6979"
+	"github.com/nspcc-dev/rfc6979/dnssec"
+)
+
+func fromHex(s string) []byte {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+func fromBase64(s string) []byte {
+	b, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// rrsig returns the RRSIG RDATA without the signature.
+func rrsig(typeCovered uint16, alg, labels byte, ttl uint32, expiration, inception time.Time, keyTag uint16, signer []byte) []byte {
+	b := make([]byte, 18)
+	binary.BigEndian.PutUint16(b, typeCovered)
+	b[2], b[3] = alg, labels
+	binary.BigEndian.PutUint32(b[4:], ttl)
+	binary.BigEndian.PutUint32(b[8:], uint32(expiration.Unix()))
+	binary.BigEndian.PutUint32(b[12:], uint32(inception.Unix()))
+	binary.BigEndian.PutUint16(b[16:], keyTag)
+	return append(b, signer...)
+}
+
+var (
+	exampleNet = []byte("\x07example\x03net\x00")
+	expiration = time.Date(2010, 9, 9, 10, 4, 39, 0, time.UTC)
+	inception  = time.Date(2010, 8, 12, 10, 4, 39, 0, time.UTC)
+
+	// www.example.net. 3600 IN A 192.0.2.1
+	rrset = append(append([]byte("\x03www"), exampleNet...), 0, 1, 0, 1, 0, 0, 0x0e, 0x10, 0, 4, 192, 0, 2, 1)
+)
+
+// The ECDSAP256SHA256 example of RFC 6605 section 6.1.
+var (
+	p256DNSKEY = fromBase64("GojIhhXUN/u4v54ZQqGSnyhWJwaubCvTmeexv7bR6edbkrSqQpF64cYbcB7wNcP+e+MAnLr+Wi9xMWyQLc8NAA==")
+	p256RRSIG  = rrsig(1, dnssec.AlgECDSAP256SHA256, 3, 3600, expiration, inception, 55648, exampleNet)
+)
+
+// The P-256 key of RFC 6979 appendix A.2.5.
+var p256Key = &ecdsa.PrivateKey{
+	PublicKey: ecdsa.PublicKey{Curve: elliptic.P256()},
+	D:         new(big.Int).SetBytes(fromHex("C9AFA9D845BA75166B5C215767B1D6934E50C3DB36E89B127B8A622B120F6721")),
+}
+
+func init() {
+	p256Key.X, p256Key.Y = p256Key.Curve.ScalarBaseMult(p256Key.D.Bytes())
+}
+
+func TestPublicKey(t *testing.T) {
+	pub, err := dnssec.ParsePublicKey(dnssec.AlgECDSAP256SHA256, p256DNSKEY)
+	if err != nil {
+		t.Fatal(err)
+	}
+	key, err := dnssec.PublicKey(pub)
+	if err != nil || !bytes.Equal(key, p256DNSKEY) {
+		t.Errorf("Expected %X, got %X, %v", p256DNSKEY, key, err)
+	}
+
+	// DNSKEY 257 3 13.
+	rdata := append([]byte{1, 1, 3, dnssec.AlgECDSAP256SHA256}, key...)
+	if tag := dnssec.KeyTag(rdata); tag != 55648 {
+		t.Errorf("Expected key tag 55648, got %d", tag)
+	}
+
+	// Coordinates are left-padded.
+	small := &ecdsa.PublicKey{Curve: elliptic.P256()}
+	small.X, small.Y = small.Curve.ScalarBaseMult([]byte{1})
+	small.X = new(big.Int).Rsh(small.X, 8)
+	if key, err := dnssec.PublicKey(small); err != nil || len(key) != 64 || key[0] != 0 {
+		t.Errorf("Expected a padded 64-byte key, got %X, %v", key, err)
+	}
+
+	if _, err := dnssec.ParsePublicKey(dnssec.AlgECDSAP384SHA384, p256DNSKEY); err != dnssec.ErrInvalidRData {
+		t.Errorf("Expected %v, got %v", dnssec.ErrInvalidRData, err)
+	}
+	if _, err := dnssec.ParsePublicKey(8, p256DNSKEY); err != dnssec.ErrInvalidAlgorithm {
+		t.Errorf("Expected %v, got %v", dnssec.ErrInvalidAlgorithm, err)
+	}
+	key[63] ^= 1
+	if _, err := dnssec.ParsePublicKey(dnssec.AlgECDSAP256SHA256, key); err != dnssec.ErrInvalidRData {
+		t.Errorf("Expected %v, got %v", dnssec.ErrInvalidRData, err)
+	}
+	p224 := elliptic.P224().Params()
+	if _, err := dnssec.PublicKey(&ecdsa.PublicKey{Curve: p224, X: p224.Gx, Y: p224.Gy}); err != dnssec.ErrInvalidAlgorithm {
+		t.Errorf("Expected %v, got %v", dnssec.ErrInvalidAlgorithm, err)
+	}
+}
+
+func TestVerifyRRSIG(t *testing.T) {
+	// The signature of the example was made with a random nonce.
+	sig := fromBase64("qx6wLYqmh+l9oCKTN6qIc+bw6ya+KJ8oMz0YP107epXAyGmt+3SNruPFKG7tZoLBLlUzGGus7ZwmwWep666VCw==")
+	pub, err := dnssec.ParsePublicKey(dnssec.AlgECDSAP256SHA256, p256DNSKEY)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := dnssec.VerifyRRSIG(pub, p256RRSIG, rrset, sig); err != nil {
+		t.Fatal(err)
+	}
+	other := append(append([]byte{}, rrset[:len(rrset)-1]...), 2)
+	if err := dnssec.VerifyRRSIG(pub, p256RRSIG, other, sig); err != rfc6979.ErrInvalidSignature {
+		t.Errorf("Expected %v, got %v", rfc6979.ErrInvalidSignature, err)
+	}
+	if err := dnssec.VerifyRRSIG(pub, p256RRSIG, rrset, sig[1:]); err == nil {
+		t.Error("Expected an error for a short signature")
+	}
+}
+
+func TestSignRRSIG(t *testing.T) {
+	sig, err := dnssec.SignRRSIG(p256Key, p256RRSIG, rrset)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := fromBase64("Uq9y66lg0RmsExg0b1xE7wlxScOFIz/N47fU8pc5XbS+N/8vNaPKWxYkJ3gfmhcwRTgI1Dq7CV178UN+V9K6mQ==")
+	if !bytes.Equal(sig, expected) {
+		t.Errorf("Expected %X, got %X", expected, sig)
+	}
+	if err := dnssec.VerifyRRSIG(&p256Key.PublicKey, p256RRSIG, rrset, sig); err != nil {
+		t.Error(err)
+	}
+
+	priv, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p384RRSIG := rrsig(1, dnssec.AlgECDSAP384SHA384, 3, 3600, expiration, inception, 10771, exampleNet)
+	sig, err = dnssec.SignRRSIG(priv, p384RRSIG, rrset)
+	if err != nil || len(sig) != 96 {
+		t.Fatalf("Expected a 96-byte signature, got %X, %v", sig, err)
+	}
+	if err := dnssec.VerifyRRSIG(&priv.PublicKey, p384RRSIG, rrset, sig); err != nil {
+		t.Error(err)
+	}
+
+	if _, err := dnssec.SignRRSIG(priv, p256RRSIG, rrset); err != dnssec.ErrInvalidAlgorithm {
+		t.Errorf("Expected %v, got %v", dnssec.ErrInvalidAlgorithm, err)
+	}
+	for name, bad := range map[string][]byte{
+		"no signer":   p256RRSIG[:18],
+		"unended":     p256RRSIG[:len(p256RRSIG)-1],
+		"trailing":    append(append([]byte{}, p256RRSIG...), 0),
+		"compression": append(append([]byte{}, p256RRSIG[:18]...), 0xc0, 0x0c),
+	} {
+		if _, err := dnssec.SignRRSIG(p256Key, bad, rrset); err != dnssec.ErrInvalidRData {
+			t.Errorf("%s: Expected %v, got %v", name, dnssec.ErrInvalidRData, err)
+		}
+	}
+}
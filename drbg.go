@@ -0,0 +1,213 @@
+package rfc6979
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"errors"
+	"hash"
+	"io"
+)
+
+// drbg is the HMAC_DRBG instance used by RFC 6979 section 3.2 to derive
+// nonce candidates. It is never reseeded.
+type drbg struct {
+	alg    func() hash.Hash
+	newMAC HMACFunc
+	k, v   []byte
+
+	// h is an HMAC keyed with k, buf is scratch space for the messages
+	// updating k.
+	h   hash.Hash
+	buf []byte
+
+	// additional is the additional_input of SP 800-90A section 10.1.2.5
+	// passed to every generate call, RFC 6979 uses none.
+	additional []byte
+
+	// trace receives the generated candidates, it's only used for
+	// diagnostics.
+	trace func(t []byte)
+
+	// macs counts HMAC invocations, it's only used by tests to check that
+	// the generator doesn't do more work than the specification permits.
+	macs int
+}
+
+// newDRBG instantiates the generator with the seed material, that is
+// int2octets(x) || bits2octets(h1), performing steps B to G. HMAC is computed
+// with newMAC, or with crypto/hmac if it's nil.
+func newDRBG(alg func() hash.Hash, newMAC HMACFunc, seed []byte) *drbg {
+	if newMAC == nil {
+		newMAC = hmac.New
+	}
+
+	holen := alg().Size()
+	d := &drbg{
+		alg:    alg,
+		newMAC: newMAC,
+		// Step B
+		v: bytes.Repeat([]byte{0x01}, holen),
+		// Step C
+		k:   make([]byte, holen),
+		buf: make([]byte, 0, holen+1+len(seed)),
+	}
+	d.h = newMAC(alg, d.k)
+
+	// Step D
+	d.updateKey(0x00, seed)
+
+	// Step E
+	d.v = d.mac(d.v, d.v)
+
+	// Step F
+	d.updateKey(0x01, seed)
+
+	// Step G
+	d.v = d.mac(d.v, d.v)
+
+	return d
+}
+
+// mac returns an HMAC of the message under the current key written into buf,
+// counting invocations.
+func (d *drbg) mac(m, buf []byte) []byte {
+	d.macs++
+	d.h.Reset()
+	d.h.Write(m)
+	return d.h.Sum(buf[:0])
+}
+
+// updateKey sets K = HMAC_K(V || sep || data).
+func (d *drbg) updateKey(sep byte, data []byte) {
+	d.buf = append(append(append(d.buf[:0], d.v...), sep), data...)
+	d.k = d.mac(d.buf, d.k)
+	d.h = d.newMAC(d.alg, d.k)
+}
+
+// generate returns at least n bytes of output as described in step H2. Only
+// as many whole blocks as required to reach n bytes are produced.
+func (d *drbg) generate(n int) []byte {
+	if len(d.additional) != 0 {
+		d.updateWith(d.additional)
+	}
+
+	holen := len(d.v)
+	t := make([]byte, 0, (n+holen-1)/holen*holen)
+	for len(t) < n {
+		d.v = d.mac(d.v, d.v)
+		t = append(t, d.v...)
+	}
+	return t
+}
+
+// update updates the state after a rejected candidate, the last part of
+// step H3.
+func (d *drbg) update() {
+	d.updateWith(d.additional)
+}
+
+// updateWith is the HMAC_DRBG_Update function of SP 800-90A section
+// 10.1.2.2, its second round is only performed for non-empty data.
+func (d *drbg) updateWith(data []byte) {
+	d.updateKey(0x00, data)
+	d.v = d.mac(d.v, d.v)
+	if len(data) != 0 {
+		d.updateKey(0x01, data)
+		d.v = d.mac(d.v, d.v)
+	}
+}
+
+// ErrRequestTooLarge is returned by DRBG.Generate for requests exceeding
+// the SP 800-90A limit.
+var ErrRequestTooLarge = errors.New("rfc6979: DRBG request is too large")
+
+// DRBG is the HMAC_DRBG of NIST SP 800-90A section 10.1.2, the deterministic
+// random bit generator RFC 6979 derives nonces with. It is never reseeded,
+// its output depends on the instantiation seed and the inputs only. A DRBG
+// must not be used concurrently.
+type DRBG struct {
+	d *drbg
+}
+
+// NewDRBG instantiates HMAC_DRBG over HMAC with alg using the seed material,
+// the concatenation of the entropy input, the nonce and the personalization
+// string in SP 800-90A terms. RFC 6979 instantiates it with
+// int2octets(x) || bits2octets(h1).
+func NewDRBG(alg func() hash.Hash, seed []byte) *DRBG {
+	return &DRBG{d: newDRBG(alg, nil, seed)}
+}
+
+// Generate returns n bytes of output performing the HMAC_DRBG_Generate
+// process of SP 800-90A section 10.1.2.5, including the final state update,
+// with the optional additional input. Consecutive calls with an empty
+// additional input produce the consecutive candidates T of RFC 6979 section
+// 3.2 step H. Requests over 65536 bytes fail with ErrRequestTooLarge.
+func (g *DRBG) Generate(n int, additionalInput []byte) ([]byte, error) {
+	if n < 0 || n > maxRequestBytes {
+		return nil, ErrRequestTooLarge
+	}
+
+	var out []byte
+	if len(additionalInput) != 0 {
+		g.d.updateWith(additionalInput)
+	}
+	if n > 0 {
+		out = g.d.generate(n)[:n]
+	}
+	g.d.updateWith(additionalInput)
+	return out, nil
+}
+
+// Update performs the HMAC_DRBG_Update function of SP 800-90A section
+// 10.1.2.2 with the provided data, mixing it into the state.
+func (g *DRBG) Update(data []byte) {
+	g.d.updateWith(data)
+}
+
+// maxRequestBytes is the maximum number of bytes produced by a single
+// HMAC_DRBG Generate request, 2^19 bits per SP 800-90A table 2.
+const maxRequestBytes = 1 << 16
+
+// drbgReader streams the output of consecutive HMAC_DRBG Generate requests.
+type drbgReader struct {
+	d *drbg
+
+	// buf is the unread part of the last block, produced is the number of
+	// bytes generated within the current request.
+	buf      []byte
+	produced int
+}
+
+// NewDRBGReader returns a reader producing an unlimited deterministic stream
+// from HMAC_DRBG (NIST SP 800-90A section 10.1.2) instantiated with the
+// seed material, exactly the way RFC 6979 instantiates it with
+// int2octets(x) || bits2octets(h1). The stream is made of Generate requests
+// of the maximum size, 65536 bytes, each followed by the state update
+// mandated by SP 800-90A, so its content doesn't depend on how it is read.
+// The generator is never reseeded.
+func NewDRBGReader(alg func() hash.Hash, seed []byte) io.Reader {
+	return &drbgReader{d: newDRBG(alg, nil, seed)}
+}
+
+// Read implements io.Reader, it never fails.
+func (r *drbgReader) Read(p []byte) (int, error) {
+	n := 0
+	for n < len(p) {
+		if len(r.buf) == 0 {
+			if r.produced >= maxRequestBytes {
+				r.d.update()
+				r.produced = 0
+			}
+			r.buf = r.d.generate(1)
+			if rest := maxRequestBytes - r.produced; len(r.buf) > rest {
+				r.buf = r.buf[:rest]
+			}
+			r.produced += len(r.buf)
+		}
+
+		c := copy(p[n:], r.buf)
+		r.buf = r.buf[c:]
+		n += c
+	}
+	return n, nil
+}
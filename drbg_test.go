@@ -0,0 +1,142 @@
+package rfc6979
+
+import (
+	"bytes"
+	"crypto/elliptic"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"hash"
+	"io"
+	"math/big"
+	"testing"
+)
+
+// Checks that the generator performs exactly the HMAC invocations required by
+// RFC 6979 section 3.2: four to instantiate, enough to fill each candidate and
+// two to update the state after every rejected candidate.
+func TestDRBGOperations(t *testing.T) {
+	curves := []elliptic.Curve{elliptic.P224(), elliptic.P256(), elliptic.P384(), elliptic.P521()}
+	algs := []func() hash.Hash{sha1.New, sha256.New224, sha256.New, sha512.New384, sha512.New}
+	x := big.NewInt(0x1337)
+
+	for _, c := range curves {
+		q := c.Params().N
+		qlen := q.BitLen()
+		rolen := (qlen + 7) >> 3
+
+		for _, alg := range algs {
+			holen := alg().Size()
+			h := alg()
+			h.Write([]byte("sample"))
+			digest := h.Sum(nil)
+
+			for rejects := 0; rejects < 3; rejects++ {
+				seed := append(int2octets(x, rolen), bits2octets(digest, q, qlen, rolen)...)
+				d := newDRBG(alg, nil, seed)
+
+				candidates := 0
+				nextSecret(d, q, func(*big.Int) bool {
+					candidates++
+					return candidates > rejects
+				})
+
+				perCandidate := (rolen + holen - 1) / holen
+				expected := 4 + candidates*perCandidate + 2*(candidates-1)
+				if candidates != rejects+1 || d.macs != expected {
+					t.Errorf("%s/%d: Expected %d HMAC calls for %d candidates, got %d for %d",
+						c.Params().Name, holen*8, expected, rejects+1, d.macs, candidates)
+				}
+			}
+		}
+	}
+}
+
+// Checks the stream is made of maximum-size Generate requests chained with
+// updates, regardless of how it's read.
+func TestDRBGReader(t *testing.T) {
+	seed := []byte("seed material")
+	for _, alg := range []func() hash.Hash{sha256.New, sha512.New384} {
+		total := 2*maxRequestBytes + 100
+
+		d := newDRBG(alg, nil, seed)
+		var expected []byte
+		for len(expected) < total {
+			n := total - len(expected)
+			if n > maxRequestBytes {
+				n = maxRequestBytes
+			}
+			expected = append(expected, d.generate(n)[:n]...)
+			d.update()
+		}
+
+		single := make([]byte, total)
+		if _, err := io.ReadFull(NewDRBGReader(alg, seed), single); err != nil {
+			t.Fatal(err)
+		}
+
+		var multi []byte
+		r := NewDRBGReader(alg, seed)
+		for i := 1; len(multi) < total; i++ {
+			chunk := make([]byte, i%97)
+			if len(multi)+len(chunk) > total {
+				chunk = chunk[:total-len(multi)]
+			}
+			if _, err := io.ReadFull(r, chunk); err != nil {
+				t.Fatal(err)
+			}
+			multi = append(multi, chunk...)
+		}
+
+		if !bytes.Equal(single, expected) {
+			t.Errorf("%d: Expected single read to match chained Generate requests", alg().Size())
+		}
+		if !bytes.Equal(multi, expected) {
+			t.Errorf("%d: Expected multiple reads to match a single one", alg().Size())
+		}
+	}
+}
+
+// https://tools.ietf.org/html/rfc6979#appendix-A.1.2
+func TestDRBG(t *testing.T) {
+	seed := mustDecodeHex("009A4D6792295A7F730FC3F2B49CBC0F62E862272F01795EDF0D54DB760F156D0DAC04C0322B3A204224")
+	expected := []string{
+		"9305A46DE7FF8EB107194DEBD3FD48AA20D5E7656CBE0EA69D2A8D4E7C67314A",
+		"C70C78608A3B5BE9289BE90EF6E81A9E2C1516D5751D2F75F50033E45F73BDEB",
+		"475E80E992140567FCC3A50DAB90FE84BCD7BB03638E9C4656A06F37F6508A7C",
+	}
+
+	g := NewDRBG(sha256.New, seed)
+	for i, e := range expected {
+		out, err := g.Generate(32, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(out, mustDecodeHex(e)) {
+			t.Errorf("#%d: Expected %s, got %X", i, e, out)
+		}
+	}
+}
+
+// Vectors computed with an independent SP 800-90A implementation.
+func TestDRBGInputs(t *testing.T) {
+	g := NewDRBG(sha256.New, []byte("seed material"))
+
+	out, err := g.Generate(40, []byte("additional"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := mustDecodeHex("1348ad9096b452f21b867798cd4a177cb9d2e36814e4eec26c21690704bb3d6618a2a67af9a56d1e"); !bytes.Equal(out, expected) {
+		t.Errorf("Expected %X, got %X", expected, out)
+	}
+
+	g.Update([]byte("update"))
+	out, _ = g.Generate(16, nil)
+	if expected := mustDecodeHex("e99f5c066d58d6129e00d39b2be32f79"); !bytes.Equal(out, expected) {
+		t.Errorf("Expected %X, got %X", expected, out)
+	}
+
+	if _, err := g.Generate(maxRequestBytes+1, nil); err != ErrRequestTooLarge {
+		t.Errorf("Expected %v, got %v", ErrRequestTooLarge, err)
+	}
+}
@@ -6,8 +6,10 @@ import (
 	"crypto/sha256"
 	"crypto/sha512"
 	"encoding/hex"
+	"fmt"
 	"hash"
 	"math/big"
+	"regexp"
 	"testing"
 
 	"github.com/nspcc-dev/rfc6979"
@@ -268,3 +270,61 @@ func dsaLoadInt(s string) *big.Int {
 
 	return new(big.Int).SetBytes(b)
 }
+
+// dsaFixtureLiteral signs the message and returns a dsaFixtures entry for it,
+// with r and s as fixed-width hex like in RFC 6979 appendix A.2. keyName and
+// algName are the Go expressions referring to key and alg.
+func dsaFixtureLiteral(name, keyName, algName string, key *dsaKey, alg func() hash.Hash, message string) (string, error) {
+	h := alg()
+	h.Write([]byte(message))
+	digest := h.Sum(nil)
+
+	g := key.subgroup / 8
+	if len(digest) > g {
+		digest = digest[0:g]
+	}
+
+	r, s, err := rfc6979.SignDSA(key.key, digest, alg)
+	if err != nil {
+		return "", err
+	}
+
+	width := 2 * ((key.key.Q.BitLen() + 7) / 8)
+	return fmt.Sprintf(`	{
+		name:    %q,
+		key:     %s,
+		alg:     %s,
+		message: %q,
+		r:       "%0*X",
+		s:       "%0*X",
+	},
+`, name, keyName, algName, message, width, r, width, s), nil
+}
+
+func TestDSAFixtureLiteral(t *testing.T) {
+	literal, err := dsaFixtureLiteral("1024/SHA-1 #1", "dsa1024", "sha1.New", dsa1024, sha1.New, "sample")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	f := dsaFixtures[0]
+	expected := fmt.Sprintf(`	{
+		name:    "%s",
+		key:     dsa1024,
+		alg:     sha1.New,
+		message: "%s",
+		r:       "%s",
+		s:       "%s",
+	},
+`, f.name, f.message, f.r, f.s)
+
+	if literal != expected {
+		t.Errorf("Expected\n%s\ngot\n%s", expected, literal)
+	}
+
+	m := regexp.MustCompile(`r:\s+"([0-9A-F]+)",\s+s:\s+"([0-9A-F]+)"`).FindStringSubmatch(literal)
+	if m == nil {
+		t.Fatalf("Can't parse generated literal")
+	}
+	testDsaFixture(&dsaFixture{name: f.name, key: dsa1024, alg: sha1.New, message: "sample", r: m[1], s: m[2]}, t)
+}
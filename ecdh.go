@@ -0,0 +1,54 @@
+package rfc6979
+
+import (
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"errors"
+	"hash"
+	"math/big"
+)
+
+// ErrUnsupportedCurve is returned for keys on curves that can't be used for
+// ECDSA signing.
+var ErrUnsupportedCurve = errors.New("rfc6979: unsupported curve")
+
+// ECDSAKeyFromECDH converts a crypto/ecdh private key on P-256, P-384 or
+// P-521 to the equivalent crypto/ecdsa one. X25519 keys are rejected.
+func ECDSAKeyFromECDH(k *ecdh.PrivateKey) (*ecdsa.PrivateKey, error) {
+	var c elliptic.Curve
+	switch k.Curve() {
+	case ecdh.P256():
+		c = elliptic.P256()
+	case ecdh.P384():
+		c = elliptic.P384()
+	case ecdh.P521():
+		c = elliptic.P521()
+	default:
+		return nil, ErrUnsupportedCurve
+	}
+
+	// Public keys are encoded as uncompressed points, 0x04 || X || Y.
+	pub := k.PublicKey().Bytes()
+	size := (len(pub) - 1) / 2
+
+	return &ecdsa.PrivateKey{
+		PublicKey: ecdsa.PublicKey{
+			Curve: c,
+			X:     new(big.Int).SetBytes(pub[1 : 1+size]),
+			Y:     new(big.Int).SetBytes(pub[1+size:]),
+		},
+		D: new(big.Int).SetBytes(k.Bytes()),
+	}, nil
+}
+
+// SignECDSAFromECDH signs the hash with a crypto/ecdh private key like
+// SignECDSA does, see ECDSAKeyFromECDH for the supported curves.
+func SignECDSAFromECDH(k *ecdh.PrivateKey, hash []byte, alg func() hash.Hash) (r, s *big.Int, err error) {
+	priv, err := ECDSAKeyFromECDH(k)
+	if err != nil {
+		return
+	}
+	r, s = SignECDSA(priv, hash, alg)
+	return
+}
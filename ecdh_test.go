@@ -0,0 +1,53 @@
+package rfc6979_test
+
+import (
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/sha256"
+	"testing"
+
+	"github.com/nspcc-dev/rfc6979"
+)
+
+func TestSignECDSAFromECDH(t *testing.T) {
+	digest := sha256.Sum256([]byte("sample"))
+
+	for _, c := range []ecdh.Curve{ecdh.P256(), ecdh.P384(), ecdh.P521()} {
+		k, err := c.GenerateKey(rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		r, s, err := rfc6979.SignECDSAFromECDH(k, digest[:], sha256.New)
+		if err != nil {
+			t.Fatalf("%s: %v", c, err)
+		}
+
+		priv, err := rfc6979.ECDSAKeyFromECDH(k)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !priv.Curve.IsOnCurve(priv.X, priv.Y) {
+			t.Errorf("%s: Converted public key is not on the curve", c)
+		}
+
+		// Cross-check the conversion with crypto/ecdsa's own one.
+		ek, err := priv.ECDH()
+		if err != nil || !ek.Equal(k) {
+			t.Errorf("%s: Expected conversion to round-trip, got %v", c, err)
+		}
+
+		if !ecdsa.Verify(&priv.PublicKey, digest[:], r, s) {
+			t.Errorf("%s: Invalid signature", c)
+		}
+	}
+
+	k, err := ecdh.X25519().GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := rfc6979.SignECDSAFromECDH(k, digest[:], sha256.New); err != rfc6979.ErrUnsupportedCurve {
+		t.Errorf("Expected %v, got %v", rfc6979.ErrUnsupportedCurve, err)
+	}
+}
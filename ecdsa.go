@@ -3,10 +3,16 @@ package rfc6979
 import (
 	"crypto/ecdsa"
 	"crypto/elliptic"
+	"encoding/binary"
+	"errors"
 	"hash"
 	"math/big"
 )
 
+// ErrTruncation is returned when the hash would be truncated while
+// NoTruncate is requested.
+var ErrTruncation = errors.New("rfc6979: hash is longer than the group order")
+
 // SignECDSA signs an arbitrary length hash (which should be the result of
 // hashing a larger message) using the private key, priv. It returns the
 // signature as a pair of integers.
@@ -14,31 +20,247 @@ import (
 // Note that FIPS 186-3 section 4.6 specifies that the hash should be truncated
 // to the byte-length of the subgroup. This function does not perform that
 // truncation itself.
+//
+// priv is only read when signing starts, copying the private scalar, so
+// changes made to priv after that don't affect the signature. Modifying priv
+// during that read is a data race, which may produce a signature with a mix
+// of both keys: callers modifying shared keys have to synchronize the access
+// to priv.
 func SignECDSA(priv *ecdsa.PrivateKey, hash []byte, alg func() hash.Hash) (r, s *big.Int) {
-	c := priv.PublicKey.Curve
-	N := c.Params().N
+	return SignECDSAWithOptions(priv, hash, alg, nil)
+}
 
-	generateSecret(N, priv.D, alg, hash, func(k *big.Int) bool {
+// SignECDSAWithOptions is like SignECDSA, but allows to deviate from RFC 6979
+// as described by opts. A nil opts is equivalent to calling SignECDSA.
+func SignECDSAWithOptions(priv *ecdsa.PrivateKey, hash []byte, alg func() hash.Hash, opts *Options) (r, s *big.Int) {
+	r, s = new(big.Int), new(big.Int)
+	signECDSA(priv, hash, alg, opts, r, s, nil)
+	return
+}
+
+// SignECDSAChecked is like SignECDSAWithOptions, but also checks the
+// assertions requested by opts, returning an error instead of a signature if
+// any of them fails. Currently that's NoTruncate.
+func SignECDSAChecked(priv *ecdsa.PrivateKey, hash []byte, alg func() hash.Hash, opts *Options) (r, s *big.Int, err error) {
+	if opts != nil && opts.Truncation == NoTruncate && len(hash)*8 > priv.Curve.Params().N.BitLen() {
+		err = ErrTruncation
+		return
+	}
+	r, s = SignECDSAWithOptions(priv, hash, alg, opts)
+	return
+}
+
+// SignECDSAStrict is like SignECDSA, but refuses to sign with invalid or
+// trivially weak keys, see CheckPrivateKey.
+func SignECDSAStrict(priv *ecdsa.PrivateKey, hash []byte, alg func() hash.Hash) (r, s *big.Int, err error) {
+	if err = CheckPrivateKey(priv); err != nil {
+		return
+	}
+	r, s = SignECDSA(priv, hash, alg)
+	return
+}
+
+// SignECDSAReuse is like SignECDSA, but stores the signature into the
+// caller-provided r and s instead of allocating new integers, so they can be
+// reused across calls in tight loops. Previous values of r and s are
+// overwritten.
+func SignECDSAReuse(priv *ecdsa.PrivateKey, hash []byte, alg func() hash.Hash, r, s *big.Int) {
+	signECDSA(priv, hash, alg, nil, r, s, nil)
+}
+
+// signECDSA is the common implementation of the ECDSA signers. It stores the
+// signature into r and s, k⁻¹ into kInv unless it's nil, and returns the
+// recovery id: bit 0 is the parity of the y coordinate of the ephemeral point
+// and bit 1 is set if its x coordinate is not less than the group order.
+func signECDSA(priv *ecdsa.PrivateKey, hash []byte, alg func() hash.Hash, opts *Options, r, s, kInv *big.Int) (recid byte) {
+	if opts == nil {
+		opts = new(Options)
+	}
+
+	// Work on copies so that later changes to priv don't affect the
+	// signature. The copy of the private scalar is wiped when done.
+	c := priv.Curve
+	N := new(big.Int).Set(c.Params().N)
+	d := new(big.Int).Set(priv.D)
+	defer wipeInt(d)
+
+	if opts.Truncation == NoTruncate && len(hash)*8 > N.BitLen() {
+		panic(ErrTruncation)
+	}
+
+	var e *big.Int
+	if opts.Truncation == ReduceMod {
+		e = new(big.Int).SetBytes(hash)
+		e.Mod(e, N)
+	} else {
+		e = hashToInt(hash, c)
+	}
+
+	generateSecretOpts(N, d, alg, hash, opts, func(k *big.Int) bool {
 		inv := new(big.Int).ModInverse(k, N)
-		r, _ = priv.Curve.ScalarBaseMult(k.Bytes())
-		r.Mod(r, N)
+		x, y := c.ScalarBaseMult(scalarBytes(c, k))
+		recid = byte(y.Bit(0))
+		if x.Cmp(N) >= 0 {
+			recid |= 2
+		}
+		r.Mod(x, N)
 
 		if r.Sign() == 0 {
 			return false
 		}
 
-		e := hashToInt(hash, c)
-		s = new(big.Int).Mul(priv.D, r)
+		s.Mul(d, r)
 		s.Add(s, e)
 		s.Mul(s, inv)
 		s.Mod(s, N)
 
+		if kInv != nil {
+			kInv.Set(inv)
+		}
 		return s.Sign() != 0
 	})
 
+	// Negating s is equivalent to negating k, which mirrors the ephemeral
+	// point and thus flips the parity of its y coordinate.
+	if opts.LowS && !IsLowS(c, s) {
+		s.Sub(N, s)
+		recid ^= 1
+		if kInv != nil {
+			kInv.Sub(N, kInv)
+		}
+	}
+
 	return
 }
 
+// SignECDSAExposingNonceInverse is like SignECDSAWithOptions, but also
+// returns k⁻¹ mod N, the inverse of the nonce used to compute s, for
+// adaptor signature and threshold constructions building upon it.
+//
+// This is dangerous: anyone knowing k⁻¹ along with the signature and the hash
+// can compute the private key as d = (s·k - h)·r⁻¹ mod N. The returned value
+// must be treated exactly like the private key itself.
+func SignECDSAExposingNonceInverse(priv *ecdsa.PrivateKey, hash []byte, alg func() hash.Hash, opts *Options) (r, s, kInv *big.Int) {
+	r, s, kInv = new(big.Int), new(big.Int), new(big.Int)
+	signECDSA(priv, hash, alg, opts, r, s, kInv)
+	return
+}
+
+// SignECDSATrace is a diagnostic variant of SignECDSAWithOptions, which also
+// returns every octet string T produced by the nonce generator in step H2 of
+// RFC 6979 section 3.2, in order, including the candidates that were
+// rejected, either for being out of range or for yielding a zero r or s. The
+// last one is the nonce k used. For the NIST curves there is practically
+// always exactly one T. It is meant for pinpointing where another
+// implementation diverges; T contains the nonce, so the output must be
+// treated like the private key itself.
+func SignECDSATrace(priv *ecdsa.PrivateKey, hash []byte, alg func() hash.Hash, opts *Options) (r, s *big.Int, candidates [][]byte) {
+	var o Options
+	if opts != nil {
+		o = *opts
+	}
+	o.trace = func(t []byte) {
+		candidates = append(candidates, t)
+	}
+
+	r, s = new(big.Int), new(big.Int)
+	signECDSA(priv, hash, alg, &o, r, s, nil)
+	return r, s, candidates
+}
+
+// SignECDSAKeyed signs message using the private key, priv, after reducing it
+// to a digest with HMAC under hmacKey, a secret distinct from the signing key.
+// It returns the signature as a pair of integers.
+//
+// Holders of the same signing key but different HMAC keys produce different,
+// yet still deterministic, signatures over the same message. The result is a
+// regular ECDSA signature over HMAC(hmacKey, message), so a verifier must know
+// hmacKey to recompute the digest. The HMAC key only isolates signers from each
+// other; it adds nothing to the secrecy of priv.
+func SignECDSAKeyed(priv *ecdsa.PrivateKey, hmacKey, message []byte, alg func() hash.Hash) (r, s *big.Int) {
+	return SignECDSA(priv, mac(alg, hmacKey, message, nil), alg)
+}
+
+// SignECDSAMessage hashes the message with alg and signs the digest using the
+// private key, priv, truncating it to the bit length of the curve order. It
+// returns the signature as a pair of integers.
+//
+// An empty message is perfectly valid, its signature covers the hash of the
+// empty input. That's different from passing an empty hash to SignECDSA,
+// which signs zero.
+func SignECDSAMessage(priv *ecdsa.PrivateKey, message []byte, alg func() hash.Hash) (r, s *big.Int) {
+	return SignECDSAParts(priv, alg, message)
+}
+
+// SignECDSASalted hashes salt followed by the message with alg and signs the
+// digest using the private key, priv. The salt affects the digest and thus
+// the deterministic nonce; verifiers must hash the same salt || message. It
+// returns the signature as a pair of integers.
+func SignECDSASalted(priv *ecdsa.PrivateKey, salt, message []byte, alg func() hash.Hash) (r, s *big.Int) {
+	return SignECDSAParts(priv, alg, salt, message)
+}
+
+// SignECDSADoubleHash signs H(H(message)), where H is alg, using the private
+// key, priv, as used by Bitcoin with SHA-256. The outer digest is what gets
+// signed and what seeds the nonce generator, exactly as if it was passed to
+// SignECDSA. It returns the signature as a pair of integers.
+func SignECDSADoubleHash(priv *ecdsa.PrivateKey, message []byte, alg func() hash.Hash) (r, s *big.Int) {
+	h := alg()
+	h.Write(message)
+	inner := h.Sum(nil)
+
+	h.Reset()
+	h.Write(inner)
+	return SignECDSA(priv, h.Sum(nil), alg)
+}
+
+// SignECDSAParts hashes parts in order with alg, which is equivalent to hashing
+// their concatenation, and signs the resulting digest using the private key,
+// priv. It returns the signature as a pair of integers.
+func SignECDSAParts(priv *ecdsa.PrivateKey, alg func() hash.Hash, parts ...[]byte) (r, s *big.Int) {
+	return SignECDSAPartsWithOptions(priv, alg, nil, parts...)
+}
+
+// SignECDSAPartsWithOptions is like SignECDSAParts, but hashes and signs as
+// opts, which may be nil, request.
+func SignECDSAPartsWithOptions(priv *ecdsa.PrivateKey, alg func() hash.Hash, opts *Options, parts ...[]byte) (r, s *big.Int) {
+	h := alg()
+	var prefix [8]byte
+	for _, p := range parts {
+		if opts != nil && opts.LengthPrefix {
+			binary.BigEndian.PutUint64(prefix[:], uint64(len(p)))
+			h.Write(prefix[:])
+		}
+		h.Write(p)
+	}
+	return SignECDSAWithOptions(priv, h.Sum(nil), alg, opts)
+}
+
+// NonceR returns the r component SignECDSA would produce for the same
+// arguments, i.e. the x coordinate of k·G modulo the group order, without
+// computing s. It is meant for diagnostics and research, like analyzing the
+// distribution of nonce points; note that with RFC 6979 k depends on the
+// hash, so r can't be committed to before the message is known.
+func NonceR(priv *ecdsa.PrivateKey, hash []byte, alg func() hash.Hash) *big.Int {
+	c := priv.Curve
+	N := c.Params().N
+
+	var r *big.Int
+	generateSecret(N, priv.D, alg, hash, func(k *big.Int) bool {
+		r, _ = c.ScalarBaseMult(scalarBytes(c, k))
+		r.Mod(r, N)
+		return r.Sign() != 0
+	})
+
+	return r
+}
+
+// scalarBytes returns the secret scalar k < N as OrderSize(c) bytes, so
+// that the length passed to ScalarBaseMult doesn't depend on its value.
+func scalarBytes(c elliptic.Curve, k *big.Int) []byte {
+	return k.FillBytes(make([]byte, OrderSize(c)))
+}
+
 // copied from crypto/ecdsa
 func hashToInt(hash []byte, c elliptic.Curve) *big.Int {
 	orderBits := c.Params().N.BitLen()
@@ -1,13 +1,18 @@
 package rfc6979_test
 
 import (
+	"bytes"
 	"crypto/ecdsa"
 	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rand"
 	"crypto/sha1"
 	"crypto/sha256"
 	"crypto/sha512"
 	"hash"
 	"math/big"
+	mrand "math/rand"
+	"sync"
 	"testing"
 
 	"github.com/nspcc-dev/rfc6979"
@@ -18,7 +23,7 @@ type ecdsaFixture struct {
 	key     *ecdsaKey
 	alg     func() hash.Hash
 	message string
-	r, s    string
+	k, r, s string
 }
 
 type ecdsaKey struct {
@@ -411,6 +416,68 @@ func TestECDSA(t *testing.T) {
 	}
 }
 
+// Every fixture sharing a hash constructor is signed from many goroutines at
+// once, any hash.Hash instance cached across calls would mix their states and
+// break the signatures. Meant to be run with -race.
+func TestECDSASharedConstructor(t *testing.T) {
+	const rounds = 8
+
+	var wg sync.WaitGroup
+	for i := 0; i < rounds; i++ {
+		for j := range fixtures {
+			wg.Add(1)
+			go func(f *ecdsaFixture) {
+				defer wg.Done()
+
+				h := f.alg()
+				h.Write([]byte(f.message))
+				r, s := rfc6979.SignECDSA(f.key.key, h.Sum(nil), f.alg)
+				if r.Cmp(ecdsaLoadInt(f.r)) != 0 || s.Cmp(ecdsaLoadInt(f.s)) != 0 {
+					t.Errorf("%s: Signature differs under concurrent use", f.name)
+				}
+			}(&fixtures[j])
+		}
+	}
+	wg.Wait()
+}
+
+// Random digests of random lengths signed with every curve and hash must
+// verify, and flipping any bit that survives truncation must break them.
+func TestECDSARoundTripProperty(t *testing.T) {
+	iterations := 8
+	if testing.Short() {
+		iterations = 1
+	}
+	rng := mrand.New(mrand.NewSource(6979))
+
+	for _, key := range []*ecdsaKey{p224, p256, p384, p521} {
+		pub := &key.key.PublicKey
+		orderBits := pub.Curve.Params().N.BitLen()
+
+		for _, alg := range []func() hash.Hash{sha1.New, sha256.New224, sha256.New, sha512.New384, sha512.New} {
+			for i := 0; i < iterations; i++ {
+				digest := make([]byte, 1+rng.Intn(80))
+				rng.Read(digest)
+
+				r, s := rfc6979.SignECDSA(key.key, digest, alg)
+				if !ecdsa.Verify(pub, digest, r, s) {
+					t.Fatalf("%s: Invalid signature of %X", pub.Curve.Params().Name, digest)
+				}
+
+				signedBits := len(digest) * 8
+				if signedBits > orderBits {
+					signedBits = orderBits
+				}
+				bit := rng.Intn(signedBits)
+				digest[bit/8] ^= 0x80 >> uint(bit%8)
+				if ecdsa.Verify(pub, digest, r, s) {
+					t.Fatalf("%s: Signature verifies with bit %d flipped", pub.Curve.Params().Name, bit)
+				}
+			}
+		}
+	}
+}
+
 func ecdsaLoadInt(s string) (n *big.Int) {
 	n, _ = new(big.Int).SetString(s, 16)
 	return
@@ -440,3 +507,341 @@ func testEcsaFixture(f *ecdsaFixture, t *testing.T) {
 		t.Errorf("%s: Expected S of %X, got %X", f.name, expectedS, s)
 	}
 }
+
+func TestECDSAKeyed(t *testing.T) {
+	key := p256.key
+	message := []byte("sample")
+
+	r1, s1 := rfc6979.SignECDSAKeyed(key, []byte("tenant A"), message, sha256.New)
+	r2, s2 := rfc6979.SignECDSAKeyed(key, []byte("tenant A"), message, sha256.New)
+	r3, s3 := rfc6979.SignECDSAKeyed(key, []byte("tenant B"), message, sha256.New)
+
+	if r1.Cmp(r2) != 0 || s1.Cmp(s2) != 0 {
+		t.Errorf("Expected the same HMAC key to produce the same signature")
+	}
+
+	if r1.Cmp(r3) == 0 || s1.Cmp(s3) == 0 {
+		t.Errorf("Expected different HMAC keys to produce different signatures")
+	}
+
+	m := hmac.New(sha256.New, []byte("tenant A"))
+	m.Write(message)
+	if !ecdsa.Verify(&key.PublicKey, m.Sum(nil), r1, s1) {
+		t.Errorf("Invalid signature")
+	}
+}
+
+func TestECDSAParts(t *testing.T) {
+	key := p384.key
+	parts := [][]byte{[]byte("sa"), nil, []byte("mp"), []byte("le")}
+
+	r, s := rfc6979.SignECDSAParts(key, sha512.New384, parts...)
+
+	digest := sha512.Sum384([]byte("sample"))
+	expectedR, expectedS := rfc6979.SignECDSA(key, digest[:], sha512.New384)
+
+	if r.Cmp(expectedR) != 0 || s.Cmp(expectedS) != 0 {
+		t.Errorf("Expected signature over parts to match the one over their concatenation")
+	}
+}
+
+func TestECDSAPartsLengthPrefix(t *testing.T) {
+	key := p256.key
+	opts := &rfc6979.Options{LengthPrefix: true}
+
+	r1, s1 := rfc6979.SignECDSAParts(key, sha256.New, []byte("ab"), []byte("c"))
+	r2, s2 := rfc6979.SignECDSAParts(key, sha256.New, []byte("a"), []byte("bc"))
+	if r1.Cmp(r2) != 0 || s1.Cmp(s2) != 0 {
+		t.Fatal("Expected naive concatenation to collide")
+	}
+
+	r1, s1 = rfc6979.SignECDSAPartsWithOptions(key, sha256.New, opts, []byte("ab"), []byte("c"))
+	r2, _ = rfc6979.SignECDSAPartsWithOptions(key, sha256.New, opts, []byte("a"), []byte("bc"))
+	if r1.Cmp(r2) == 0 {
+		t.Error("Expected length-prefixed parts to produce distinct signatures")
+	}
+
+	// 0x0000000000000002 "ab" 0x0000000000000001 "c"
+	digest := sha256.Sum256([]byte("\x00\x00\x00\x00\x00\x00\x00\x02ab\x00\x00\x00\x00\x00\x00\x00\x01c"))
+	expectedR, expectedS := rfc6979.SignECDSA(key, digest[:], sha256.New)
+	if r1.Cmp(expectedR) != 0 || s1.Cmp(expectedS) != 0 {
+		t.Error("Expected parts to be prefixed with 64-bit big-endian lengths")
+	}
+}
+
+// Triangulates GenerateK, SignECDSA and the manual computation of (r, s) from
+// the nonce against every RFC fixture.
+func TestECDSAConsistency(t *testing.T) {
+	for _, f := range fixtures {
+		h := f.alg()
+		h.Write([]byte(f.message))
+		digest := h.Sum(nil)
+
+		r, s := rfc6979.SignECDSA(f.key.key, digest, f.alg)
+		manualR, manualS := manualECDSA(f.key.key, digest, f.alg)
+		expectedR, expectedS := ecdsaLoadInt(f.r), ecdsaLoadInt(f.s)
+
+		if manualR.Cmp(expectedR) != 0 || r.Cmp(expectedR) != 0 {
+			t.Errorf("%s: Expected R of %X, got %X (manual) and %X (signer)", f.name, expectedR, manualR, r)
+		}
+
+		if manualS.Cmp(expectedS) != 0 || s.Cmp(expectedS) != 0 {
+			t.Errorf("%s: Expected S of %X, got %X (manual) and %X (signer)", f.name, expectedS, manualS, s)
+		}
+	}
+}
+
+// manualECDSA computes an ECDSA signature using the nonce from GenerateK.
+func manualECDSA(priv *ecdsa.PrivateKey, digest []byte, alg func() hash.Hash) (r, s *big.Int) {
+	N := priv.Curve.Params().N
+	k := rfc6979.GenerateK(N, priv.D, alg, digest)
+
+	r, _ = priv.Curve.ScalarBaseMult(k.Bytes())
+	r.Mod(r, N)
+
+	e := new(big.Int).SetBytes(digest)
+	if excess := len(digest)*8 - N.BitLen(); excess > 0 {
+		e.Rsh(e, uint(excess))
+	}
+
+	s = new(big.Int).Mul(priv.D, r)
+	s.Add(s, e)
+	s.Mul(s, new(big.Int).ModInverse(k, N))
+	s.Mod(s, N)
+	return
+}
+
+func TestECDSAReuse(t *testing.T) {
+	r, s := new(big.Int), new(big.Int)
+	for _, f := range fixtures {
+		h := f.alg()
+		h.Write([]byte(f.message))
+
+		rfc6979.SignECDSAReuse(f.key.key, h.Sum(nil), f.alg, r, s)
+		if r.Cmp(ecdsaLoadInt(f.r)) != 0 || s.Cmp(ecdsaLoadInt(f.s)) != 0 {
+			t.Errorf("%s: Expected reused integers to hold the RFC vector", f.name)
+		}
+	}
+}
+
+func BenchmarkSignECDSA(b *testing.B) {
+	digest := sha256.Sum256([]byte("sample"))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		rfc6979.SignECDSA(p256.key, digest[:], sha256.New)
+	}
+}
+
+// Compares the deterministic signer with the randomized one of the standard
+// library, the difference being mostly the cost of the nonce derivation.
+func BenchmarkSignP256(b *testing.B) {
+	digest := sha256.Sum256([]byte("sample"))
+
+	b.Run("rfc6979", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			rfc6979.SignECDSA(p256.key, digest[:], sha256.New)
+		}
+	})
+
+	b.Run("crypto/ecdsa", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, err := ecdsa.Sign(rand.Reader, p256.key, digest[:]); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkSignECDSAReuse(b *testing.B) {
+	digest := sha256.Sum256([]byte("sample"))
+	r, s := new(big.Int), new(big.Int)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		rfc6979.SignECDSAReuse(p256.key, digest[:], sha256.New, r, s)
+	}
+}
+
+func TestECDSAKeyMutation(t *testing.T) {
+	key := *p256.key
+	original := new(big.Int).Set(key.D)
+	key.D = new(big.Int).Set(original)
+	replacement := new(big.Int).Sub(key.Curve.Params().N, original)
+	digest := sha256.Sum256([]byte("sample"))
+	expectedR, expectedS := rfc6979.SignECDSA(p256.key, digest[:], sha256.New)
+
+	// The key is modified in place from another goroutine as soon as the
+	// signer starts hashing, concurrently with the rest of the computation.
+	// Under -race this catches any read of priv after the initial copy.
+	start, done := make(chan struct{}), make(chan struct{})
+	go func() {
+		<-start
+		key.D.Set(replacement)
+		key.PublicKey.X, key.PublicKey.Y = nil, nil
+		close(done)
+	}()
+
+	var once sync.Once
+	alg := func() hash.Hash {
+		once.Do(func() { close(start) })
+		return sha256.New()
+	}
+
+	r, s := rfc6979.SignECDSA(&key, digest[:], alg)
+	<-done
+
+	if r.Cmp(expectedR) != 0 || s.Cmp(expectedS) != 0 {
+		t.Errorf("Expected signature to be made with the key read when signing started")
+	}
+}
+
+func TestNonceR(t *testing.T) {
+	for _, f := range fixtures {
+		h := f.alg()
+		h.Write([]byte(f.message))
+		digest := h.Sum(nil)
+
+		expected, _ := rfc6979.SignECDSA(f.key.key, digest, f.alg)
+		if r := rfc6979.NonceR(f.key.key, digest, f.alg); r.Cmp(expected) != 0 {
+			t.Errorf("%s: Expected R of %X, got %X", f.name, expected, r)
+		}
+	}
+}
+
+func TestECDSASalted(t *testing.T) {
+	key := p256.key
+	salt, message := []byte("salt"), []byte("sample")
+
+	r, s := rfc6979.SignECDSASalted(key, salt, message, sha256.New)
+
+	digest := sha256.Sum256([]byte("saltsample"))
+	expectedR, expectedS := rfc6979.SignECDSA(key, digest[:], sha256.New)
+	if r.Cmp(expectedR) != 0 || s.Cmp(expectedS) != 0 {
+		t.Errorf("Expected salted signature to match the one over salt||message")
+	}
+
+	if !ecdsa.Verify(&key.PublicKey, digest[:], r, s) {
+		t.Errorf("Invalid signature")
+	}
+
+	otherR, _ := rfc6979.SignECDSASalted(key, []byte("pepper"), message, sha256.New)
+	if otherR.Cmp(r) == 0 {
+		t.Errorf("Expected salt to affect the nonce")
+	}
+}
+
+func TestECDSADoubleHash(t *testing.T) {
+	message := []byte("sample")
+	inner := sha256.Sum256(message)
+	digest := sha256.Sum256(inner[:])
+
+	k1 := secp256k1Key("C9AFA9D845BA75166B5C215767B1D6934E50C3DB36E89B127B8A622B120F6721")
+	for _, key := range []*ecdsa.PrivateKey{p224.key, p256.key, p384.key, p521.key, k1} {
+		r, s := rfc6979.SignECDSADoubleHash(key, message, sha256.New)
+		expectedR, expectedS := rfc6979.SignECDSA(key, digest[:], sha256.New)
+
+		if r.Cmp(expectedR) != 0 || s.Cmp(expectedS) != 0 {
+			t.Errorf("%s: Expected signature over the double hash", key.Curve.Params().Name)
+		}
+	}
+
+	// Bitcoin's double SHA-256 of "sample", as signed by the decred
+	// secp256k1 port. Its S happens to be low already, so the low-S
+	// normalization applied there makes no difference.
+	r, s := rfc6979.SignECDSADoubleHash(k1, message, sha256.New)
+	expectedR := ecdsaLoadInt("47E103F6E9703CDD126BF1E3778F5A64F83294242586F79D12687AC6DB216A27")
+	expectedS := ecdsaLoadInt("35029D8B8B994A688C70DFA4371762119A852A32C3B851D454D6581C6C013920")
+	if r.Cmp(expectedR) != 0 || s.Cmp(expectedS) != 0 {
+		t.Errorf("secp256k1: Expected (%X, %X), got (%X, %X)", expectedR, expectedS, r, s)
+	}
+}
+
+func TestECDSAExposingNonceInverse(t *testing.T) {
+	for _, f := range fixtures {
+		h := f.alg()
+		h.Write([]byte(f.message))
+		digest := h.Sum(nil)
+		N := f.key.key.Curve.Params().N
+
+		for _, opts := range []*rfc6979.Options{nil, {LowS: true}} {
+			r, s, kInv := rfc6979.SignECDSAExposingNonceInverse(f.key.key, digest, f.alg, opts)
+
+			e := new(big.Int).SetBytes(digest)
+			if excess := len(digest)*8 - N.BitLen(); excess > 0 {
+				e.Rsh(e, uint(excess))
+			}
+
+			// s = k⁻¹(e + r·d) mod N
+			expected := new(big.Int).Mul(r, f.key.key.D)
+			expected.Add(expected, e)
+			expected.Mul(expected, kInv)
+			expected.Mod(expected, N)
+
+			if s.Cmp(expected) != 0 {
+				t.Errorf("%s: Expected S of %X, got %X", f.name, expected, s)
+			}
+		}
+	}
+}
+
+// https://tools.ietf.org/html/rfc6979#appendix-A.2.5
+func TestECDSATrace(t *testing.T) {
+	key := p256.key
+	digest := sha256.Sum256([]byte("sample"))
+
+	r, s, candidates := rfc6979.SignECDSATrace(key, digest[:], sha256.New, nil)
+	if r.Cmp(ecdsaLoadInt("EFD48B2AACB6A8FD1140DD9CD45E81D69D2C877B56AAF991C34D0EA84EAF3716")) != 0 ||
+		s.Cmp(ecdsaLoadInt("F7CB1C942D657C41D436C7A1B6E29F65F3E900DBB9AFF4064DC4AB2F843ACDA8")) != 0 {
+		t.Errorf("Expected trace not to change the signature")
+	}
+	if len(candidates) != 1 {
+		t.Fatalf("Expected a single candidate, got %d", len(candidates))
+	}
+
+	// Steps B to H2 by hand, x and h1 are both 32 bytes for P-256.
+	hm := func(k []byte, parts ...[]byte) []byte {
+		h := hmac.New(sha256.New, k)
+		for _, p := range parts {
+			h.Write(p)
+		}
+		return h.Sum(nil)
+	}
+	v := bytes.Repeat([]byte{0x01}, 32)
+	k := make([]byte, 32)
+	seed := append(key.D.FillBytes(make([]byte, 32)), digest[:]...)
+	k = hm(k, v, []byte{0x00}, seed)
+	v = hm(k, v)
+	k = hm(k, v, []byte{0x01}, seed)
+	v = hm(k, v)
+	v = hm(k, v)
+
+	if !bytes.Equal(candidates[0], v) {
+		t.Errorf("Expected T of %X, got %X", v, candidates[0])
+	}
+	if expected := ecdsaLoadInt("A6E3C57DD01ABE90086538398355DD4C3B17AA873382B0F24D6129493D8AAD60"); new(big.Int).SetBytes(candidates[0]).Cmp(expected) != 0 {
+		t.Errorf("Expected T to be the RFC nonce %X, got %X", expected, candidates[0])
+	}
+}
+
+func TestECDSAEmptyMessage(t *testing.T) {
+	algs := []func() hash.Hash{sha1.New, sha256.New224, sha256.New, sha512.New384, sha512.New}
+
+	for _, key := range []*ecdsaKey{p224, p256, p384, p521} {
+		for _, alg := range algs {
+			name := key.key.Curve.Params().Name
+			digest := alg().Sum(nil)
+
+			r1, s1 := rfc6979.SignECDSAMessage(key.key, nil, alg)
+			r2, s2 := rfc6979.SignECDSAMessage(key.key, []byte{}, alg)
+
+			if r1.Cmp(r2) != 0 || s1.Cmp(s2) != 0 {
+				t.Errorf("%s: Expected empty message signatures to be identical", name)
+			}
+
+			if !ecdsa.Verify(&key.key.PublicKey, digest, r1, s1) {
+				t.Errorf("%s: Invalid signature over the empty message", name)
+			}
+		}
+	}
+}
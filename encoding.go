@@ -0,0 +1,335 @@
+package rfc6979
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"encoding/asn1"
+	"errors"
+	"hash"
+	"math/big"
+)
+
+// ErrSignatureOverflow is returned when a signature component doesn't fit into
+// the fixed-width encoding of the curve.
+var ErrSignatureOverflow = errors.New("rfc6979: signature component is too large")
+
+// ErrInvalidP1363 is returned when a signature can't be decoded as IEEE P1363
+// r || s for the curve.
+var ErrInvalidP1363 = errors.New("rfc6979: invalid P1363 signature")
+
+// ErrInvalidDER is returned when a signature can't be decoded as a DER
+// encoded ASN.1 SEQUENCE of two INTEGERs.
+var ErrInvalidDER = errors.New("rfc6979: invalid DER signature")
+
+// CoordinateSize returns the byte length of the field elements of c,
+// ceil(BitSize/8), which is the width of each coordinate in an uncompressed
+// point.
+func CoordinateSize(c elliptic.Curve) int {
+	return (c.Params().BitSize + 7) / 8
+}
+
+// OrderSize returns the byte length of the group order of c,
+// ceil(N.BitLen()/8), which is the width of r and s in fixed-size signature
+// encodings like IEEE P1363, and of k in RFC 6979. It is equal to
+// CoordinateSize for the NIST curves, including P-521 where both are 66
+// bytes, but curves with an order larger than the field, like secp160r1,
+// need one byte more.
+func OrderSize(c elliptic.Curve) int {
+	return (c.Params().N.BitLen() + 7) / 8
+}
+
+// encodeRaw returns r and s as concatenated big-endian integers, each padded
+// with leading zeros to size bytes.
+func encodeRaw(r, s *big.Int, size int) ([]byte, error) {
+	out := make([]byte, 2*size)
+	if err := fillRaw(out, r, s); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// fillRaw writes r and s into the halves of dst.
+func fillRaw(dst []byte, r, s *big.Int) error {
+	size := len(dst) / 2
+	if r.Sign() < 0 || s.Sign() < 0 || (r.BitLen()+7)/8 > size || (s.BitLen()+7)/8 > size {
+		return ErrSignatureOverflow
+	}
+
+	r.FillBytes(dst[:size])
+	s.FillBytes(dst[size:])
+	return nil
+}
+
+// SignECDSAP1363 signs a hash like SignECDSA does and returns the signature in
+// the IEEE P1363 format used by the W3C WebCrypto API for ECDSA: r followed
+// by s, both as big-endian unsigned integers left-padded with zeros to the
+// byte length of the curve order, e.g. 64 bytes for P-256 and 132 bytes for
+// P-521. Padding is mandatory, WebCrypto rejects signatures of any other
+// length.
+func SignECDSAP1363(priv *ecdsa.PrivateKey, hash []byte, alg func() hash.Hash) ([]byte, error) {
+	r, s := SignECDSA(priv, hash, alg)
+	return EncodeP1363(priv.Curve, r, s)
+}
+
+// EncodeP1363 encodes the signature (r, s) made on the curve c in the IEEE
+// P1363 format described in SignECDSAP1363, e.g. 132 bytes with 66-byte
+// components for P-521. ErrSignatureOverflow is returned for negative
+// components or ones that don't fit into the byte length of the curve order.
+func EncodeP1363(c elliptic.Curve, r, s *big.Int) ([]byte, error) {
+	return encodeRaw(r, s, OrderSize(c))
+}
+
+// DecodeP1363 decodes an IEEE P1363 signature made on the curve c. Its length
+// must be exactly twice the byte length of the curve order, the range of the
+// components is left to the verifier. MarshalCompact and UnmarshalCompact
+// produce the same format, but validate the range as well.
+func DecodeP1363(c elliptic.Curve, sig []byte) (r, s *big.Int, err error) {
+	size := OrderSize(c)
+	if len(sig) != 2*size {
+		return nil, nil, ErrInvalidP1363
+	}
+	r = new(big.Int).SetBytes(sig[:size])
+	s = new(big.Int).SetBytes(sig[size:])
+	return r, s, nil
+}
+
+// SignASN1 signs a hash like SignECDSA does and returns the DER encoded
+// signature, like crypto/ecdsa.SignASN1, but deterministic.
+func SignASN1(priv *ecdsa.PrivateKey, hash []byte, alg func() hash.Hash) ([]byte, error) {
+	r, s := SignECDSA(priv, hash, alg)
+	return MarshalSignatureDER(r, s)
+}
+
+// derSignature is the ASN.1 structure of ECDSA and DSA signatures.
+type derSignature struct {
+	R, S *big.Int
+}
+
+// decodeDER parses a DER encoded signature, rejecting trailing data and
+// non-positive components.
+func decodeDER(sig []byte) (r, s *big.Int, err error) {
+	var v derSignature
+	rest, err := asn1.Unmarshal(sig, &v)
+	if err != nil || len(rest) != 0 || v.R.Sign() <= 0 || v.S.Sign() <= 0 {
+		return nil, nil, ErrInvalidDER
+	}
+	return v.R, v.S, nil
+}
+
+// ParseDERStrict parses a DER encoded signature, an ASN.1 SEQUENCE of two
+// INTEGERs, accepting canonical encodings only: the indefinite length, long
+// form lengths below 128 or with leading zero bytes, integers with redundant
+// leading bytes, negative or zero integers, extra elements and trailing data
+// are all rejected with ErrInvalidDER. Components aren't checked against the
+// group order, that is left to the verifier.
+func ParseDERStrict(sig []byte) (r, s *big.Int, err error) {
+	seq, rest, ok := readDER(sig, 0x30)
+	if !ok || len(rest) != 0 {
+		return nil, nil, ErrInvalidDER
+	}
+	if r, seq, ok = readDERInt(seq); !ok {
+		return nil, nil, ErrInvalidDER
+	}
+	if s, seq, ok = readDERInt(seq); !ok || len(seq) != 0 {
+		return nil, nil, ErrInvalidDER
+	}
+	return r, s, nil
+}
+
+// readDER splits off the contents of a DER element with the tag from the
+// beginning of b, checking that its length is minimally encoded.
+func readDER(b []byte, tag byte) (content, rest []byte, ok bool) {
+	if len(b) < 2 || b[0] != tag {
+		return nil, nil, false
+	}
+
+	n, b := int(b[1]), b[2:]
+	if n&0x80 != 0 {
+		l := n & 0x7f
+		// Lengths over 2^24 aren't meaningful for signatures.
+		if l == 0 || l > 3 || len(b) < l || b[0] == 0 {
+			return nil, nil, false
+		}
+		n = 0
+		for _, c := range b[:l] {
+			n = n<<8 | int(c)
+		}
+		if n < 0x80 {
+			return nil, nil, false
+		}
+		b = b[l:]
+	}
+	if len(b) < n {
+		return nil, nil, false
+	}
+	return b[:n], b[n:], true
+}
+
+// readDERInt reads a positive minimally encoded INTEGER from the beginning
+// of b.
+func readDERInt(b []byte) (v *big.Int, rest []byte, ok bool) {
+	content, rest, ok := readDER(b, 0x02)
+	if !ok || len(content) == 0 || content[0]&0x80 != 0 ||
+		(len(content) > 1 && content[0] == 0 && content[1]&0x80 == 0) {
+		return nil, nil, false
+	}
+
+	v = new(big.Int).SetBytes(content)
+	if v.Sign() == 0 {
+		return nil, nil, false
+	}
+	return v, rest, true
+}
+
+// MarshalSignatureDER returns r and s encoded as an ASN.1 SEQUENCE of two
+// INTEGERs in DER, the format used by X.509, TLS and crypto/ecdsa.SignASN1.
+func MarshalSignatureDER(r, s *big.Int) ([]byte, error) {
+	return AppendSignatureDER(nil, r, s)
+}
+
+// AppendSignatureDER appends the DER encoding of the signature (r, s) to dst
+// and returns the extended buffer, like MarshalSignatureDER does for an
+// empty one. dst is only reallocated if it lacks capacity. A nil or
+// non-positive r or s yields ErrInvalidDER.
+func AppendSignatureDER(dst []byte, r, s *big.Int) ([]byte, error) {
+	if r == nil || s == nil || r.Sign() <= 0 || s.Sign() <= 0 {
+		return dst, ErrInvalidDER
+	}
+	return appendSignatureDER(dst, r, s, 0), nil
+}
+
+// MarshalSignatureDERFixed is like MarshalSignatureDER, but left-pads both
+// INTEGERs with zeros to size bytes, plus the usual zero byte if the high bit
+// would otherwise be set, e.g. for P-256 r = 0x01 is encoded as 02 20 00…01
+// instead of 02 01 01. Lengths stay minimal. This is NOT canonical DER, since
+// DER requires minimal INTEGER encodings and strict parsers (including
+// encoding/asn1) reject it; it only exists for legacy systems expecting
+// fixed-width integers. Use MarshalSignatureDER unless such a system requires
+// this layout.
+func MarshalSignatureDERFixed(r, s *big.Int, size int) ([]byte, error) {
+	if r.Sign() <= 0 || s.Sign() <= 0 || (r.BitLen()+7)/8 > size || (s.BitLen()+7)/8 > size {
+		return nil, ErrSignatureOverflow
+	}
+	return appendSignatureDER(nil, r, s, size), nil
+}
+
+// appendSignatureDER appends the SEQUENCE of positive r and s, with INTEGERs
+// at least width bytes long.
+func appendSignatureDER(dst []byte, r, s *big.Int, width int) []byte {
+	body := derIntLen(r, width) + derIntLen(s, width)
+	dst = append(dst, 0x30)
+	dst = appendDERLength(dst, body)
+	dst = appendDERInt(dst, r, width)
+	dst = appendDERInt(dst, s, width)
+	return dst
+}
+
+// derIntContentLen returns the length of the INTEGER contents of a positive
+// v, which is minimal, but at least width bytes.
+func derIntContentLen(v *big.Int, width int) int {
+	n := v.BitLen()/8 + 1
+	if n < width {
+		n = width
+	}
+	return n
+}
+
+// derIntLen returns the length of the INTEGER encoding of a positive v,
+// including the tag and the length.
+func derIntLen(v *big.Int, width int) int {
+	n := derIntContentLen(v, width)
+	return 1 + derLengthLen(n) + n
+}
+
+// derLengthLen returns the length of the DER encoding of the length n.
+func derLengthLen(n int) int {
+	l := 1
+	if n >= 0x80 {
+		for ; n > 0; n >>= 8 {
+			l++
+		}
+	}
+	return l
+}
+
+// appendDERLength appends the DER encoding of the length n.
+func appendDERLength(dst []byte, n int) []byte {
+	if n < 0x80 {
+		return append(dst, byte(n))
+	}
+
+	l := derLengthLen(n) - 1
+	dst = append(dst, 0x80|byte(l))
+	for i := l - 1; i >= 0; i-- {
+		dst = append(dst, byte(n>>(8*uint(i))))
+	}
+	return dst
+}
+
+// appendDERInt appends the INTEGER encoding of a positive v, which has a
+// leading zero byte whenever the most significant bit is set and is padded
+// with zeros to width bytes.
+func appendDERInt(dst []byte, v *big.Int, width int) []byte {
+	n := derIntContentLen(v, width)
+	dst = append(dst, 0x02)
+	dst = appendDERLength(dst, n)
+
+	start := len(dst)
+	for i := 0; i < n; i++ {
+		dst = append(dst, 0)
+	}
+	v.FillBytes(dst[start:])
+	return dst
+}
+
+// MarshalSignatureBER returns r and s as an ASN.1 SEQUENCE of two INTEGERs in
+// a non-canonical BER form accepted by some legacy verifiers: every length
+// uses the long form and every integer is left-padded with zeros to size
+// bytes (plus a zero byte if its high bit would otherwise be set), so the
+// output length depends only on size. Both rules are forbidden by DER, so
+// strict parsers (including encoding/asn1) reject this encoding. Use
+// MarshalSignatureDER unless a verifier requires this layout.
+func MarshalSignatureBER(r, s *big.Int, size int) ([]byte, error) {
+	if r.Sign() <= 0 || s.Sign() <= 0 || (r.BitLen()+7)/8 > size || (s.BitLen()+7)/8 > size {
+		return nil, ErrSignatureOverflow
+	}
+
+	rb, sb := berInt(r, size), berInt(s, size)
+	body := berHeaderLen(len(rb)) + len(rb) + berHeaderLen(len(sb)) + len(sb)
+
+	out := appendBERHeader(nil, 0x30, body)
+	out = appendBERHeader(out, 0x02, len(rb))
+	out = append(out, rb...)
+	out = appendBERHeader(out, 0x02, len(sb))
+	out = append(out, sb...)
+	return out, nil
+}
+
+// berInt returns the contents of a padded INTEGER encoding of a positive v.
+func berInt(v *big.Int, size int) []byte {
+	b := make([]byte, size)
+	v.FillBytes(b)
+	if b[0]&0x80 != 0 {
+		b = append([]byte{0}, b...)
+	}
+	return b
+}
+
+// berHeaderLen returns the length of a tag followed by a long form length.
+func berHeaderLen(n int) int {
+	l := 3
+	for n >>= 8; n > 0; n >>= 8 {
+		l++
+	}
+	return l
+}
+
+// appendBERHeader appends the tag and the long form encoding of n.
+func appendBERHeader(dst []byte, tag byte, n int) []byte {
+	l := berHeaderLen(n) - 2
+	dst = append(dst, tag, 0x80|byte(l))
+	for i := l - 1; i >= 0; i-- {
+		dst = append(dst, byte(n>>(8*uint(i))))
+	}
+	return dst
+}
@@ -0,0 +1,395 @@
+package rfc6979_test
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/sha256"
+	"encoding/asn1"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/nspcc-dev/rfc6979"
+	"github.com/nspcc-dev/rfc6979/legacy"
+)
+
+func TestCurveSizes(t *testing.T) {
+	for _, v := range []struct {
+		c                 elliptic.Curve
+		coordinate, order int
+	}{
+		{elliptic.P224(), 28, 28},
+		{elliptic.P256(), 32, 32},
+		{elliptic.P384(), 48, 48},
+		// Neither 521-bit value is byte aligned.
+		{elliptic.P521(), 66, 66},
+		{rfc6979.Secp256k1(), 32, 32},
+		// secp160r1 has a 160-bit field, but a 161-bit order.
+		{legacy.Secp160r1(), 20, 21},
+	} {
+		name := v.c.Params().Name
+		if size := rfc6979.CoordinateSize(v.c); size != v.coordinate {
+			t.Errorf("%s: Expected coordinate size %d, got %d", name, v.coordinate, size)
+		}
+		if size := rfc6979.OrderSize(v.c); size != v.order {
+			t.Errorf("%s: Expected order size %d, got %d", name, v.order, size)
+		}
+	}
+}
+
+// webCryptoP1363 is a signature of SHA-256("sample") with the P-256 key of
+// RFC 6979 appendix A.2.5, made by crypto.subtle.sign in Node.js with
+// {name: "ECDSA", hash: "SHA-256"}. WebCrypto uses random nonces and the
+// IEEE P1363 format, r || s.
+const webCryptoP1363 = "BF1EE7941C5BC96A04F5A0A686F0080F877DD48883FDC36FE517A2097BC9C329" +
+	"10C9748B7616B34A0FA7F81F07B12376FFDEFED2AE73E89D4B28E9AC81316C25"
+
+func TestSignECDSAP1363(t *testing.T) {
+	for _, f := range fixtures {
+		h := f.alg()
+		h.Write([]byte(f.message))
+		digest := h.Sum(nil)
+
+		sig, err := rfc6979.SignECDSAP1363(f.key.key, digest, f.alg)
+		if err != nil {
+			t.Errorf("%s: %v", f.name, err)
+			continue
+		}
+
+		size := (f.key.key.Curve.Params().N.BitLen() + 7) / 8
+		if len(sig) != 2*size {
+			t.Errorf("%s: Expected %d bytes, got %d", f.name, 2*size, len(sig))
+			continue
+		}
+
+		expected := make([]byte, 2*size)
+		ecdsaLoadInt(f.r).FillBytes(expected[:size])
+		ecdsaLoadInt(f.s).FillBytes(expected[size:])
+		if !bytes.Equal(sig, expected) {
+			t.Errorf("%s: Expected %X, got %X", f.name, expected, sig)
+		}
+
+		r := new(big.Int).SetBytes(sig[:size])
+		s := new(big.Int).SetBytes(sig[size:])
+		if !ecdsa.Verify(&f.key.key.PublicKey, digest, r, s) {
+			t.Errorf("%s: Invalid signature", f.name)
+		}
+	}
+
+	sig, _ := hex.DecodeString(webCryptoP1363)
+	r, s, err := rfc6979.DecodeP1363(elliptic.P256(), sig)
+	if err != nil {
+		t.Fatalf("WebCrypto: %v", err)
+	}
+	digest := sha256.Sum256([]byte("sample"))
+	if !ecdsa.Verify(&p256.key.PublicKey, digest[:], r, s) {
+		t.Error("WebCrypto: Invalid signature")
+	}
+}
+
+func TestMarshalSignatureDER(t *testing.T) {
+	for _, f := range fixtures {
+		r, s := ecdsaLoadInt(f.r), ecdsaLoadInt(f.s)
+
+		expected, err := asn1.Marshal(struct{ R, S *big.Int }{r, s})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		der, err := rfc6979.MarshalSignatureDER(r, s)
+		if err != nil {
+			t.Errorf("%s: %v", f.name, err)
+			continue
+		}
+		if !bytes.Equal(der, expected) {
+			t.Errorf("%s: Expected %X, got %X", f.name, expected, der)
+		}
+
+		prefix := []byte("prefix")
+		buf := make([]byte, len(prefix), len(prefix)+len(expected))
+		copy(buf, prefix)
+		appended, err := rfc6979.AppendSignatureDER(buf, r, s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(appended, append(prefix, expected...)) {
+			t.Errorf("%s: Expected %X to be appended, got %X", f.name, expected, appended)
+		}
+		if &appended[0] != &buf[0] {
+			t.Errorf("%s: Expected buffer with enough capacity to be reused", f.name)
+		}
+
+		// A buffer that is too small grows.
+		grown, err := rfc6979.AppendSignatureDER(prefix[:len(prefix):len(prefix)], r, s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(grown, append(prefix, expected...)) {
+			t.Errorf("%s: Expected %X to be appended, got %X", f.name, expected, grown)
+		}
+	}
+
+	if _, err := rfc6979.MarshalSignatureDER(big.NewInt(0), big.NewInt(1)); err != rfc6979.ErrInvalidDER {
+		t.Errorf("Expected %v, got %v", rfc6979.ErrInvalidDER, err)
+	}
+	if _, err := rfc6979.AppendSignatureDER(nil, nil, big.NewInt(1)); err != rfc6979.ErrInvalidDER {
+		t.Errorf("Nil r: expected %v, got %v", rfc6979.ErrInvalidDER, err)
+	}
+	if _, err := rfc6979.AppendSignatureDER(nil, big.NewInt(1), nil); err != rfc6979.ErrInvalidDER {
+		t.Errorf("Nil s: expected %v, got %v", rfc6979.ErrInvalidDER, err)
+	}
+}
+
+// berParse decodes the output of MarshalSignatureBER, which is neither DER
+// nor accepted by encoding/asn1.
+func berParse(t *testing.T, b []byte) (r, s *big.Int) {
+	read := func(tag byte) []byte {
+		if len(b) < 2 || b[0] != tag || b[1]&0x80 == 0 {
+			t.Fatalf("Expected tag %X with long form length, got %X", tag, b)
+		}
+		l := int(b[1] & 0x7f)
+		n := 0
+		for _, c := range b[2 : 2+l] {
+			n = n<<8 | int(c)
+		}
+		content := b[2+l : 2+l+n]
+		b = b[2+l+n:]
+		return content
+	}
+
+	b = read(0x30)
+	r = new(big.Int).SetBytes(read(0x02))
+	s = new(big.Int).SetBytes(read(0x02))
+	if len(b) != 0 {
+		t.Fatalf("Unexpected trailing data %X", b)
+	}
+	return
+}
+
+func TestMarshalSignatureBER(t *testing.T) {
+	for _, f := range fixtures {
+		r, s := ecdsaLoadInt(f.r), ecdsaLoadInt(f.s)
+		size := (f.key.key.Curve.Params().N.BitLen() + 7) / 8
+
+		ber, err := rfc6979.MarshalSignatureBER(r, s, size)
+		if err != nil {
+			t.Fatalf("%s: %v", f.name, err)
+		}
+
+		br, bs := berParse(t, ber)
+		if br.Cmp(r) != 0 || bs.Cmp(s) != 0 {
+			t.Errorf("%s: Expected BER to decode to the same signature", f.name)
+		}
+
+		// BER differs from DER, which strict parsers insist on.
+		der, _ := rfc6979.MarshalSignatureDER(r, s)
+		if bytes.Equal(ber, der) {
+			t.Errorf("%s: Expected BER to differ from DER", f.name)
+		}
+		var v struct{ R, S *big.Int }
+		if _, err := asn1.Unmarshal(ber, &v); err == nil {
+			t.Errorf("%s: Expected encoding/asn1 to reject BER", f.name)
+		}
+	}
+}
+
+// laxParse parses a SEQUENCE of two INTEGERs without checking that they are
+// minimally encoded, which encoding/asn1 does.
+func laxParse(t *testing.T, b []byte) (r, s *big.Int) {
+	read := func(tag byte) []byte {
+		if len(b) < 2 || b[0] != tag {
+			t.Fatalf("Expected tag %X, got %X", tag, b)
+		}
+		n, l := int(b[1]), 0
+		if n&0x80 != 0 {
+			l = n & 0x7f
+			n = 0
+			for _, c := range b[2 : 2+l] {
+				n = n<<8 | int(c)
+			}
+		}
+		content := b[2+l : 2+l+n]
+		b = b[2+l+n:]
+		return content
+	}
+
+	b = read(0x30)
+	r = new(big.Int).SetBytes(read(0x02))
+	s = new(big.Int).SetBytes(read(0x02))
+	if len(b) != 0 {
+		t.Fatalf("Unexpected trailing data %X", b)
+	}
+	return
+}
+
+func TestMarshalSignatureDERFixed(t *testing.T) {
+	for _, f := range fixtures {
+		r, s := ecdsaLoadInt(f.r), ecdsaLoadInt(f.s)
+		size := rfc6979.OrderSize(f.key.key.Curve)
+
+		fixed, err := rfc6979.MarshalSignatureDERFixed(r, s, size)
+		if err != nil {
+			t.Fatalf("%s: %v", f.name, err)
+		}
+		der, _ := rfc6979.MarshalSignatureDER(r, s)
+
+		fr, fs := laxParse(t, fixed)
+		dr, ds := laxParse(t, der)
+		if fr.Cmp(r) != 0 || fs.Cmp(s) != 0 || dr.Cmp(r) != 0 || ds.Cmp(s) != 0 {
+			t.Errorf("%s: Expected both modes to decode to the same signature", f.name)
+		}
+
+		// Components shorter than size differ from DER and get rejected
+		// by strict parsers, the others are encoded identically.
+		short := r.BitLen() <= 8*size-8 || s.BitLen() <= 8*size-8
+		if short == bytes.Equal(fixed, der) {
+			t.Errorf("%s: Expected fixed-width encoding to differ from DER iff a component is short", f.name)
+		}
+	}
+
+	// 30 08 02 02 00 01 02 02 00 80, where DER has 30 07 02 01 01 02 02 00 80.
+	small, err := rfc6979.MarshalSignatureDERFixed(big.NewInt(1), big.NewInt(0x80), 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []byte{0x30, 0x08, 0x02, 0x02, 0x00, 0x01, 0x02, 0x02, 0x00, 0x80}
+	if !bytes.Equal(small, expected) {
+		t.Errorf("Expected %X, got %X", expected, small)
+	}
+	var v struct{ R, S *big.Int }
+	if _, err := asn1.Unmarshal(small, &v); err == nil {
+		t.Errorf("Expected encoding/asn1 to reject padded integers")
+	}
+
+	if _, err := rfc6979.MarshalSignatureDERFixed(big.NewInt(0x100), big.NewInt(1), 1); err != rfc6979.ErrSignatureOverflow {
+		t.Errorf("Expected %v, got %v", rfc6979.ErrSignatureOverflow, err)
+	}
+}
+
+func TestSignASN1(t *testing.T) {
+	for _, f := range fixtures {
+		h := f.alg()
+		h.Write([]byte(f.message))
+		digest := h.Sum(nil)
+
+		sig, err := rfc6979.SignASN1(f.key.key, digest, f.alg)
+		if err != nil {
+			t.Fatalf("%s: %v", f.name, err)
+		}
+
+		expected, _ := rfc6979.MarshalSignatureDER(ecdsaLoadInt(f.r), ecdsaLoadInt(f.s))
+		if !bytes.Equal(sig, expected) {
+			t.Errorf("%s: Expected %X, got %X", f.name, expected, sig)
+		}
+
+		pub := &f.key.key.PublicKey
+		if !rfc6979.VerifyASN1(pub, digest, sig) || !ecdsa.VerifyASN1(pub, digest, sig) {
+			t.Errorf("%s: Invalid signature", f.name)
+		}
+		if rfc6979.VerifyASN1(pub, digest, append(sig, 0)) {
+			t.Errorf("%s: Expected trailing data to be rejected", f.name)
+		}
+		digest[0] ^= 0x80
+		if rfc6979.VerifyASN1(pub, digest, sig) {
+			t.Errorf("%s: Expected signature of another hash to be rejected", f.name)
+		}
+	}
+}
+
+func TestP1363(t *testing.T) {
+	for _, f := range fixtures {
+		c := f.key.key.Curve
+		size := rfc6979.OrderSize(c)
+		r, s := ecdsaLoadInt(f.r), ecdsaLoadInt(f.s)
+
+		sig, err := rfc6979.EncodeP1363(c, r, s)
+		if err != nil || len(sig) != 2*size {
+			t.Errorf("%s: Unexpected %d bytes, %v", f.name, len(sig), err)
+			continue
+		}
+
+		r2, s2, err := rfc6979.DecodeP1363(c, sig)
+		if err != nil || r2.Cmp(r) != 0 || s2.Cmp(s) != 0 {
+			t.Errorf("%s: Expected round trip, got %v", f.name, err)
+		}
+
+		for _, bad := range [][]byte{nil, sig[1:], append(sig, 0)} {
+			if _, _, err := rfc6979.DecodeP1363(c, bad); err != rfc6979.ErrInvalidP1363 {
+				t.Errorf("%s: Expected %v for %d bytes, got %v", f.name, rfc6979.ErrInvalidP1363, len(bad), err)
+			}
+		}
+	}
+
+	// Small components of P-521 are padded to 66 bytes each.
+	sig, err := rfc6979.EncodeP1363(elliptic.P521(), big.NewInt(1), big.NewInt(0x0102))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := make([]byte, 132)
+	expected[65], expected[130], expected[131] = 1, 1, 2
+	if !bytes.Equal(sig, expected) {
+		t.Errorf("Expected %X, got %X", expected, sig)
+	}
+
+	huge := new(big.Int).Lsh(big.NewInt(1), 528)
+	if _, err := rfc6979.EncodeP1363(elliptic.P521(), huge, huge); err != rfc6979.ErrSignatureOverflow {
+		t.Errorf("Expected %v, got %v", rfc6979.ErrSignatureOverflow, err)
+	}
+}
+
+func TestParseDERStrict(t *testing.T) {
+	for _, f := range fixtures {
+		r, s := ecdsaLoadInt(f.r), ecdsaLoadInt(f.s)
+		der, _ := rfc6979.MarshalSignatureDER(r, s)
+
+		pr, ps, err := rfc6979.ParseDERStrict(der)
+		if err != nil || pr.Cmp(r) != 0 || ps.Cmp(s) != 0 {
+			t.Errorf("%s: Expected round trip, got %v", f.name, err)
+		}
+
+		if _, _, err := rfc6979.ParseDERStrict(append(der, 0)); err != rfc6979.ErrInvalidDER {
+			t.Errorf("%s: Expected trailing data to be rejected, got %v", f.name, err)
+		}
+		ber, _ := rfc6979.MarshalSignatureBER(r, s, rfc6979.OrderSize(f.key.key.Curve))
+		if _, _, err := rfc6979.ParseDERStrict(ber); err != rfc6979.ErrInvalidDER {
+			t.Errorf("%s: Expected BER to be rejected, got %v", f.name, err)
+		}
+	}
+
+	// 30 06 02 01 01 02 01 07 encodes (1, 7).
+	for _, v := range []struct {
+		name, der string
+	}{
+		{"empty", ""},
+		{"wrong tag", "3106020101020107"},
+		{"indefinite length", "3080020101020107"},
+		{"long form length", "308106020101020107"},
+		{"padded length", "30820006020101020107"},
+		{"truncated", "3007020101020107"},
+		{"trailing data", "30060201010201070000"},
+		{"inner trailing data", "3009020101020107020101"},
+		{"single integer", "3003020101"},
+		{"empty integer", "30050200020107"},
+		{"negative integer", "3006020181020107"},
+		{"zero integer", "3006020100020107"},
+		{"padded integer", "300702020001020107"},
+		{"long form integer length", "300702810101020107"},
+		{"octet string", "3006040101020107"},
+	} {
+		b, err := hex.DecodeString(v.der)
+		if err != nil {
+			t.Fatalf("%s: %v", v.name, err)
+		}
+		if _, _, err := rfc6979.ParseDERStrict(b); err != rfc6979.ErrInvalidDER {
+			t.Errorf("%s: Expected %v, got %v", v.name, rfc6979.ErrInvalidDER, err)
+		}
+	}
+
+	// Leading zeros are needed for the high bit: (128, 255).
+	b, _ := hex.DecodeString("300802020080020200ff")
+	r, s, err := rfc6979.ParseDERStrict(b)
+	if err != nil || r.Int64() != 128 || s.Int64() != 255 {
+		t.Errorf("Expected (128, 255), got (%v, %v), %v", r, s, err)
+	}
+}
@@ -0,0 +1,93 @@
+package rfc6979
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"errors"
+	"math/big"
+)
+
+// ErrInvalidEnvelope is returned when a signature envelope can't be decoded
+// or doesn't match the verification key.
+var ErrInvalidEnvelope = errors.New("rfc6979: invalid signature envelope")
+
+// envelopeCurves lists the curves that can be described by an envelope, the
+// index is the curve identifier.
+var envelopeCurves = []func() elliptic.Curve{
+	1:  elliptic.P224,
+	2:  elliptic.P256,
+	3:  elliptic.P384,
+	4:  elliptic.P521,
+	5:  Secp256k1,
+	6:  BrainpoolP256r1,
+	7:  BrainpoolP384r1,
+	8:  BrainpoolP512r1,
+	9:  BrainpoolP256t1,
+	10: BrainpoolP384t1,
+	11: BrainpoolP512t1,
+	12: FRP256v1,
+}
+
+// envelopeCurveID returns the envelope identifier of c or 0 if there's none.
+func envelopeCurveID(c elliptic.Curve) byte {
+	for id, newCurve := range envelopeCurves {
+		if newCurve != nil && sameCurve(newCurve(), c) {
+			return byte(id)
+		}
+	}
+	return 0
+}
+
+// sameCurve reports whether a and b have the same domain parameters. Names
+// aren't compared, since any implementation can claim any name.
+func sameCurve(a, b elliptic.Curve) bool {
+	p, q := a.Params(), b.Params()
+	return p.P.Cmp(q.P) == 0 && p.N.Cmp(q.N) == 0 && p.B.Cmp(q.B) == 0 &&
+		p.Gx.Cmp(q.Gx) == 0 && p.Gy.Cmp(q.Gy) == 0
+}
+
+// SignEnvelope hashes the message with h, signs it using the private key,
+// priv, as SignECDSA does and returns a self-describing envelope: one byte
+// identifying the curve (1 for P-224, 2 for P-256, 3 for P-384, 4 for P-521,
+// 5 for secp256k1, 6, 7 and 8 for brainpoolP256r1, P384r1 and P512r1, 9, 10
+// and 11 for brainpoolP256t1, P384t1 and P512t1, 12 for FRP256v1), one byte
+// holding the crypto.Hash value of h and the signature as r || s padded to
+// the byte length of the curve order.
+func SignEnvelope(priv *ecdsa.PrivateKey, message []byte, h crypto.Hash) ([]byte, error) {
+	id := envelopeCurveID(priv.Curve)
+	if id == 0 || !h.Available() || h > 0xff {
+		return nil, ErrInvalidEnvelope
+	}
+
+	r, s := SignECDSAMessage(priv, message, h.New)
+	raw, err := encodeRaw(r, s, OrderSize(priv.Curve))
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte{id, byte(h)}, raw...), nil
+}
+
+// VerifyEnvelope verifies an envelope produced by SignEnvelope over the
+// message with the public key, pub. The hash function is taken from the
+// envelope, the curve it names must be the one of pub.
+func VerifyEnvelope(pub *ecdsa.PublicKey, message []byte, envelope []byte) error {
+	size := OrderSize(pub.Curve)
+	if len(envelope) != 2+2*size || envelope[0] == 0 || envelope[0] != envelopeCurveID(pub.Curve) {
+		return ErrInvalidEnvelope
+	}
+
+	h := crypto.Hash(envelope[1])
+	if !h.Available() {
+		return ErrInvalidEnvelope
+	}
+
+	w := h.New()
+	w.Write(message)
+	r := new(big.Int).SetBytes(envelope[2 : 2+size])
+	s := new(big.Int).SetBytes(envelope[2+size:])
+	if !VerifyCurve(pub.Curve, pub.X, pub.Y, w.Sum(nil), r, s) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
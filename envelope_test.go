@@ -0,0 +1,84 @@
+package rfc6979_test
+
+import (
+	"crypto"
+	"crypto/elliptic"
+	_ "crypto/sha256"
+	_ "crypto/sha512"
+	"testing"
+
+	"github.com/nspcc-dev/rfc6979"
+)
+
+// renamedCurve is a curve reporting another name in its parameters.
+type renamedCurve struct {
+	elliptic.Curve
+	name string
+}
+
+func (c renamedCurve) Params() *elliptic.CurveParams {
+	params := *c.Curve.Params()
+	params.Name = c.name
+	return &params
+}
+
+func TestEnvelope(t *testing.T) {
+	message := []byte("sample")
+
+	for _, key := range []*ecdsaKey{p224, p256, p384, p521, {key: secp256k1Key("1")}, {key: curveKey(rfc6979.BrainpoolP512r1(), "1")}, frp256v1} {
+		name := key.key.Curve.Params().Name
+
+		env, err := rfc6979.SignEnvelope(key.key, message, crypto.SHA384)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+
+		if err := rfc6979.VerifyEnvelope(&key.key.PublicKey, message, env); err != nil {
+			t.Errorf("%s: %v", name, err)
+		}
+
+		if err := rfc6979.VerifyEnvelope(&key.key.PublicKey, []byte("test"), env); err != rfc6979.ErrInvalidSignature {
+			t.Errorf("%s: Expected %v, got %v", name, rfc6979.ErrInvalidSignature, err)
+		}
+
+		tampered := append([]byte(nil), env...)
+		tampered[1] = byte(crypto.SHA256)
+		if err := rfc6979.VerifyEnvelope(&key.key.PublicKey, message, tampered); err != rfc6979.ErrInvalidSignature {
+			t.Errorf("%s: Expected tampered hash to be rejected, got %v", name, err)
+		}
+
+		tampered[1] = 0xff
+		if err := rfc6979.VerifyEnvelope(&key.key.PublicKey, message, tampered); err != rfc6979.ErrInvalidEnvelope {
+			t.Errorf("%s: Expected %v, got %v", name, rfc6979.ErrInvalidEnvelope, err)
+		}
+	}
+
+	env, _ := rfc6979.SignEnvelope(p256.key, message, crypto.SHA256)
+	if err := rfc6979.VerifyEnvelope(&p384.key.PublicKey, message, env); err != rfc6979.ErrInvalidEnvelope {
+		t.Errorf("Expected curve mismatch to be rejected, got %v", err)
+	}
+	// secp256k1 and P-256 have orders of the same length.
+	if err := rfc6979.VerifyEnvelope(&secp256k1Key("1").PublicKey, message, env); err != rfc6979.ErrInvalidEnvelope {
+		t.Errorf("Expected curve mismatch to be rejected for secp256k1, got %v", err)
+	}
+	if err := rfc6979.VerifyEnvelope(&frp256v1.key.PublicKey, message, env); err != rfc6979.ErrInvalidEnvelope {
+		t.Errorf("Expected curve mismatch to be rejected for FRP256v1, got %v", err)
+	}
+
+	// The curve is identified by its parameters, not by its name.
+	renamed := *p256.key
+	renamed.Curve = renamedCurve{elliptic.P256(), "P-384"}
+	env, err := rfc6979.SignEnvelope(&renamed, message, crypto.SHA256)
+	if err != nil || env[0] != 2 {
+		t.Fatalf("Expected renamed P-256 to be identified as P-256, got %X, %v", env, err)
+	}
+	if err := rfc6979.VerifyEnvelope(&p256.key.PublicKey, message, env); err != nil {
+		t.Errorf("Renamed P-256: %v", err)
+	}
+	impostor := secp256k1Key("1")
+	impostor.Curve = renamedCurve{rfc6979.Secp256k1(), "P-256"}
+	env, _ = rfc6979.SignEnvelope(impostor, message, crypto.SHA256)
+	if err := rfc6979.VerifyEnvelope(&p256.key.PublicKey, message, env); err != rfc6979.ErrInvalidEnvelope {
+		t.Errorf("Expected secp256k1 named P-256 to be rejected, got %v", err)
+	}
+}
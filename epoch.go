@@ -0,0 +1,50 @@
+package rfc6979
+
+import (
+	"crypto/ecdsa"
+	"encoding/binary"
+	"hash"
+	"math/big"
+)
+
+// epochLabel separates epoch key derivation from nonce generation.
+const epochLabel = "rfc6979 epoch key"
+
+// DeriveEpochKey deterministically derives the signing key of the epoch from
+// the master key. The epoch private scalar is the first value within
+// [1, N-1] produced by the RFC 6979 generator, see GenerateKFromSeed, with
+// alg and the seed
+//
+//	int2octets(master.D) || "rfc6979 epoch key" || uint64be(epoch)
+//
+// where int2octets pads to the byte length of the group order N. Anyone
+// holding the master key can thus reproduce the key of any epoch, while the
+// keys of different epochs look unrelated to everyone else.
+func DeriveEpochKey(master *ecdsa.PrivateKey, epoch uint64, alg func() hash.Hash) *ecdsa.PrivateKey {
+	c := master.Curve
+	N := c.Params().N
+	rolen := (N.BitLen() + 7) >> 3
+
+	seed := appendInt2Octets(make([]byte, 0, rolen+len(epochLabel)+8), master.D, rolen)
+	seed = append(seed, epochLabel...)
+	var be [8]byte
+	binary.BigEndian.PutUint64(be[:], epoch)
+	seed = append(seed, be[:]...)
+
+	d := GenerateKFromSeed(N, alg, seed)
+	x, y := c.ScalarBaseMult(scalarBytes(c, d))
+	return &ecdsa.PrivateKey{
+		PublicKey: ecdsa.PublicKey{Curve: c, X: x, Y: y},
+		D:         d,
+	}
+}
+
+// SignECDSAEpoch signs the hash like SignECDSA with the key derived by
+// DeriveEpochKey from master for the epoch, alg being used for both. It
+// returns the signature as a pair of integers and the public key of the
+// epoch verifying it.
+func SignECDSAEpoch(master *ecdsa.PrivateKey, epoch uint64, hash []byte, alg func() hash.Hash) (r, s *big.Int, pub *ecdsa.PublicKey) {
+	key := DeriveEpochKey(master, epoch, alg)
+	r, s = SignECDSA(key, hash, alg)
+	return r, s, &key.PublicKey
+}
@@ -0,0 +1,78 @@
+package rfc6979_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+	"math/big"
+	"testing"
+
+	"github.com/nspcc-dev/rfc6979"
+)
+
+func TestSignECDSAEpoch(t *testing.T) {
+	key := p256.key
+	digest := sha256.Sum256([]byte("sample"))
+
+	r1, s1, pub1 := rfc6979.SignECDSAEpoch(key, 1, digest[:], sha256.New)
+	r2, s2, pub2 := rfc6979.SignECDSAEpoch(key, 1, digest[:], sha256.New)
+	r3, _, pub3 := rfc6979.SignECDSAEpoch(key, 2, digest[:], sha256.New)
+
+	if r1.Cmp(r2) != 0 || s1.Cmp(s2) != 0 || pub1.X.Cmp(pub2.X) != 0 || pub1.Y.Cmp(pub2.Y) != 0 {
+		t.Errorf("Expected the same epoch to give the same key and signature")
+	}
+	if r1.Cmp(r3) == 0 || pub1.X.Cmp(pub3.X) == 0 {
+		t.Errorf("Expected different epochs to give different keys and signatures")
+	}
+	if pub1.X.Cmp(key.X) == 0 {
+		t.Errorf("Expected epoch key to differ from the master key")
+	}
+
+	if !ecdsa.Verify(pub1, digest[:], r1, s1) {
+		t.Errorf("Invalid epoch signature")
+	}
+
+	epochKey := rfc6979.DeriveEpochKey(key, 1, sha256.New)
+	if !epochKey.PublicKey.Equal(pub1) {
+		t.Errorf("Expected signing key to be the derived one")
+	}
+	if x, y := key.Curve.ScalarBaseMult(epochKey.D.Bytes()); x.Cmp(epochKey.X) != 0 || y.Cmp(epochKey.Y) != 0 {
+		t.Errorf("Expected derived public key to match the private one")
+	}
+}
+
+// Reproduces the documented derivation with the generator steps spelled out.
+func TestDeriveEpochKeyDocumented(t *testing.T) {
+	key := p256.key
+	const epoch = 42
+
+	seed := key.D.FillBytes(make([]byte, 32))
+	seed = append(seed, "rfc6979 epoch key"...)
+	seed = binary.BigEndian.AppendUint64(seed, epoch)
+
+	// RFC 6979 section 3.2 steps B to H, the first candidate is accepted
+	// with overwhelming probability for P-256.
+	v := make([]byte, 32)
+	for i := range v {
+		v[i] = 1
+	}
+	k := make([]byte, 32)
+	hm := func(key []byte, parts ...[]byte) []byte {
+		h := hmac.New(sha256.New, key)
+		for _, p := range parts {
+			h.Write(p)
+		}
+		return h.Sum(nil)
+	}
+	k = hm(k, v, []byte{0}, seed)
+	v = hm(k, v)
+	k = hm(k, v, []byte{1}, seed)
+	v = hm(k, v)
+	v = hm(k, v)
+
+	d := rfc6979.DeriveEpochKey(key, epoch, sha256.New).D
+	if expected := new(big.Int).SetBytes(v); d.Cmp(expected) != 0 {
+		t.Errorf("Expected %X, got %X", expected, d)
+	}
+}
@@ -0,0 +1,322 @@
+/*
+Package eip712 hashes and signs EIP-712 typed structured data, the payload of
+eth_signTypedData_v4, with RFC 6979 deterministic ECDSA over secp256k1, so the
+same typed message always yields the same signature.
+*/
+package eip712
+
+import (
+	"crypto/ecdsa"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/nspcc-dev/rfc6979/ethsign"
+)
+
+// ErrInvalidTypedData is returned for typed data that doesn't match its
+// types.
+var ErrInvalidTypedData = errors.New("eip712: invalid typed data")
+
+// domainType is the name of the domain separator type.
+const domainType = "EIP712Domain"
+
+// Field is a member of a struct type.
+type Field struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// TypedData is the JSON object signed by eth_signTypedData_v4. Struct values
+// are maps, arrays are slices, integers may be numbers (json.Number keeps
+// them exact), decimal or 0x-prefixed hex strings or *big.Int, and addresses
+// and bytes are 0x-prefixed hex strings.
+type TypedData struct {
+	Types       map[string][]Field     `json:"types"`
+	PrimaryType string                 `json:"primaryType"`
+	Domain      map[string]interface{} `json:"domain"`
+	Message     map[string]interface{} `json:"message"`
+}
+
+// Hash returns the digest to be signed: Keccak-256 of
+// "\x19\x01" || domainSeparator || hashStruct(message).
+func (td *TypedData) Hash() ([]byte, error) {
+	domain, err := td.HashStruct(domainType, td.Domain)
+	if err != nil {
+		return nil, err
+	}
+	message, err := td.HashStruct(td.PrimaryType, td.Message)
+	if err != nil {
+		return nil, err
+	}
+	return ethsign.Keccak256([]byte{0x19, 0x01}, domain, message), nil
+}
+
+// Sign signs the typed data with the secp256k1 private key, priv, like
+// eth_signTypedData_v4 does, returning r || s || v with v being 27 or 28.
+func Sign(priv *ecdsa.PrivateKey, td *TypedData) ([]byte, error) {
+	digest, err := td.Hash()
+	if err != nil {
+		return nil, err
+	}
+	sig, err := ethsign.SignHash(priv, digest)
+	if err != nil {
+		return nil, err
+	}
+	sig[64] += 27
+	return sig, nil
+}
+
+// EncodeType returns the type encoding of the struct type, e.g.
+// "Mail(Person from,Person to,string contents)Person(string name,address wallet)":
+// the type itself followed by the struct types it references, directly or
+// not, sorted by name.
+func (td *TypedData) EncodeType(typ string) (string, error) {
+	deps := make(map[string]bool)
+	if err := td.dependencies(typ, deps); err != nil {
+		return "", err
+	}
+	delete(deps, typ)
+	names := make([]string, 0, len(deps))
+	for name := range deps {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	for _, name := range append([]string{typ}, names...) {
+		b.WriteString(name)
+		b.WriteByte('(')
+		for i, f := range td.Types[name] {
+			if i > 0 {
+				b.WriteByte(',')
+			}
+			b.WriteString(f.Type)
+			b.WriteByte(' ')
+			b.WriteString(f.Name)
+		}
+		b.WriteByte(')')
+	}
+	return b.String(), nil
+}
+
+// dependencies adds typ and the struct types it references to deps.
+func (td *TypedData) dependencies(typ string, deps map[string]bool) error {
+	if deps[typ] {
+		return nil
+	}
+	fields, ok := td.Types[typ]
+	if !ok {
+		return ErrInvalidTypedData
+	}
+	deps[typ] = true
+	for _, f := range fields {
+		base := baseType(f.Type)
+		if _, ok := td.Types[base]; ok {
+			if err := td.dependencies(base, deps); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// TypeHash returns Keccak-256 of the type encoding.
+func (td *TypedData) TypeHash(typ string) ([]byte, error) {
+	enc, err := td.EncodeType(typ)
+	if err != nil {
+		return nil, err
+	}
+	return ethsign.Keccak256([]byte(enc)), nil
+}
+
+// HashStruct returns hashStruct(data) for the struct type: Keccak-256 of the
+// type hash followed by the 32-byte encodings of the members.
+func (td *TypedData) HashStruct(typ string, data map[string]interface{}) ([]byte, error) {
+	typeHash, err := td.TypeHash(typ)
+	if err != nil {
+		return nil, err
+	}
+
+	enc := typeHash
+	for _, f := range td.Types[typ] {
+		v, ok := data[f.Name]
+		if !ok {
+			return nil, ErrInvalidTypedData
+		}
+		word, err := td.encodeValue(f.Type, v)
+		if err != nil {
+			return nil, err
+		}
+		enc = append(enc, word...)
+	}
+	return ethsign.Keccak256(enc), nil
+}
+
+// encodeValue returns the 32-byte encoding of v of the type.
+func (td *TypedData) encodeValue(typ string, v interface{}) ([]byte, error) {
+	if i := strings.LastIndexByte(typ, '['); i > 0 && strings.HasSuffix(typ, "]") {
+		items, ok := v.([]interface{})
+		if !ok {
+			return nil, ErrInvalidTypedData
+		}
+		if n := typ[i+1 : len(typ)-1]; n != "" && n != strconv.Itoa(len(items)) {
+			return nil, ErrInvalidTypedData
+		}
+		var enc []byte
+		for _, item := range items {
+			word, err := td.encodeValue(typ[:i], item)
+			if err != nil {
+				return nil, err
+			}
+			enc = append(enc, word...)
+		}
+		return ethsign.Keccak256(enc), nil
+	}
+
+	if _, ok := td.Types[typ]; ok {
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			return nil, ErrInvalidTypedData
+		}
+		return td.HashStruct(typ, m)
+	}
+
+	switch {
+	case typ == "string":
+		s, ok := v.(string)
+		if !ok {
+			return nil, ErrInvalidTypedData
+		}
+		return ethsign.Keccak256([]byte(s)), nil
+	case typ == "bytes":
+		b, err := hexBytes(v)
+		if err != nil {
+			return nil, err
+		}
+		return ethsign.Keccak256(b), nil
+	case typ == "bool":
+		b, ok := v.(bool)
+		if !ok {
+			return nil, ErrInvalidTypedData
+		}
+		word := make([]byte, 32)
+		if b {
+			word[31] = 1
+		}
+		return word, nil
+	case typ == "address":
+		b, err := hexBytes(v)
+		if err != nil || len(b) != 20 {
+			return nil, ErrInvalidTypedData
+		}
+		return append(make([]byte, 12), b...), nil
+	case strings.HasPrefix(typ, "bytes"):
+		n, err := strconv.Atoi(typ[len("bytes"):])
+		if err != nil || n < 1 || n > 32 {
+			return nil, ErrInvalidTypedData
+		}
+		b, err := hexBytes(v)
+		if err != nil || len(b) != n {
+			return nil, ErrInvalidTypedData
+		}
+		return append(b, make([]byte, 32-n)...), nil
+	case strings.HasPrefix(typ, "uint"):
+		return encodeInt(typ[len("uint"):], v, false)
+	case strings.HasPrefix(typ, "int"):
+		return encodeInt(typ[len("int"):], v, true)
+	}
+	return nil, ErrInvalidTypedData
+}
+
+// baseType strips array suffixes from the type.
+func baseType(typ string) string {
+	if i := strings.IndexByte(typ, '['); i >= 0 {
+		return typ[:i]
+	}
+	return typ
+}
+
+// hexBytes decodes a 0x-prefixed hex string.
+func hexBytes(v interface{}) ([]byte, error) {
+	s, ok := v.(string)
+	if !ok || !strings.HasPrefix(s, "0x") {
+		return nil, ErrInvalidTypedData
+	}
+	b, err := hex.DecodeString(s[2:])
+	if err != nil {
+		return nil, ErrInvalidTypedData
+	}
+	return b, nil
+}
+
+// encodeInt returns the 32-byte two's complement encoding of an integer of
+// the size in bits, 256 if it's empty.
+func encodeInt(size string, v interface{}, signed bool) ([]byte, error) {
+	bits := 256
+	if size != "" {
+		var err error
+		if bits, err = strconv.Atoi(size); err != nil || bits < 8 || bits > 256 || bits%8 != 0 {
+			return nil, ErrInvalidTypedData
+		}
+	}
+
+	n, err := toInt(v)
+	if err != nil {
+		return nil, err
+	}
+	lo, hi := new(big.Int), new(big.Int).Lsh(big.NewInt(1), uint(bits))
+	if signed {
+		hi.Rsh(hi, 1)
+		lo.Neg(hi)
+	}
+	if n.Cmp(lo) < 0 || n.Cmp(hi) >= 0 {
+		return nil, ErrInvalidTypedData
+	}
+
+	if n.Sign() < 0 {
+		n.Add(n, new(big.Int).Lsh(big.NewInt(1), 256))
+	}
+	return n.FillBytes(make([]byte, 32)), nil
+}
+
+// toInt converts the supported integer representations to *big.Int.
+func toInt(v interface{}) (*big.Int, error) {
+	switch v := v.(type) {
+	case *big.Int:
+		return new(big.Int).Set(v), nil
+	case int:
+		return big.NewInt(int64(v)), nil
+	case int64:
+		return big.NewInt(v), nil
+	case uint64:
+		return new(big.Int).SetUint64(v), nil
+	case float64:
+		if v != float64(int64(v)) || v > 1<<53 || v < -(1<<53) {
+			return nil, ErrInvalidTypedData
+		}
+		return big.NewInt(int64(v)), nil
+	case json.Number:
+		return parseInt(string(v))
+	case string:
+		return parseInt(v)
+	}
+	return nil, ErrInvalidTypedData
+}
+
+// parseInt parses a decimal or 0x-prefixed hex integer.
+func parseInt(s string) (*big.Int, error) {
+	base := 10
+	if strings.HasPrefix(s, "0x") {
+		s, base = s[2:], 16
+	}
+	n, ok := new(big.Int).SetString(s, base)
+	if !ok || strings.HasPrefix(s, "+") {
+		return nil, ErrInvalidTypedData
+	}
+	return n, nil
+}
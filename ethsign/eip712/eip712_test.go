@@ -0,0 +1,181 @@
+package eip712_test
+
+import (
+	"crypto/ecdsa"
+	"encoding/hex"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/nspcc-dev/rfc6979"
+	"github.com/nspcc-dev/rfc6979/ethsign"
+	"github.com/nspcc-dev/rfc6979/ethsign/eip712"
+)
+
+// The example of EIP-712.
+const mail = `{
+	"types": {
+		"EIP712Domain": [
+			{"name": "name", "type": "string"},
+			{"name": "version", "type": "string"},
+			{"name": "chainId", "type": "uint256"},
+			{"name": "verifyingContract", "type": "address"}
+		],
+		"Person": [
+			{"name": "name", "type": "string"},
+			{"name": "wallet", "type": "address"}
+		],
+		"Mail": [
+			{"name": "from", "type": "Person"},
+			{"name": "to", "type": "Person"},
+			{"name": "contents", "type": "string"}
+		]
+	},
+	"primaryType": "Mail",
+	"domain": {
+		"name": "Ether Mail",
+		"version": "1",
+		"chainId": 1,
+		"verifyingContract": "0xCcCCccccCCCCcCCCCCCcCcCccCcCCCcCcccccccC"
+	},
+	"message": {
+		"from": {"name": "Cow", "wallet": "0xCD2a3d9F938E13CD947Ec05AbC7FE734Df8DD826"},
+		"to": {"name": "Bob", "wallet": "0xbBbBBBBbbBBBbbbBbbBbbbbBBbBbbbbBbBbbBBbB"},
+		"contents": "Hello, Bob!"
+	}
+}`
+
+func mustHex(s string) []byte {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+func parse(t *testing.T, s string) *eip712.TypedData {
+	td := new(eip712.TypedData)
+	if err := json.Unmarshal([]byte(s), td); err != nil {
+		t.Fatal(err)
+	}
+	return td
+}
+
+func TestMail(t *testing.T) {
+	td := parse(t, mail)
+
+	enc, err := td.EncodeType("Mail")
+	if expected := "Mail(Person from,Person to,string contents)Person(string name,address wallet)"; err != nil || enc != expected {
+		t.Errorf("Expected %s, got %s, %v", expected, enc, err)
+	}
+
+	for _, v := range []struct {
+		name     string
+		f        func() ([]byte, error)
+		expected string
+	}{
+		{"type hash", func() ([]byte, error) { return td.TypeHash("Mail") }, "a0cedeb2dc280ba39b857546d74f5549c3a1d7bdc2dd96bf881f76108e23dac2"},
+		{"message", func() ([]byte, error) { return td.HashStruct("Mail", td.Message) }, "c52c0ee5d84264471806290a3f2c4cecfc5490626bf912d01f240d7a274b371e"},
+		{"domain", func() ([]byte, error) { return td.HashStruct("EIP712Domain", td.Domain) }, "f2cee375fa42b42143804025fc449deafd50cc031ca257e0b194a650a912090f"},
+		{"digest", td.Hash, "be609aee343fb3c4b28e1df9e632fca64fcfaede20f02e86244efddf30957bd2"},
+	} {
+		h, err := v.f()
+		if err != nil || hex.EncodeToString(h) != v.expected {
+			t.Errorf("%s: Expected %s, got %x, %v", v.name, v.expected, h, err)
+		}
+	}
+
+	// The private key of "Cow" is Keccak-256 of "cow".
+	c := rfc6979.Secp256k1()
+	key := &ecdsa.PrivateKey{D: new(big.Int).SetBytes(ethsign.Keccak256([]byte("cow")))}
+	key.Curve = c
+	key.X, key.Y = c.ScalarBaseMult(key.D.Bytes())
+
+	sig, err := eip712.Sign(key, td)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := "4355c47d63924e8a72e509b65029052eb6c299d53a04e167c5775fd466751c9d" +
+		"07299936d304c153f6443dfa05f40ff007d72911b6f72307f996231605b91562" + "1c"
+	if hex.EncodeToString(sig) != expected {
+		t.Errorf("Expected %s, got %x", expected, sig)
+	}
+
+	digest, _ := td.Hash()
+	pub, err := ethsign.RecoverPublicKey(digest, sig)
+	if err != nil || ethsign.Address(pub) != ethsign.Address(&key.PublicKey) {
+		t.Errorf("Expected the signer to be recovered, got %v", err)
+	}
+	if addr := ethsign.Address(pub); hex.EncodeToString(addr[:]) != "cd2a3d9f938e13cd947ec05abc7fe734df8dd826" {
+		t.Errorf("Expected Cow's wallet, got %x", addr)
+	}
+}
+
+func TestEncodeValues(t *testing.T) {
+	td := &eip712.TypedData{
+		Types: map[string][]eip712.Field{
+			"Values": {
+				{Name: "i", Type: "int8"},
+				{Name: "u", Type: "uint256"},
+				{Name: "b", Type: "bool"},
+				{Name: "b4", Type: "bytes4"},
+				{Name: "bs", Type: "bytes"},
+				{Name: "list", Type: "uint16[2]"},
+			},
+		},
+	}
+	values := map[string]interface{}{
+		"i":    json.Number("-1"),
+		"u":    "0x0100",
+		"b":    true,
+		"b4":   "0xdeadbeef",
+		"bs":   "0x",
+		"list": []interface{}{float64(1), big.NewInt(2)},
+	}
+
+	word := func(s string) []byte {
+		return mustHex(s)
+	}
+	var enc []byte
+	typeHash, _ := td.TypeHash("Values")
+	enc = append(enc, typeHash...)
+	enc = append(enc, word("ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff")...)
+	enc = append(enc, word("0000000000000000000000000000000000000000000000000000000000000100")...)
+	enc = append(enc, word("0000000000000000000000000000000000000000000000000000000000000001")...)
+	enc = append(enc, word("deadbeef00000000000000000000000000000000000000000000000000000000")...)
+	enc = append(enc, ethsign.Keccak256(nil)...)
+	enc = append(enc, ethsign.Keccak256(
+		word("0000000000000000000000000000000000000000000000000000000000000001"),
+		word("0000000000000000000000000000000000000000000000000000000000000002"))...)
+
+	h, err := td.HashStruct("Values", values)
+	if expected := ethsign.Keccak256(enc); err != nil || string(h) != string(expected) {
+		t.Errorf("Expected %x, got %x, %v", expected, h, err)
+	}
+
+	for field, bad := range map[string]interface{}{
+		"i":    json.Number("128"),
+		"u":    "-1",
+		"b":    "true",
+		"b4":   "0xdead",
+		"bs":   "dead",
+		"list": []interface{}{float64(1)},
+	} {
+		invalid := make(map[string]interface{})
+		for k, v := range values {
+			invalid[k] = v
+		}
+		invalid[field] = bad
+		if _, err := td.HashStruct("Values", invalid); err != eip712.ErrInvalidTypedData {
+			t.Errorf("%s: Expected %v, got %v", field, eip712.ErrInvalidTypedData, err)
+		}
+	}
+
+	delete(values, "b")
+	if _, err := td.HashStruct("Values", values); err != eip712.ErrInvalidTypedData {
+		t.Errorf("Expected %v for a missing member, got %v", eip712.ErrInvalidTypedData, err)
+	}
+	if _, err := td.Hash(); err != eip712.ErrInvalidTypedData {
+		t.Errorf("Expected %v without a domain type, got %v", eip712.ErrInvalidTypedData, err)
+	}
+}
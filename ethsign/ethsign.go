@@ -0,0 +1,151 @@
+/*
+Package ethsign produces Ethereum compatible RFC 6979 deterministic
+signatures over secp256k1.
+
+Ethereum hashes with Keccak-256, the original Keccak submission, which differs
+from the standardized SHA3-256 in padding only, but yields entirely different
+digests: Keccak-256("") is c5d2460186f7…, SHA3-256("") is a7ffc6f8bf1e….
+Using crypto/sha3 instead is a common bug, which is why the digests here are
+always computed by this package. The nonce is derived with HMAC-SHA-256 like
+libsecp256k1 does, so signatures match the ones of go-ethereum and web3
+libraries.
+
+It lives in its own module to keep the Keccak dependency out of package
+rfc6979.
+*/
+package ethsign
+
+import (
+	"crypto/ecdsa"
+	"crypto/sha256"
+	"errors"
+	"math/big"
+	"strconv"
+
+	"github.com/nspcc-dev/rfc6979"
+	"golang.org/x/crypto/sha3"
+)
+
+// SignatureSize is the length of a recoverable signature.
+const SignatureSize = 65
+
+// ErrInvalidSignature is returned for signatures that are malformed or don't
+// allow to recover the public key.
+var ErrInvalidSignature = errors.New("ethsign: invalid signature")
+
+// ErrInvalidChainID is returned for chain ids that aren't positive.
+var ErrInvalidChainID = errors.New("ethsign: invalid chain id")
+
+// Keccak256 returns the Keccak-256 digest of the concatenated data.
+func Keccak256(data ...[]byte) []byte {
+	h := sha3.NewLegacyKeccak256()
+	for _, d := range data {
+		h.Write(d)
+	}
+	return h.Sum(nil)
+}
+
+// PersonalHash returns the digest signed by personal_sign (EIP-191 version
+// 0x45): Keccak-256 of "\x19Ethereum Signed Message:\n", the decimal length of
+// the message and the message itself.
+func PersonalHash(message []byte) []byte {
+	return Keccak256([]byte("\x19Ethereum Signed Message:\n"+strconv.Itoa(len(message))), message)
+}
+
+// SignHash signs a 32-byte digest with the secp256k1 private key, priv, and
+// returns the 65-byte signature r || s || v, where r and s are 32-byte
+// big-endian integers, s is low (EIP-2) and v is the recovery id, 0 or 1, as
+// go-ethereum's crypto.Sign does. rfc6979.ErrUnsupportedCurve is returned for
+// keys on other curves.
+func SignHash(priv *ecdsa.PrivateKey, digest []byte) ([]byte, error) {
+	if !rfc6979.IsSecp256k1(priv.Curve) {
+		return nil, rfc6979.ErrUnsupportedCurve
+	}
+
+	r, s, recid := rfc6979.SignECDSARecoverable(priv, digest, sha256.New, &rfc6979.Options{LowS: true})
+	sig := make([]byte, SignatureSize)
+	r.FillBytes(sig[:32])
+	s.FillBytes(sig[32:64])
+	sig[64] = recid
+	return sig, nil
+}
+
+// SignPersonal signs the message like personal_sign and eth_sign of JSON-RPC
+// do, returning r || s || v with v being 27 or 28.
+func SignPersonal(priv *ecdsa.PrivateKey, message []byte) ([]byte, error) {
+	sig, err := SignHash(priv, PersonalHash(message))
+	if err != nil {
+		return nil, err
+	}
+	sig[64] += 27
+	return sig, nil
+}
+
+// RecoverPublicKey returns the public key that made the signature of the
+// digest, accepting v as either 0/1 or 27/28.
+func RecoverPublicKey(digest, sig []byte) (*ecdsa.PublicKey, error) {
+	if len(sig) != SignatureSize {
+		return nil, ErrInvalidSignature
+	}
+	v := sig[64]
+	if v >= 27 {
+		v -= 27
+	}
+	if v > 1 {
+		return nil, ErrInvalidSignature
+	}
+
+	r := new(big.Int).SetBytes(sig[:32])
+	s := new(big.Int).SetBytes(sig[32:64])
+	pub, err := rfc6979.RecoverPublicKey(rfc6979.Secp256k1(), digest, r, s, v)
+	if err != nil {
+		return nil, ErrInvalidSignature
+	}
+	return pub, nil
+}
+
+// Address returns the 20-byte Ethereum address of the public key, the last
+// 20 bytes of Keccak-256 of its uncompressed coordinates.
+func Address(pub *ecdsa.PublicKey) [20]byte {
+	var xy [64]byte
+	pub.X.FillBytes(xy[:32])
+	pub.Y.FillBytes(xy[32:])
+
+	var addr [20]byte
+	copy(addr[:], Keccak256(xy[:])[12:])
+	return addr
+}
+
+// SignHashEIP155 is like SignHash, but returns the components separately
+// with v computed for the chain as EIP-155 prescribes for transactions:
+// recovery id + 35 + 2·chainID, e.g. 37 or 38 on mainnet. The digest is
+// the Keccak-256 hash of the RLP encoded transaction with the chain id, 0
+// and 0 in place of v, r and s.
+func SignHashEIP155(priv *ecdsa.PrivateKey, digest []byte, chainID *big.Int) (r, s, v *big.Int, err error) {
+	if chainID.Sign() <= 0 {
+		return nil, nil, nil, ErrInvalidChainID
+	}
+	sig, err := SignHash(priv, digest)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	v = new(big.Int).Lsh(chainID, 1)
+	v.Add(v, big.NewInt(35+int64(sig[64])))
+	return new(big.Int).SetBytes(sig[:32]), new(big.Int).SetBytes(sig[32:64]), v, nil
+}
+
+// EIP155RecoveryID returns the recovery id encoded in v of an EIP-155
+// transaction signature for the chain. ErrInvalidSignature is returned if v
+// doesn't belong to the chain.
+func EIP155RecoveryID(v, chainID *big.Int) (byte, error) {
+	if chainID.Sign() <= 0 {
+		return 0, ErrInvalidChainID
+	}
+	id := new(big.Int).Lsh(chainID, 1)
+	id.Sub(v, id.Add(id, big.NewInt(35)))
+	if !id.IsInt64() || id.Int64() < 0 || id.Int64() > 1 {
+		return 0, ErrInvalidSignature
+	}
+	return byte(id.Int64()), nil
+}
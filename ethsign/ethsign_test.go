@@ -0,0 +1,195 @@
+package ethsign_test
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/sha256"
+	"crypto/sha3"
+	"encoding/hex"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/nspcc-dev/rfc6979"
+	"github.com/nspcc-dev/rfc6979/ethsign"
+)
+
+func mustHex(s string) []byte {
+	b, err := hex.DecodeString(strings.TrimPrefix(s, "0x"))
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+func key(d string) *ecdsa.PrivateKey {
+	c := rfc6979.Secp256k1()
+	priv := &ecdsa.PrivateKey{D: new(big.Int).SetBytes(mustHex(d))}
+	priv.PublicKey.Curve = c
+	priv.PublicKey.X, priv.PublicKey.Y = c.ScalarBaseMult(priv.D.Bytes())
+	return priv
+}
+
+func TestKeccak256(t *testing.T) {
+	keccak := ethsign.Keccak256(nil)
+	if expected := mustHex("c5d2460186f7233c927e7db2dcc703c0e500b653ca82273b7bfad8045d85a470"); string(keccak) != string(expected) {
+		t.Errorf("Expected %x, got %x", expected, keccak)
+	}
+
+	// The mistake this package exists to prevent.
+	if sum := sha3.Sum256(nil); string(sum[:]) == string(keccak) {
+		t.Errorf("Expected Keccak-256 to differ from SHA3-256")
+	}
+}
+
+// The web3.js documentation example of web3.eth.accounts.sign.
+func TestSignPersonal(t *testing.T) {
+	priv := key("4c0883a69102937d6231471b5dbb6204fe5129617082792ae468d01a3f362318")
+	message := []byte("Some data")
+
+	digest := ethsign.PersonalHash(message)
+	if expected := mustHex("1da44b586eb0729ff70a73c326926f6ed5a25f5b056e7f47fbc6e58d86871655"); string(digest) != string(expected) {
+		t.Errorf("Expected message hash %x, got %x", expected, digest)
+	}
+
+	sig, err := ethsign.SignPersonal(priv, message)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := mustHex("b91467e570a6466aa9e9876cbcd013baba02900b8979d43fe208a4a4f339f5fd6007e74cd82e037b800186422fc2da167c747ef045e5d18a5f5d4300f8e1a0291c")
+	if string(sig) != string(expected) {
+		t.Errorf("Expected signature %x, got %x", expected, sig)
+	}
+
+	pub, err := ethsign.RecoverPublicKey(digest, sig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := ethsign.Address(pub)
+	if expected := mustHex("2c7536E3605D9C16a7a3D7b1898e529396a65c23"); string(addr[:]) != string(expected) {
+		t.Errorf("Expected address %x, got %x", expected, addr)
+	}
+}
+
+// otherSecp256k1 stands for another implementation of secp256k1, a distinct
+// elliptic.Curve with the same parameters.
+type otherSecp256k1 struct {
+	elliptic.Curve
+}
+
+func TestSignHash(t *testing.T) {
+	priv := key("C9AFA9D845BA75166B5C215767B1D6934E50C3DB36E89B127B8A622B120F6721")
+	for i := 0; i < 16; i++ {
+		digest := ethsign.Keccak256([]byte{byte(i)})
+		sig, err := ethsign.SignHash(priv, digest)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if sig[64] > 1 {
+			t.Fatalf("Expected v to be 0 or 1, got %d", sig[64])
+		}
+
+		s := new(big.Int).SetBytes(sig[32:64])
+		if !rfc6979.IsLowS(priv.Curve, s) {
+			t.Errorf("#%d: Expected low S", i)
+		}
+
+		pub, err := ethsign.RecoverPublicKey(digest, sig)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !pub.Equal(&priv.PublicKey) {
+			t.Errorf("#%d: Recovered wrong public key", i)
+		}
+	}
+
+	digest := ethsign.Keccak256([]byte("other"))
+	expected, _ := ethsign.SignHash(priv, digest)
+	other := *priv
+	other.Curve = otherSecp256k1{priv.Curve}
+	if sig, err := ethsign.SignHash(&other, digest); err != nil || !bytes.Equal(sig, expected) {
+		t.Errorf("Other secp256k1 implementation: expected %X, got %X (%v)", expected, sig, err)
+	}
+
+	if _, err := ethsign.RecoverPublicKey(make([]byte, 32), make([]byte, 64)); err != ethsign.ErrInvalidSignature {
+		t.Errorf("Expected %v, got %v", ethsign.ErrInvalidSignature, err)
+	}
+}
+
+func TestFormat(t *testing.T) {
+	priv := key("C9AFA9D845BA75166B5C215767B1D6934E50C3DB36E89B127B8A622B120F6721")
+	for i := 0; i < 16; i++ {
+		digest := ethsign.Keccak256([]byte{byte(i)})
+		expected, _ := ethsign.SignHash(priv, digest)
+
+		sig, err := rfc6979.SignEncoded(priv, digest, sha256.New, ethsign.FormatName)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(sig, expected) {
+			t.Errorf("#%d: Expected %X, got %X", i, expected, sig)
+		}
+
+		if err := rfc6979.VerifyEncoded(&priv.PublicKey, digest, sig, ethsign.FormatName); err != nil {
+			t.Errorf("#%d: %v", i, err)
+		}
+		sig[64] += 27
+		if err := rfc6979.VerifyEncoded(&priv.PublicKey, digest, sig, ethsign.FormatName); err != nil {
+			t.Errorf("#%d: Expected v of 27/28 to be accepted, got %v", i, err)
+		}
+
+		// High S is rejected even though it verifies.
+		s := new(big.Int).SetBytes(sig[32:64])
+		s.Sub(priv.Curve.Params().N, s).FillBytes(sig[32:64])
+		if err := rfc6979.VerifyEncoded(&priv.PublicKey, digest, sig, ethsign.FormatName); err != rfc6979.ErrInvalidSignature {
+			t.Errorf("#%d: Expected %v for high S, got %v", i, rfc6979.ErrInvalidSignature, err)
+		}
+	}
+
+	digest := ethsign.Keccak256([]byte("other"))
+	expected, _ := ethsign.SignHash(priv, digest)
+	other := *priv
+	other.Curve = otherSecp256k1{priv.Curve}
+	sig, err := rfc6979.SignEncoded(&other, digest, sha256.New, ethsign.FormatName)
+	if err != nil || !bytes.Equal(sig, expected) {
+		t.Errorf("Other secp256k1 implementation: expected %X, got %X (%v)", expected, sig, err)
+	}
+	if err := rfc6979.VerifyEncoded(&other.PublicKey, digest, sig, ethsign.FormatName); err != nil {
+		t.Errorf("Other secp256k1 implementation: %v", err)
+	}
+}
+
+// The example of EIP-155.
+func TestSignHashEIP155(t *testing.T) {
+	priv := key("4646464646464646464646464646464646464646464646464646464646464646")
+	digest := mustHex("daf5a779ae972f972197303d7b574746c7ef83eadac0f2791ad23db92e4c8e53")
+
+	r, s, v, err := ethsign.SignHashEIP155(priv, digest, big.NewInt(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedR, _ := new(big.Int).SetString("18515461264373351373200002665853028612451056578545711640558177340181847433846", 10)
+	expectedS, _ := new(big.Int).SetString("46948507304638947509940763649030358759909902576025900602547168820602576006531", 10)
+	if v.Int64() != 37 || r.Cmp(expectedR) != 0 || s.Cmp(expectedS) != 0 {
+		t.Errorf("Expected (37, %d, %d), got (%d, %d, %d)", expectedR, expectedS, v, r, s)
+	}
+
+	recid, err := ethsign.EIP155RecoveryID(v, big.NewInt(1))
+	if err != nil || recid != 0 {
+		t.Errorf("Expected recovery id 0, got %d, %v", recid, err)
+	}
+	if _, err := ethsign.EIP155RecoveryID(v, big.NewInt(5)); err != ethsign.ErrInvalidSignature {
+		t.Errorf("Expected %v for another chain, got %v", ethsign.ErrInvalidSignature, err)
+	}
+
+	// v grows past a byte for large chain ids.
+	chainID := big.NewInt(11155111)
+	_, _, v, err = ethsign.SignHashEIP155(priv, digest, chainID)
+	if err != nil || v.Int64() != 2*11155111+35 {
+		t.Errorf("Unexpected v %d, %v", v, err)
+	}
+	if _, _, _, err := ethsign.SignHashEIP155(priv, digest, new(big.Int)); err != ethsign.ErrInvalidChainID {
+		t.Errorf("Expected %v, got %v", ethsign.ErrInvalidChainID, err)
+	}
+}
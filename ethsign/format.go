@@ -0,0 +1,53 @@
+package ethsign
+
+import (
+	"crypto/elliptic"
+	"math/big"
+
+	"github.com/nspcc-dev/rfc6979"
+)
+
+// FormatName is the name of the r || s || v format registered with
+// rfc6979.RegisterFormat, signatures are low-S and v is 0 or 1, as SignHash
+// makes them. Decoding accepts v being 27 or 28 as well.
+const FormatName = "ethereum"
+
+func init() {
+	rfc6979.RegisterFormat(FormatName, rfc6979.Format{
+		Encode: encode,
+		Decode: decode,
+		LowS:   true,
+	})
+}
+
+// encode implements rfc6979.Format.Encode.
+func encode(c elliptic.Curve, r, s *big.Int, recid byte) ([]byte, error) {
+	if !rfc6979.IsSecp256k1(c) {
+		return nil, rfc6979.ErrUnsupportedCurve
+	}
+	if recid > 1 {
+		return nil, ErrInvalidSignature
+	}
+
+	sig, err := rfc6979.MarshalCompact(c, r, s)
+	if err != nil {
+		return nil, err
+	}
+	return append(sig, recid), nil
+}
+
+// decode implements rfc6979.Format.Decode.
+func decode(c elliptic.Curve, sig []byte) (r, s *big.Int, err error) {
+	if !rfc6979.IsSecp256k1(c) {
+		return nil, nil, rfc6979.ErrUnsupportedCurve
+	}
+	if len(sig) != SignatureSize || (sig[64] > 1 && sig[64] != 27 && sig[64] != 28) {
+		return nil, nil, ErrInvalidSignature
+	}
+
+	r, s, err = rfc6979.UnmarshalCompact(c, sig[:64])
+	if err != nil {
+		return nil, nil, ErrInvalidSignature
+	}
+	return r, s, nil
+}
@@ -0,0 +1,12 @@
+module github.com/nspcc-dev/rfc6979/ethsign
+
+go 1.25.0
+
+require (
+	github.com/nspcc-dev/rfc6979 v0.0.0
+	golang.org/x/crypto v0.54.0
+)
+
+require golang.org/x/sys v0.47.0 // indirect
+
+replace github.com/nspcc-dev/rfc6979 => ../
@@ -0,0 +1,127 @@
+package rfc6979
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"hash"
+	"math/big"
+	"sort"
+	"sync"
+)
+
+// Format is a signature encoding that can be registered with RegisterFormat
+// and used by name with SignEncoded and VerifyEncoded.
+type Format struct {
+	// Encode encodes the signature (r, s) made on the curve c, recid is
+	// the recovery id, see SignECDSARecoverable.
+	Encode func(c elliptic.Curve, r, s *big.Int, recid byte) ([]byte, error)
+
+	// Decode decodes a signature made on the curve c, it may ignore the
+	// recovery id.
+	Decode func(c elliptic.Curve, sig []byte) (r, s *big.Int, err error)
+
+	// LowS makes SignEncoded normalize s, for formats that require it.
+	LowS bool
+}
+
+var (
+	formatsMu sync.RWMutex
+	formats   = map[string]Format{
+		"der": {
+			Encode: func(_ elliptic.Curve, r, s *big.Int, _ byte) ([]byte, error) {
+				return MarshalSignatureDER(r, s)
+			},
+			Decode: func(_ elliptic.Curve, sig []byte) (*big.Int, *big.Int, error) {
+				return ParseDERStrict(sig)
+			},
+		},
+		"p1363": {
+			Encode: func(c elliptic.Curve, r, s *big.Int, _ byte) ([]byte, error) {
+				return EncodeP1363(c, r, s)
+			},
+			Decode: DecodeP1363,
+		},
+		"compact": {
+			Encode: MarshalCompactRecoverable,
+			Decode: func(c elliptic.Curve, sig []byte) (*big.Int, *big.Int, error) {
+				r, s, _, err := UnmarshalCompactRecoverable(c, sig)
+				return r, s, err
+			},
+		},
+	}
+)
+
+// RegisterFormat makes a signature format available by the provided name.
+// "der" (see ParseDERStrict), "p1363" (see EncodeP1363) and "compact" (see
+// MarshalCompactRecoverable) are registered by this package, other packages
+// may register theirs in init functions. RegisterFormat panics if the name
+// is already taken or the format lacks a function.
+func RegisterFormat(name string, f Format) {
+	if f.Encode == nil || f.Decode == nil {
+		panic("rfc6979: incomplete format " + name)
+	}
+
+	formatsMu.Lock()
+	defer formatsMu.Unlock()
+	if _, ok := formats[name]; ok {
+		panic("rfc6979: format " + name + " is registered twice")
+	}
+	formats[name] = f
+}
+
+// Formats returns the sorted names of the registered formats.
+func Formats() []string {
+	formatsMu.RLock()
+	defer formatsMu.RUnlock()
+	names := make([]string, 0, len(formats))
+	for name := range formats {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// lookupFormat returns the format registered by the name.
+func lookupFormat(name string) (Format, error) {
+	formatsMu.RLock()
+	defer formatsMu.RUnlock()
+	f, ok := formats[name]
+	if !ok {
+		return Format{}, ErrUnknownFormat
+	}
+	return f, nil
+}
+
+// SignEncoded signs the hash like SignECDSA does, normalizing s if the
+// format requires it, and returns the signature in the format registered by
+// the name.
+func SignEncoded(priv *ecdsa.PrivateKey, hash []byte, alg func() hash.Hash, format string) ([]byte, error) {
+	f, err := lookupFormat(format)
+	if err != nil {
+		return nil, err
+	}
+
+	r, s, recid := SignECDSARecoverable(priv, hash, alg, &Options{LowS: f.LowS})
+	return f.Encode(priv.Curve, r, s, recid)
+}
+
+// VerifyEncoded decodes the signature of the digest in the format
+// registered by the name and verifies it with the public key, pub, like
+// VerifyECDSA does. Signatures that can't be decoded are rejected with the
+// error of the format, invalid ones with ErrInvalidSignature. High s values
+// are rejected for formats requiring low ones.
+func VerifyEncoded(pub *ecdsa.PublicKey, digest, sig []byte, format string) error {
+	f, err := lookupFormat(format)
+	if err != nil {
+		return err
+	}
+
+	r, s, err := f.Decode(pub.Curve, sig)
+	if err != nil {
+		return err
+	}
+	if (f.LowS && s != nil && !IsLowS(pub.Curve, s)) || !VerifyECDSA(pub, digest, r, s) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+package rfc6979_test
+
+import (
+	"bytes"
+	"crypto/elliptic"
+	"crypto/sha256"
+	"math/big"
+	"testing"
+
+	"github.com/nspcc-dev/rfc6979"
+)
+
+func TestSignEncoded(t *testing.T) {
+	digest := sha256.Sum256([]byte("sample"))
+	key := p256.key
+	r, s, recid := rfc6979.SignECDSARecoverable(key, digest[:], sha256.New, nil)
+
+	der, _ := rfc6979.MarshalSignatureDER(r, s)
+	p1363, _ := rfc6979.EncodeP1363(key.Curve, r, s)
+	compact, _ := rfc6979.MarshalCompactRecoverable(key.Curve, r, s, recid)
+	for name, expected := range map[string][]byte{"der": der, "p1363": p1363, "compact": compact} {
+		sig, err := rfc6979.SignEncoded(key, digest[:], sha256.New, name)
+		if err != nil || !bytes.Equal(sig, expected) {
+			t.Errorf("%s: Expected %X, got %X, %v", name, expected, sig, err)
+			continue
+		}
+
+		if err := rfc6979.VerifyEncoded(&key.PublicKey, digest[:], sig, name); err != nil {
+			t.Errorf("%s: %v", name, err)
+		}
+		other := sha256.Sum256([]byte("test"))
+		if err := rfc6979.VerifyEncoded(&key.PublicKey, other[:], sig, name); err != rfc6979.ErrInvalidSignature {
+			t.Errorf("%s: Expected %v, got %v", name, rfc6979.ErrInvalidSignature, err)
+		}
+		if err := rfc6979.VerifyEncoded(&key.PublicKey, digest[:], sig[1:], name); err == nil {
+			t.Errorf("%s: Expected a decoding error", name)
+		}
+	}
+
+	if _, err := rfc6979.SignEncoded(key, digest[:], sha256.New, "pem"); err != rfc6979.ErrUnknownFormat {
+		t.Errorf("Expected %v, got %v", rfc6979.ErrUnknownFormat, err)
+	}
+	if err := rfc6979.VerifyEncoded(&key.PublicKey, digest[:], der, "DER"); err != rfc6979.ErrUnknownFormat {
+		t.Errorf("Expected %v, got %v", rfc6979.ErrUnknownFormat, err)
+	}
+}
+
+func TestRegisterFormat(t *testing.T) {
+	registered := func() bool {
+		for _, name := range rfc6979.Formats() {
+			if name == "test-swapped" {
+				return true
+			}
+		}
+		return false
+	}
+
+	// s || r, low S. The registry outlives repeated test runs.
+	if !registered() {
+		rfc6979.RegisterFormat("test-swapped", rfc6979.Format{
+			Encode: func(c elliptic.Curve, r, s *big.Int, _ byte) ([]byte, error) {
+				return rfc6979.EncodeP1363(c, s, r)
+			},
+			Decode: func(c elliptic.Curve, sig []byte) (*big.Int, *big.Int, error) {
+				s, r, err := rfc6979.DecodeP1363(c, sig)
+				return r, s, err
+			},
+			LowS: true,
+		})
+	}
+	if !registered() {
+		t.Errorf("Expected the format to be listed, got %v", rfc6979.Formats())
+	}
+
+	key := p256.key
+	for i := 0; i < 8; i++ {
+		digest := sha256.Sum256([]byte{byte(i)})
+		sig, err := rfc6979.SignEncoded(key, digest[:], sha256.New, "test-swapped")
+		if err != nil {
+			t.Fatal(err)
+		}
+		s := new(big.Int).SetBytes(sig[:32])
+		if !rfc6979.IsLowS(key.Curve, s) {
+			t.Errorf("#%d: Expected low S", i)
+		}
+		if err := rfc6979.VerifyEncoded(&key.PublicKey, digest[:], sig, "test-swapped"); err != nil {
+			t.Errorf("#%d: %v", i, err)
+		}
+	}
+
+	for name, f := range map[string]rfc6979.Format{
+		"der":        {Encode: func(elliptic.Curve, *big.Int, *big.Int, byte) ([]byte, error) { return nil, nil }, Decode: rfc6979.DecodeP1363},
+		"incomplete": {Decode: rfc6979.DecodeP1363},
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s: Expected a panic", name)
+				}
+			}()
+			rfc6979.RegisterFormat(name, f)
+		}()
+	}
+}
@@ -0,0 +1,35 @@
+package rfc6979
+
+import (
+	"crypto/elliptic"
+	"math/big"
+	"sync"
+
+	"github.com/nspcc-dev/rfc6979/internal/weierstrass"
+)
+
+var (
+	frp256v1Once sync.Once
+	frp256v1     *weierstrass.Curve
+)
+
+func initFRP256v1() {
+	p := &elliptic.CurveParams{Name: "FRP256v1", BitSize: 256}
+	p.P, _ = new(big.Int).SetString("F1FD178C0B3AD58F10126DE8CE42435B3961ADBCABC8CA6DE8FCF353D86E9C03", 16)
+	p.N, _ = new(big.Int).SetString("F1FD178C0B3AD58F10126DE8CE42435B53DC67E140D2BF941FFDD459C6D655E1", 16)
+	p.B, _ = new(big.Int).SetString("EE353FCA5428A9300D4ABA754A44C00FDFEC0C9AE4B1A1803075ED967B7BB73F", 16)
+	p.Gx, _ = new(big.Int).SetString("B6B3D4C356C139EB31183D4749D423958C27D2DCAF98B70164C97A2DD98F5CFF", 16)
+	p.Gy, _ = new(big.Int).SetString("6142E0F7C8B204911F9271F0F3ECEF8C2701C307E8E4C9E183115A1554062CFB", 16)
+	a := new(big.Int).Sub(p.P, big.NewInt(3))
+	frp256v1 = weierstrass.New(p, a)
+}
+
+// FRP256v1 returns an elliptic.Curve implementing FRP256v1, y² = x³ - 3x + b,
+// the curve published by ANSSI in the Journal officiel of 24 October 2011 for
+// RGS-compliant systems. Like Secp256k1 it can be used with SignECDSA and
+// crypto/ecdsa.Verify, and its scalar multiplications are constant-time.
+// Multiple invocations return the same value.
+func FRP256v1() elliptic.Curve {
+	frp256v1Once.Do(initFRP256v1)
+	return frp256v1
+}
@@ -0,0 +1,159 @@
+package rfc6979_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"testing"
+
+	"github.com/nspcc-dev/rfc6979"
+)
+
+// frp256v1 is the key with d = SHA-256("FRP256v1") mod N.
+var frp256v1 = &ecdsaKey{
+	key: &ecdsa.PrivateKey{
+		PublicKey: ecdsa.PublicKey{
+			Curve: rfc6979.FRP256v1(),
+			X:     ecdsaLoadInt("C0C353ECB92EEBD35AA04392CCB35C614E0A643786531EA32F1FF093519D2CDC"),
+			Y:     ecdsaLoadInt("1F9D0289FADC4E24D4BBB4BE6104C58792B89360DA9DB83BFE097EBAC7B15284"),
+		},
+		D: ecdsaLoadInt("4C9DF79499398F4699E10C87CBF362B7430C9867E03CCC705E5C3F2A855F49DC"),
+	},
+	subgroup: 256,
+}
+
+// frp256v1Fixtures follow RFC 6979 appendix A.2, as no vectors have been
+// published for the curve. They were computed by libgcrypt 1.10.1 with
+// (flags rfc6979); it doesn't know FRP256v1, so the curve was given by its
+// parameters as in
+// (private-key (ecc (p …) (a …) (b …) (g …) (n …) (h #01#) (q …) (d …))).
+var frp256v1Fixtures = []ecdsaFixture{
+	{
+		name:    "FRP256v1/SHA-1 #1",
+		key:     frp256v1,
+		alg:     sha1.New,
+		message: "sample",
+		r:       "AEDD985D85F331EED2D39162D6BAFEAB603F7838C997255FCB36E372999C90EA",
+		s:       "1D07015CB67757E83F74D1C65147198D85257C545B9F9C25B5FEB4252111E3D1",
+	},
+	{
+		name:    "FRP256v1/SHA-224 #1",
+		key:     frp256v1,
+		alg:     sha256.New224,
+		message: "sample",
+		r:       "14E8ED256E9B271216DC9D5BAF11BDC05E6FFD98C8A10EB6D3DE1CF38ED558B1",
+		s:       "BE41F07A29293F551ABDA4E56C6DB4FEA3C3BEB9B236C4B03243F4019624768D",
+	},
+	{
+		name:    "FRP256v1/SHA-256 #1",
+		key:     frp256v1,
+		alg:     sha256.New,
+		message: "sample",
+		r:       "E8C8A90B21040F7D6F4CB2572D586110123E99DBF984F65FC4BC47729797B8B8",
+		s:       "7B3DE0AB07D22413DA3E1283122751A2E2218D19C3C0CB8655E606E612C0107B",
+	},
+	{
+		name:    "FRP256v1/SHA-384 #1",
+		key:     frp256v1,
+		alg:     sha512.New384,
+		message: "sample",
+		r:       "4AC6F8B75E7E7D68FC2CF4355A2F10E07DF2B87B6715B88BBFCFCF4560EC326C",
+		s:       "4B35F718966D41E4D14B3569A063A2815FBD7E1059C8D150BA3CBB5FA6141D4F",
+	},
+	{
+		name:    "FRP256v1/SHA-512 #1",
+		key:     frp256v1,
+		alg:     sha512.New,
+		message: "sample",
+		r:       "541B07867F6149C65FDFA3BE8CFC04F6ED4B72A6C6E0CFF0BAB39FBB28017DDD",
+		s:       "A0F3B8E59487A8E4D24B3E9928E51AABDABE978B84202ED8ECC5902BBA981210",
+	},
+	{
+		name:    "FRP256v1/SHA-1 #2",
+		key:     frp256v1,
+		alg:     sha1.New,
+		message: "test",
+		r:       "E10E44874668F9046272576F5A4FBE8954628B187461B008CCC784A694E523C9",
+		s:       "C42EB80658C85E19C81801792DE21F3902E47E7378A00C0BBC9E3A8CAA8A3505",
+	},
+	{
+		name:    "FRP256v1/SHA-224 #2",
+		key:     frp256v1,
+		alg:     sha256.New224,
+		message: "test",
+		r:       "1AE33F205DB495DDDFDFBA5A3F28CD48DEE4A09706C522E0620C16C6DE61379E",
+		s:       "624A4DC6C98AA9FC96D8D5E060B980FACBFABD403491381AC64C76E60D8A34E9",
+	},
+	{
+		name:    "FRP256v1/SHA-256 #2",
+		key:     frp256v1,
+		alg:     sha256.New,
+		message: "test",
+		r:       "D9F78278297BFBD71B7486565E64666A8BBC5C57EE3DB760E19EF535A729D4F1",
+		s:       "C168E4BB152FE7A03AD7C06C4AC6E4127B0B62E23D3FCE68ADA70538F28C199D",
+	},
+	{
+		name:    "FRP256v1/SHA-384 #2",
+		key:     frp256v1,
+		alg:     sha512.New384,
+		message: "test",
+		r:       "D0DCE6F617357182C097AAC5CF5D93F659AAFDE96F1BFB1F25893C31ACB0BDE9",
+		s:       "87FB435C29F60ABE045C26692EAC4549578A0DF7D5D0A8A3DFA0BF245BD826FB",
+	},
+	{
+		name:    "FRP256v1/SHA-512 #2",
+		key:     frp256v1,
+		alg:     sha512.New,
+		message: "test",
+		r:       "DFE6FD1563BFC2939B4105BCE525FEBBBD5C3829760EB6C4175FC36B604C18F9",
+		s:       "2FC604E971F3C20B5FEC91440ACC404A2E3C74142177B77EA30CE7D8BE2FC98B",
+	},
+}
+
+func TestFRP256v1(t *testing.T) {
+	c := rfc6979.FRP256v1()
+	params := c.Params()
+	if !c.IsOnCurve(params.Gx, params.Gy) {
+		t.Fatal("Expected generator to be on the curve")
+	}
+	if x, y := c.ScalarBaseMult(params.N.Bytes()); x.Sign() != 0 || y.Sign() != 0 {
+		t.Errorf("Expected N·G to be the point at infinity, got (%X, %X)", x, y)
+	}
+
+	// CurveParams implements the a = -3 arithmetic generically.
+	generic := *params
+	k := []byte("some fixed scalar")
+	x, y := c.ScalarBaseMult(k)
+	gx, gy := generic.ScalarBaseMult(k)
+	if x.Cmp(gx) != 0 || y.Cmp(gy) != 0 {
+		t.Error("Expected a = -3")
+	}
+
+	key := frp256v1.key
+	if x, y := c.ScalarBaseMult(key.D.Bytes()); x.Cmp(key.X) != 0 || y.Cmp(key.Y) != 0 {
+		t.Fatalf("Unexpected public key (%X, %X)", x, y)
+	}
+
+	for _, f := range frp256v1Fixtures {
+		testEcsaFixture(&f, t)
+
+		h := f.alg()
+		h.Write([]byte(f.message))
+		digest := h.Sum(nil)
+		r, s := ecdsaLoadInt(f.r), ecdsaLoadInt(f.s)
+		if !ecdsa.Verify(&key.PublicKey, digest, r, s) {
+			t.Errorf("%s: Invalid signature", f.name)
+		}
+
+		found := false
+		for recid := byte(0); recid < 4; recid++ {
+			if pub, err := rfc6979.RecoverPublicKey(c, digest, r, s, recid); err == nil && pub.Equal(&key.PublicKey) {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("%s: Expected the public key to be recoverable", f.name)
+		}
+	}
+}
@@ -0,0 +1,35 @@
+package rfc6979
+
+import (
+	"hash"
+	"math/big"
+)
+
+// SignGeneric signs the hash with the private key x in an arbitrary group of
+// prime order q, which makes it possible to use RFC 6979 with groups not
+// available as elliptic.Curve, e.g. the prime-order subgroup of Curve448.
+// baseMult must return the integer representation of k·G (the x coordinate
+// for elliptic curves, g^k mod p for DSA), it is reduced modulo q by the
+// signer. The hash is truncated to the bit length of q as in SignECDSA.
+//
+// It returns the signature as a pair of integers, where r = baseMult(k) mod q
+// and s = k⁻¹(h + x·r) mod q.
+func SignGeneric(q, x *big.Int, hash []byte, alg func() hash.Hash, baseMult func(k *big.Int) *big.Int) (r, s *big.Int) {
+	e := bits2int(hash, q.BitLen())
+
+	generateSecret(q, x, alg, hash, func(k *big.Int) bool {
+		r = new(big.Int).Mod(baseMult(k), q)
+		if r.Sign() == 0 {
+			return false
+		}
+
+		s = new(big.Int).Mul(x, r)
+		s.Add(s, e)
+		s.Mul(s, new(big.Int).ModInverse(k, q))
+		s.Mod(s, q)
+
+		return s.Sign() != 0
+	})
+
+	return
+}
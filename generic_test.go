@@ -0,0 +1,96 @@
+package rfc6979_test
+
+import (
+	"crypto/sha512"
+	"math/big"
+	"testing"
+
+	"github.com/nspcc-dev/rfc6979"
+)
+
+// stubGroup is the additive group of integers modulo a prime, which has the
+// same order as the Curve448 prime-order subgroup. It's cryptographically
+// useless, but enough to check the signer only depends on the group order
+// and the scalar multiplication.
+type stubGroup struct {
+	q, g *big.Int
+}
+
+func newStubGroup() *stubGroup {
+	l, _ := new(big.Int).SetString("3fffffffffffffffffffffffffffffffffffffffffffffffffffffff7cca23e9c44edb49aed63690216cc2728dc58f552378c292ab5844f3", 16)
+	return &stubGroup{q: l, g: big.NewInt(0x4480)}
+}
+
+func (g *stubGroup) mult(p, k *big.Int) *big.Int {
+	res := new(big.Int).Mul(p, k)
+	return res.Mod(res, g.q)
+}
+
+func (g *stubGroup) verify(pub *big.Int, hash []byte, r, s *big.Int) bool {
+	e := new(big.Int).SetBytes(hash)
+	if excess := len(hash)*8 - g.q.BitLen(); excess > 0 {
+		e.Rsh(e, uint(excess))
+	}
+
+	w := new(big.Int).ModInverse(s, g.q)
+	u1 := new(big.Int).Mul(e, w)
+	u2 := new(big.Int).Mul(r, w)
+
+	point := new(big.Int).Add(g.mult(g.g, u1), g.mult(pub, u2))
+	point.Mod(point, g.q)
+	return point.Cmp(r) == 0
+}
+
+func TestSignGeneric(t *testing.T) {
+	g := newStubGroup()
+	x := new(big.Int).Sub(g.q, big.NewInt(0x1337))
+	pub := g.mult(g.g, x)
+	baseMult := func(k *big.Int) *big.Int { return g.mult(g.g, k) }
+
+	for _, message := range []string{"sample", "test"} {
+		digest := sha512.Sum512([]byte(message))
+
+		r1, s1 := rfc6979.SignGeneric(g.q, x, digest[:], sha512.New, baseMult)
+		r2, s2 := rfc6979.SignGeneric(g.q, x, digest[:], sha512.New, baseMult)
+
+		if r1.Cmp(r2) != 0 || s1.Cmp(s2) != 0 {
+			t.Errorf("%s: Expected deterministic signature", message)
+		}
+
+		k := rfc6979.GenerateK(g.q, x, sha512.New, digest[:])
+		if r1.Cmp(baseMult(k)) != 0 {
+			t.Errorf("%s: Expected r to be derived from GenerateK", message)
+		}
+
+		if !g.verify(pub, digest[:], r1, s1) {
+			t.Errorf("%s: Invalid signature", message)
+		}
+	}
+}
+
+func TestGenerateKFunc(t *testing.T) {
+	g := newStubGroup()
+	x := big.NewInt(123456789)
+	digest := sha512.Sum512([]byte("sample"))
+
+	first := rfc6979.GenerateK(g.q, x, sha512.New, digest[:])
+
+	calls := 0
+	k := rfc6979.GenerateKFunc(g.q, x, sha512.New, digest[:], func(k *big.Int) bool {
+		calls++
+		return calls == 2
+	})
+	if calls != 2 {
+		t.Fatalf("Expected 2 candidates, got %d", calls)
+	}
+	if k.Cmp(first) == 0 || k.Sign() <= 0 || k.Cmp(g.q) >= 0 {
+		t.Errorf("Expected the second candidate to be a different value within [1, q-1], got %X", k)
+	}
+
+	again := rfc6979.GenerateKFunc(g.q, x, sha512.New, digest[:], func(c *big.Int) bool {
+		return c.Cmp(first) != 0
+	})
+	if again.Cmp(k) != 0 {
+		t.Errorf("Expected candidates to be deterministic")
+	}
+}
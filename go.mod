@@ -1,3 +1,3 @@
 module github.com/nspcc-dev/rfc6979
 
-go 1.12
+go 1.24
@@ -0,0 +1,74 @@
+package rfc6979
+
+import (
+	"crypto/ecdsa"
+	"hash"
+	"io"
+	"math/big"
+)
+
+// SignECDSAWithEntropy signs the hash using the private key, priv, with the
+// additional data k' appended to the nonce generator seed as described in RFC
+// 6979 section 3.6. With fresh random extra data the signatures are hedged:
+// no longer reproducible by anyone knowing the message, yet as safe as
+// deterministic ones if the random source fails. Empty extra data gives the
+// plain RFC 6979 signature. It returns the signature as a pair of integers.
+func SignECDSAWithEntropy(priv *ecdsa.PrivateKey, hash []byte, alg func() hash.Hash, extra []byte) (r, s *big.Int) {
+	return SignECDSAWithOptions(priv, hash, alg, &Options{ExtraData: extra})
+}
+
+// SignECDSAHedged signs the hash using the private key, priv, mixing entropy
+// into the nonce as described in RFC 6979 section 3.6 whenever it's
+// available. It returns the signature as a pair of integers.
+//
+// Exactly as many bytes as the group order is long are read from entropy
+// with io.ReadFull and used as the extra data k'. If entropy is nil or the
+// read fails for any reason, including io.EOF and short reads, whatever was
+// read is discarded and the signature is the plain RFC 6979 one, identical to
+// what SignECDSA produces. Either way the signature is valid and a broken
+// generator can't leak the key. report, if not nil, is called with true when
+// the signature is hedged and false when it fell back to determinism.
+func SignECDSAHedged(priv *ecdsa.PrivateKey, hash []byte, alg func() hash.Hash, entropy io.Reader, report func(hedged bool)) (r, s *big.Int) {
+	var extra []byte
+	if entropy != nil {
+		extra = make([]byte, OrderSize(priv.Curve))
+		if _, err := io.ReadFull(entropy, extra); err != nil {
+			extra = nil
+		}
+	}
+
+	if report != nil {
+		report(extra != nil)
+	}
+	return SignECDSAWithEntropy(priv, hash, alg, extra)
+}
+
+// SignECDSANoisy signs the hash using the private key, priv, following the
+// CFRG "deterministic signatures with noise" construction
+// (draft-irtf-cfrg-det-sigs-with-noise) when noise is not nil, and RFC 6979
+// otherwise. It returns the signature as a pair of integers.
+//
+// A fresh random Z, as long as the group order, is read from noise, and the
+// HMAC_DRBG is instantiated with
+//
+//	Z || 000... || int2octets(x) || 000... || bits2octets(h1)
+//
+// instead of int2octets(x) || bits2octets(h1), where the first run of zeros
+// makes V || 0x00 || Z || 000... a multiple of the hash block size and the
+// second one does the same for int2octets(x) || 000..., so that the secret
+// key and the noise are processed in separate HMAC blocks. Any read error is
+// returned, unlike SignECDSAHedged there is no fallback.
+func SignECDSANoisy(priv *ecdsa.PrivateKey, hash []byte, alg func() hash.Hash, noise io.Reader) (r, s *big.Int, err error) {
+	if noise == nil {
+		r, s = SignECDSA(priv, hash, alg)
+		return r, s, nil
+	}
+
+	z := make([]byte, OrderSize(priv.Curve))
+	if _, err := io.ReadFull(noise, z); err != nil {
+		return nil, nil, err
+	}
+
+	r, s = SignECDSAWithOptions(priv, hash, alg, &Options{noise: z})
+	return r, s, nil
+}
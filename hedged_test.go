@@ -0,0 +1,167 @@
+package rfc6979_test
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+	"io"
+	"math/big"
+	"testing"
+	"testing/iotest"
+
+	"github.com/nspcc-dev/rfc6979"
+)
+
+func TestSignECDSAHedged(t *testing.T) {
+	for _, f := range fixtures {
+		h := f.alg()
+		h.Write([]byte(f.message))
+		digest := h.Sum(nil)
+		expectedR, expectedS := ecdsaLoadInt(f.r), ecdsaLoadInt(f.s)
+
+		var hedged bool
+		report := func(v bool) { hedged = v }
+
+		r, s := rfc6979.SignECDSAHedged(f.key.key, digest, f.alg, rand.Reader, report)
+		if !hedged {
+			t.Errorf("%s: Expected hedged signature", f.name)
+		}
+		if r.Cmp(expectedR) == 0 {
+			t.Errorf("%s: Expected hedged nonce to differ", f.name)
+		}
+		if !ecdsa.Verify(&f.key.key.PublicKey, digest, r, s) {
+			t.Errorf("%s: Invalid hedged signature", f.name)
+		}
+
+		for _, entropy := range []io.Reader{
+			nil,
+			bytes.NewReader(nil),
+			bytes.NewReader([]byte{1, 2, 3}),
+			iotest.ErrReader(errors.New("no entropy")),
+		} {
+			hedged = true
+			r, s := rfc6979.SignECDSAHedged(f.key.key, digest, f.alg, entropy, report)
+			if hedged {
+				t.Errorf("%s: Expected fallback to be reported", f.name)
+			}
+			if r.Cmp(expectedR) != 0 || s.Cmp(expectedS) != 0 {
+				t.Errorf("%s: Expected fallback to reproduce the RFC 6979 signature", f.name)
+			}
+		}
+	}
+}
+
+func TestSignECDSAHedgedDeterministicEntropy(t *testing.T) {
+	digest := make([]byte, 32)
+	entropy := bytes.Repeat([]byte{0x42}, 64)
+
+	r1, s1 := rfc6979.SignECDSAHedged(p256.key, digest, sha256.New, bytes.NewReader(entropy), nil)
+	r2, s2 := rfc6979.SignECDSAHedged(p256.key, digest, sha256.New, bytes.NewReader(entropy), nil)
+	if r1.Cmp(r2) != 0 || s1.Cmp(s2) != 0 {
+		t.Error("Expected equal entropy to produce equal signatures")
+	}
+
+	r3, _ := rfc6979.SignECDSAWithOptions(p256.key, digest, sha256.New, &rfc6979.Options{ExtraData: entropy[:32]})
+	if r1.Cmp(r3) != 0 {
+		t.Error("Expected entropy to be used as ExtraData")
+	}
+}
+
+func TestSignECDSAWithEntropy(t *testing.T) {
+	for _, f := range fixtures {
+		h := f.alg()
+		h.Write([]byte(f.message))
+		digest := h.Sum(nil)
+		N := f.key.key.Curve.Params().N
+
+		r, s := rfc6979.SignECDSAWithEntropy(f.key.key, digest, f.alg, nil)
+		if r.Cmp(ecdsaLoadInt(f.r)) != 0 || s.Cmp(ecdsaLoadInt(f.s)) != 0 {
+			t.Errorf("%s: Expected no entropy to give the RFC signature", f.name)
+		}
+
+		extra := []byte("k' from a random source")
+		r, s = rfc6979.SignECDSAWithEntropy(f.key.key, digest, f.alg, extra)
+		if r.Cmp(ecdsaLoadInt(f.r)) == 0 {
+			t.Errorf("%s: Expected entropy to change the nonce", f.name)
+		}
+		if !ecdsa.Verify(&f.key.key.PublicKey, digest, r, s) {
+			t.Errorf("%s: Invalid signature", f.name)
+		}
+
+		// The seed is int2octets(x) || bits2octets(h1) || k'.
+		size := rfc6979.OrderSize(f.key.key.Curve)
+		z := new(big.Int).SetBytes(digest)
+		if excess := len(digest)*8 - N.BitLen(); excess > 0 {
+			z.Rsh(z, uint(excess))
+		}
+		if z.Cmp(N) >= 0 {
+			z.Sub(z, N)
+		}
+		seed := append(f.key.key.D.FillBytes(make([]byte, size)), z.FillBytes(make([]byte, size))...)
+		k := rfc6979.GenerateKFromSeed(N, f.alg, append(seed, extra...))
+		x, _ := f.key.key.Curve.ScalarBaseMult(k.Bytes())
+		if x.Mod(x, N).Cmp(r) != 0 {
+			t.Errorf("%s: Expected k' to be appended to the seed", f.name)
+		}
+	}
+}
+
+func TestSignECDSANoisy(t *testing.T) {
+	for _, f := range fixtures {
+		h := f.alg()
+		h.Write([]byte(f.message))
+		digest := h.Sum(nil)
+
+		r, s, err := rfc6979.SignECDSANoisy(f.key.key, digest, f.alg, nil)
+		if err != nil || r.Cmp(ecdsaLoadInt(f.r)) != 0 || s.Cmp(ecdsaLoadInt(f.s)) != 0 {
+			t.Errorf("%s: Expected no noise to give the RFC signature", f.name)
+		}
+
+		r, s, err = rfc6979.SignECDSANoisy(f.key.key, digest, f.alg, rand.Reader)
+		if err != nil {
+			t.Fatalf("%s: %v", f.name, err)
+		}
+		if r.Cmp(ecdsaLoadInt(f.r)) == 0 {
+			t.Errorf("%s: Expected noise to change the nonce", f.name)
+		}
+		if !ecdsa.Verify(&f.key.key.PublicKey, digest, r, s) {
+			t.Errorf("%s: Invalid signature", f.name)
+		}
+	}
+
+	noisy := errors.New("no noise")
+	digest := sha256.Sum256([]byte("sample"))
+	if _, _, err := rfc6979.SignECDSANoisy(p256.key, digest[:], sha256.New, iotest.ErrReader(noisy)); err != noisy {
+		t.Errorf("Expected %v, got %v", noisy, err)
+	}
+}
+
+// Spells out the seed layout for P-256 with SHA-256, which has 64-byte blocks.
+func TestSignECDSANoisyLayout(t *testing.T) {
+	key := p256.key
+	N := key.Curve.Params().N
+	digest := sha256.Sum256([]byte("sample"))
+	z := bytes.Repeat([]byte{0x5a}, 32)
+
+	r, _, err := rfc6979.SignECDSANoisy(key, digest[:], sha256.New, bytes.NewReader(z))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// V (32) || 0x00 || Z (32) || 63 zeros fill two blocks, x (32) || 32
+	// zeros fill one, and h1 < N for this digest.
+	var seed []byte
+	seed = append(seed, z...)
+	seed = append(seed, make([]byte, 63)...)
+	seed = append(seed, key.D.FillBytes(make([]byte, 32))...)
+	seed = append(seed, make([]byte, 32)...)
+	seed = append(seed, digest[:]...)
+
+	k := rfc6979.GenerateKFromSeed(N, sha256.New, seed)
+	x, _ := key.Curve.ScalarBaseMult(k.Bytes())
+	if x.Mod(x, N).Cmp(r) != 0 {
+		t.Errorf("Expected the documented seed layout")
+	}
+}
@@ -0,0 +1,173 @@
+// Package cbor implements the subset of CBOR (RFC 8949) used by COSE
+// structures: integers, strings, arrays, maps and tags, always with definite
+// lengths in their shortest form.
+package cbor
+
+// Major types.
+const (
+	Uint  = 0
+	Neg   = 1
+	Bytes = 2
+	Text  = 3
+	Array = 4
+	Map   = 5
+	Tag   = 6
+)
+
+// Simple values.
+const (
+	False = 0xf4
+	True  = 0xf5
+	Null  = 0xf6
+)
+
+// maxDepth limits the nesting of skipped data items.
+const maxDepth = 16
+
+// AppendHead appends the initial bytes of a data item of the major type
+// with the argument n in its shortest form.
+func AppendHead(dst []byte, major byte, n uint64) []byte {
+	major <<= 5
+	switch {
+	case n < 24:
+		return append(dst, major|byte(n))
+	case n <= 0xff:
+		return append(dst, major|24, byte(n))
+	case n <= 0xffff:
+		return append(dst, major|25, byte(n>>8), byte(n))
+	case n <= 0xffffffff:
+		return append(dst, major|26, byte(n>>24), byte(n>>16), byte(n>>8), byte(n))
+	}
+	dst = append(dst, major|27)
+	for i := 7; i >= 0; i-- {
+		dst = append(dst, byte(n>>(8*uint(i))))
+	}
+	return dst
+}
+
+// AppendInt appends an integer.
+func AppendInt(dst []byte, v int64) []byte {
+	if v < 0 {
+		return AppendHead(dst, Neg, uint64(-1-v))
+	}
+	return AppendHead(dst, Uint, uint64(v))
+}
+
+// AppendBytes appends a byte string.
+func AppendBytes(dst, b []byte) []byte {
+	return append(AppendHead(dst, Bytes, uint64(len(b))), b...)
+}
+
+// AppendText appends a text string.
+func AppendText(dst []byte, s string) []byte {
+	return append(AppendHead(dst, Text, uint64(len(s))), s...)
+}
+
+// ReadHead reads the initial bytes of a data item of the major type from
+// the beginning of b, returning its argument. Only definite lengths in their
+// shortest form are accepted.
+func ReadHead(b []byte, major byte) (n uint64, rest []byte, ok bool) {
+	if len(b) == 0 || b[0]>>5 != major {
+		return 0, nil, false
+	}
+
+	info := b[0] & 0x1f
+	b = b[1:]
+	if info < 24 {
+		return uint64(info), b, true
+	}
+	if info > 27 {
+		return 0, nil, false
+	}
+
+	l := 1 << (info - 24)
+	if len(b) < l {
+		return 0, nil, false
+	}
+	for _, c := range b[:l] {
+		n = n<<8 | uint64(c)
+	}
+	if n < 24 || (l > 1 && n>>(4*uint(l)) == 0) {
+		return 0, nil, false
+	}
+	return n, b[l:], true
+}
+
+// ReadInt reads an integer fitting into int64.
+func ReadInt(b []byte) (v int64, rest []byte, ok bool) {
+	if len(b) == 0 {
+		return 0, nil, false
+	}
+	major := b[0] >> 5
+	if major != Uint && major != Neg {
+		return 0, nil, false
+	}
+	n, rest, ok := ReadHead(b, major)
+	if !ok || n > 1<<63-1 {
+		return 0, nil, false
+	}
+	if major == Neg {
+		return -1 - int64(n), rest, true
+	}
+	return int64(n), rest, true
+}
+
+// ReadBytes reads a byte string.
+func ReadBytes(b []byte) (v, rest []byte, ok bool) {
+	return readString(b, Bytes)
+}
+
+// ReadText reads a text string, its UTF-8 validity isn't checked.
+func ReadText(b []byte) (v string, rest []byte, ok bool) {
+	s, rest, ok := readString(b, Text)
+	return string(s), rest, ok
+}
+
+// readString reads a string of the major type.
+func readString(b []byte, major byte) (v, rest []byte, ok bool) {
+	n, rest, ok := ReadHead(b, major)
+	if !ok || uint64(len(rest)) < n {
+		return nil, nil, false
+	}
+	return rest[:n], rest[n:], true
+}
+
+// Skip skips an integer, a string, an array or a map from the beginning of
+// b. Tags, floats and simple values aren't supported.
+func Skip(b []byte) (rest []byte, ok bool) {
+	return skip(b, 0)
+}
+
+func skip(b []byte, depth int) (rest []byte, ok bool) {
+	if len(b) == 0 || depth > maxDepth {
+		return nil, false
+	}
+	major := b[0] >> 5
+	n, rest, ok := ReadHead(b, major)
+	if !ok {
+		return nil, false
+	}
+	switch major {
+	case Uint, Neg:
+		return rest, true
+	case Bytes, Text:
+		if uint64(len(rest)) < n {
+			return nil, false
+		}
+		return rest[n:], true
+	case Array, Map:
+		if major == Map {
+			if n > uint64(len(rest)) {
+				return nil, false
+			}
+			n *= 2
+		}
+		for i := uint64(0); i < n; i++ {
+			if rest, ok = skip(rest, depth+1); !ok {
+				return nil, false
+			}
+		}
+		return rest, true
+	}
+	return nil, false
+}
@@ -0,0 +1,65 @@
+package cbor_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	"github.com/nspcc-dev/rfc6979/internal/cbor"
+)
+
+func TestInt(t *testing.T) {
+	// RFC 8949 appendix A.
+	for v, enc := range map[int64]string{
+		0:                    "00",
+		23:                   "17",
+		24:                   "1818",
+		1000:                 "1903e8",
+		1000000:              "1a000f4240",
+		1000000000000:        "1b000000e8d4a51000",
+		-1:                   "20",
+		-100:                 "3863",
+		-1000:                "3903e7",
+		-9223372036854775808: "3b7fffffffffffffff",
+	} {
+		b := cbor.AppendInt(nil, v)
+		if hex.EncodeToString(b) != enc {
+			t.Errorf("%d: Expected %s, got %x", v, enc, b)
+		}
+		got, rest, ok := cbor.ReadInt(b)
+		if !ok || got != v || len(rest) != 0 {
+			t.Errorf("%d: Got %d, %x, %v", v, got, rest, ok)
+		}
+	}
+
+	// Non-shortest forms, indefinite lengths and overflows.
+	for _, enc := range []string{"1817", "190017", "1900ff", "1a0000ffff", "1b00000000ffffffff", "1f", "1bffffffffffffffff", "40", ""} {
+		b, _ := hex.DecodeString(enc)
+		if _, _, ok := cbor.ReadInt(b); ok {
+			t.Errorf("%s: Expected an error", enc)
+		}
+	}
+}
+
+func TestSkip(t *testing.T) {
+	for _, enc := range []string{
+		"00",
+		"6449455446",
+		"83010203",
+		"a201020304",
+		"a161618301820203820405",
+	} {
+		b, _ := hex.DecodeString(enc + "ff")
+		rest, ok := cbor.Skip(b)
+		if !ok || !bytes.Equal(rest, []byte{0xff}) {
+			t.Errorf("%s: Got %x, %v", enc, rest, ok)
+		}
+	}
+
+	nested := bytes.Repeat([]byte{0x81}, 32)
+	for _, b := range [][]byte{nil, {0x83, 0x01}, {0x64, 0x49}, {0xc0, 0x00}, {cbor.True}, append(nested, 0)} {
+		if _, ok := cbor.Skip(b); ok {
+			t.Errorf("%x: Expected an error", b)
+		}
+	}
+}
@@ -0,0 +1,99 @@
+// Package ecdsatest checks the signers of this module against ECDSA
+// signatures made by other implementations.
+package ecdsatest
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/nspcc-dev/rfc6979"
+)
+
+// Vector is a deterministic signature (R, S) of Message hashed with Hash,
+// made by another implementation with the private scalar D on Curve. Pub is
+// the uncompressed public key and Sig, if set, a DER signature of Message
+// made by yet another implementation.
+type Vector struct {
+	Curve   func() elliptic.Curve
+	Hash    crypto.Hash
+	D, Pub  string
+	Sig     string
+	Message string
+	R, S    string
+}
+
+func loadInt(s string) *big.Int {
+	n, _ := new(big.Int).SetString(s, 16)
+	return n
+}
+
+// Key returns the private key of v.
+func (v *Vector) Key() *ecdsa.PrivateKey {
+	c := v.Curve()
+	priv := &ecdsa.PrivateKey{D: loadInt(v.D)}
+	priv.PublicKey.Curve = c
+	priv.PublicKey.X, priv.PublicKey.Y = c.ScalarBaseMult(priv.D.Bytes())
+	return priv
+}
+
+// Digest returns the hash of the message of v.
+func (v *Vector) Digest() []byte {
+	h := v.Hash.New()
+	h.Write([]byte(v.Message))
+	return h.Sum(nil)
+}
+
+// Check signs every vector with rfc6979.SignECDSA, expecting its signature,
+// and checks its public key and that Sig verifies.
+func Check(t *testing.T, vectors []Vector) {
+	t.Helper()
+	for _, v := range vectors {
+		priv := v.Key()
+		c := priv.Curve
+		name := c.Params().Name
+		expected, _ := hex.DecodeString(v.Pub)
+		if pub := elliptic.Marshal(c, priv.X, priv.Y); !bytes.Equal(pub, expected) {
+			t.Errorf("%s: Expected public key %s, got %X", name, v.Pub, pub)
+		}
+
+		digest := v.Digest()
+		r, s := rfc6979.SignECDSA(priv, digest, v.Hash.New)
+		if r.Cmp(loadInt(v.R)) != 0 || s.Cmp(loadInt(v.S)) != 0 {
+			t.Errorf("%s: Expected (%s, %s), got (%X, %X)", name, v.R, v.S, r, s)
+		}
+		if !rfc6979.VerifyCurve(c, priv.X, priv.Y, digest, r, s) {
+			t.Errorf("%s: Invalid signature", name)
+		}
+		if v.Sig == "" {
+			continue
+		}
+		sig, _ := hex.DecodeString(v.Sig)
+		if !ecdsa.VerifyASN1(&priv.PublicKey, digest, sig) {
+			t.Errorf("%s: Expected the signature %s to verify", name, v.Sig)
+		}
+	}
+}
+
+// CheckRecovery checks that the public key of every vector can be recovered
+// from its signature.
+func CheckRecovery(t *testing.T, vectors []Vector) {
+	t.Helper()
+	for _, v := range vectors {
+		priv := v.Key()
+		digest := v.Digest()
+		found := false
+		for recid := byte(0); recid < 4; recid++ {
+			if pub, err := rfc6979.RecoverPublicKey(priv.Curve, digest, loadInt(v.R), loadInt(v.S), recid); err == nil && pub.Equal(&priv.PublicKey) {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("%s: Expected the public key to be recoverable", priv.Curve.Params().Name)
+		}
+	}
+}
@@ -0,0 +1,181 @@
+package weierstrass
+
+import (
+	"encoding/binary"
+	"math/big"
+	"math/bits"
+)
+
+// maxLimbs is the number of 64-bit limbs of the largest supported field,
+// which is 576 bits long, enough for a 521-bit prime.
+const maxLimbs = 9
+
+// element is a field element in the Montgomery domain, x·R mod p. Only the
+// first n limbs of the field are used, least significant first, the others
+// stay zero.
+type element [maxLimbs]uint64
+
+// field implements arithmetic modulo an odd prime p with R = 2^(64n). All
+// operations take the same time for any input of the field, branches and
+// loop bounds only depend on p.
+type field struct {
+	n    int
+	p    element
+	pInv uint64  // -p⁻¹ mod 2⁶⁴
+	rr   element // R² mod p
+	one  element // R mod p, that is 1 in the Montgomery domain
+	pm2  element // p - 2, the exponent of the inversion
+}
+
+// newField returns the field of the odd prime p.
+func newField(p *big.Int) *field {
+	f := &field{n: (p.BitLen() + 63) / 64}
+	f.p = limbs(p)
+	f.pm2 = limbs(new(big.Int).Sub(p, big.NewInt(2)))
+
+	// Newton's iteration doubles the number of correct low bits of p⁻¹
+	// every step, p being its own inverse modulo 8.
+	inv := f.p[0]
+	for i := 0; i < 5; i++ {
+		inv *= 2 - f.p[0]*inv
+	}
+	f.pInv = -inv
+
+	r := new(big.Int).Lsh(big.NewInt(1), uint(64*f.n))
+	f.one = limbs(new(big.Int).Mod(r, p))
+	f.rr = limbs(r.Mod(r.Mul(r, r), p))
+	return f
+}
+
+// limbs returns the non-negative x, which must fit maxLimbs limbs, as is.
+func limbs(x *big.Int) element {
+	var buf [8 * maxLimbs]byte
+	x.FillBytes(buf[:])
+
+	var e element
+	for i := range e {
+		e[i] = binary.BigEndian.Uint64(buf[len(buf)-8*(i+1):])
+	}
+	return e
+}
+
+// fromBig returns x mod p in the Montgomery domain.
+func (f *field) fromBig(x *big.Int) element {
+	e := limbs(new(big.Int).Mod(x, f.bigP()))
+	f.mul(&e, &e, &f.rr)
+	return e
+}
+
+// toBig returns x out of the Montgomery domain.
+func (f *field) toBig(x *element) *big.Int {
+	e := element{1}
+	f.mul(&e, x, &e)
+
+	buf := make([]byte, 8*f.n)
+	for i := 0; i < f.n; i++ {
+		binary.BigEndian.PutUint64(buf[len(buf)-8*(i+1):], e[i])
+	}
+	return new(big.Int).SetBytes(buf)
+}
+
+// bigP returns p as a big.Int.
+func (f *field) bigP() *big.Int {
+	buf := make([]byte, 8*f.n)
+	for i := 0; i < f.n; i++ {
+		binary.BigEndian.PutUint64(buf[len(buf)-8*(i+1):], f.p[i])
+	}
+	return new(big.Int).SetBytes(buf)
+}
+
+// mul sets z = x·y·R⁻¹ mod p with the CIOS Montgomery multiplication, x and
+// y must be reduced. z may alias x or y.
+func (f *field) mul(z, x, y *element) {
+	n := f.n
+	var t [maxLimbs + 2]uint64
+	for i := 0; i < n; i++ {
+		// t += x·y[i]
+		var c uint64
+		for j := 0; j < n; j++ {
+			hi, lo := bits.Mul64(x[j], y[i])
+			var cc uint64
+			lo, cc = bits.Add64(lo, t[j], 0)
+			hi += cc
+			lo, cc = bits.Add64(lo, c, 0)
+			hi += cc
+			t[j], c = lo, hi
+		}
+		t[n], t[n+1] = bits.Add64(t[n], c, 0)
+
+		// t = (t + m·p) / 2⁶⁴, m making the lowest limb zero
+		m := t[0] * f.pInv
+		hi, lo := bits.Mul64(m, f.p[0])
+		_, cc := bits.Add64(lo, t[0], 0)
+		c = hi + cc
+		for j := 1; j < n; j++ {
+			hi, lo := bits.Mul64(m, f.p[j])
+			lo, cc = bits.Add64(lo, t[j], 0)
+			hi += cc
+			lo, cc = bits.Add64(lo, c, 0)
+			hi += cc
+			t[j-1], c = lo, hi
+		}
+		t[n-1], cc = bits.Add64(t[n], c, 0)
+		t[n] = t[n+1] + cc
+	}
+	f.reduce(z, t[:n], t[n])
+}
+
+// reduce sets z = t mod p for t = top·R + t < 2p.
+func (f *field) reduce(z *element, t []uint64, top uint64) {
+	var u element
+	var b uint64
+	for j := range t {
+		u[j], b = bits.Sub64(t[j], f.p[j], b)
+	}
+	_, b = bits.Sub64(top, 0, b)
+
+	// b is set if t < p, in which case t is kept.
+	mask := -b
+	for j := range t {
+		z[j] = t[j]&mask | u[j]&^mask
+	}
+}
+
+// add sets z = x + y mod p.
+func (f *field) add(z, x, y *element) {
+	var t element
+	var c uint64
+	for j := 0; j < f.n; j++ {
+		t[j], c = bits.Add64(x[j], y[j], c)
+	}
+	f.reduce(z, t[:f.n], c)
+}
+
+// sub sets z = x - y mod p.
+func (f *field) sub(z, x, y *element) {
+	var t element
+	var b uint64
+	for j := 0; j < f.n; j++ {
+		t[j], b = bits.Sub64(x[j], y[j], b)
+	}
+
+	// Add p back if it borrowed.
+	mask := -b
+	var c uint64
+	for j := 0; j < f.n; j++ {
+		z[j], c = bits.Add64(t[j], f.p[j]&mask, c)
+	}
+}
+
+// inv sets z = x⁻¹ mod p as x^(p-2), which is 0 for x = 0. The exponent
+// being public, only the multiplications it requires are done.
+func (f *field) inv(z, x *element) {
+	r := f.one
+	for i := 64*f.n - 1; i >= 0; i-- {
+		f.mul(&r, &r, &r)
+		if f.pm2[i/64]>>uint(i%64)&1 == 1 {
+			f.mul(&r, &r, x)
+		}
+	}
+	*z = r
+}
@@ -0,0 +1,216 @@
+// Package weierstrass implements short Weierstrass curves y² = x³ + ax + b
+// over prime fields with any a, which elliptic.CurveParams can't represent,
+// since its methods assume a = -3.
+package weierstrass
+
+import (
+	"crypto/elliptic"
+	"math/big"
+)
+
+// Curve implements elliptic.Curve for y² = x³ + ax + b. Points are processed
+// in projective coordinates with the complete addition formulas of Renes,
+// Costello and Batina, https://eprint.iacr.org/2015/1060, over fixed-size
+// limbs, so the scalar multiplications are constant-time. The formulas are
+// exception-free on curves of odd order, the curves of this module all have
+// prime order.
+type Curve struct {
+	params *elliptic.CurveParams
+	a      *big.Int
+
+	f    *field
+	am   element // a in the Montgomery domain
+	b3   element // 3b in the Montgomery domain
+	base point
+}
+
+// New returns the curve with the parameters and the coefficient a, which
+// must be reduced modulo params.P. P must be an odd prime of at most 521
+// bits.
+func New(params *elliptic.CurveParams, a *big.Int) *Curve {
+	f := newField(params.P)
+	c := &Curve{params: params, a: a, f: f, am: f.fromBig(a)}
+	c.b3 = f.fromBig(new(big.Int).Mul(params.B, big.NewInt(3)))
+	c.base = c.point(params.Gx, params.Gy)
+	return c
+}
+
+// A returns the coefficient a of the curve, it must not be modified.
+func (c *Curve) A() *big.Int {
+	return c.a
+}
+
+// Params implements elliptic.Curve.
+func (c *Curve) Params() *elliptic.CurveParams {
+	return c.params
+}
+
+// IsOnCurve implements elliptic.Curve.
+func (c *Curve) IsOnCurve(x, y *big.Int) bool {
+	P := c.params.P
+	if x.Sign() < 0 || x.Cmp(P) >= 0 || y.Sign() < 0 || y.Cmp(P) >= 0 {
+		return false
+	}
+
+	// y² = x³ + ax + b
+	y2 := new(big.Int).Mul(y, y)
+	y2.Mod(y2, P)
+
+	x3 := new(big.Int).Mul(x, x)
+	x3.Add(x3, c.a)
+	x3.Mul(x3, x)
+	x3.Add(x3, c.params.B)
+	x3.Mod(x3, P)
+
+	return x3.Cmp(y2) == 0
+}
+
+// Add implements elliptic.Curve.
+func (c *Curve) Add(x1, y1, x2, y2 *big.Int) (x, y *big.Int) {
+	p, q := c.point(x1, y1), c.point(x2, y2)
+	c.add(&p, &p, &q)
+	return c.affine(&p)
+}
+
+// Double implements elliptic.Curve.
+func (c *Curve) Double(x1, y1 *big.Int) (x, y *big.Int) {
+	p := c.point(x1, y1)
+	c.add(&p, &p, &p)
+	return c.affine(&p)
+}
+
+// ScalarMult implements elliptic.Curve. Its running time only depends on the
+// length of k.
+func (c *Curve) ScalarMult(x1, y1 *big.Int, k []byte) (x, y *big.Int) {
+	p := c.point(x1, y1)
+	q := c.scalarMult(&p, k)
+	return c.affine(&q)
+}
+
+// ScalarBaseMult implements elliptic.Curve. Its running time only depends on
+// the length of k.
+func (c *Curve) ScalarBaseMult(k []byte) (x, y *big.Int) {
+	q := c.scalarMult(&c.base, k)
+	return c.affine(&q)
+}
+
+// point is (X:Y:Z) representing (X/Z, Y/Z), with (0:1:0) being the point at
+// infinity. Coordinates are in the Montgomery domain.
+type point struct {
+	x, y, z element
+}
+
+// infinity returns the point at infinity.
+func (c *Curve) infinity() point {
+	return point{y: c.f.one}
+}
+
+// point converts an affine point, (0, 0) being the point at infinity.
+func (c *Curve) point(x, y *big.Int) point {
+	if x.Sign() == 0 && y.Sign() == 0 {
+		return c.infinity()
+	}
+	return point{x: c.f.fromBig(x), y: c.f.fromBig(y), z: c.f.one}
+}
+
+// affine converts a projective point back. The inverse of Z = 0 being 0, the
+// point at infinity yields (0, 0) without a branch.
+func (c *Curve) affine(p *point) (x, y *big.Int) {
+	var zInv, t element
+	c.f.inv(&zInv, &p.z)
+	c.f.mul(&t, &p.x, &zInv)
+	x = c.f.toBig(&t)
+	c.f.mul(&t, &p.y, &zInv)
+	y = c.f.toBig(&t)
+	return x, y
+}
+
+// scalarMult returns k·p with a 4-bit fixed window, every window costing four
+// doublings and one addition of a table entry selected in constant time.
+func (c *Curve) scalarMult(p *point, k []byte) point {
+	var table [16]point
+	table[0] = c.infinity()
+	table[1] = *p
+	for i := 2; i < len(table); i++ {
+		c.add(&table[i], &table[i-1], p)
+	}
+
+	q := c.infinity()
+	var t point
+	for _, b := range k {
+		for _, w := range [2]byte{b >> 4, b & 0xf} {
+			for i := 0; i < 4; i++ {
+				c.add(&q, &q, &q)
+			}
+			c.lookup(&t, &table, w)
+			c.add(&q, &q, &t)
+		}
+	}
+	return q
+}
+
+// lookup sets p = table[w], reading every entry.
+func (c *Curve) lookup(p *point, table *[16]point, w byte) {
+	*p = point{}
+	for i := range table {
+		// mask is all ones if i == w, zero otherwise.
+		v := uint64(i) ^ uint64(w)
+		mask := ((v | -v) >> 63) - 1
+		for j := 0; j < c.f.n; j++ {
+			p.x[j] |= table[i].x[j] & mask
+			p.y[j] |= table[i].y[j] & mask
+			p.z[j] |= table[i].z[j] & mask
+		}
+	}
+}
+
+// add sets r = p + q with algorithm 1 of Renes, Costello and Batina, which
+// is complete: it also doubles and handles the point at infinity. r may
+// alias p or q.
+func (c *Curve) add(r, p, q *point) {
+	f := c.f
+	var t0, t1, t2, t3, t4, t5, x3, y3, z3 element
+
+	f.mul(&t0, &p.x, &q.x)
+	f.mul(&t1, &p.y, &q.y)
+	f.mul(&t2, &p.z, &q.z)
+	f.add(&t3, &p.x, &p.y)
+	f.add(&t4, &q.x, &q.y)
+	f.mul(&t3, &t3, &t4)
+	f.add(&t4, &t0, &t1)
+	f.sub(&t3, &t3, &t4)
+	f.add(&t4, &p.x, &p.z)
+	f.add(&t5, &q.x, &q.z)
+	f.mul(&t4, &t4, &t5)
+	f.add(&t5, &t0, &t2)
+	f.sub(&t4, &t4, &t5)
+	f.add(&t5, &p.y, &p.z)
+	f.add(&x3, &q.y, &q.z)
+	f.mul(&t5, &t5, &x3)
+	f.add(&x3, &t1, &t2)
+	f.sub(&t5, &t5, &x3)
+	f.mul(&z3, &c.am, &t4)
+	f.mul(&x3, &c.b3, &t2)
+	f.add(&z3, &x3, &z3)
+	f.sub(&x3, &t1, &z3)
+	f.add(&z3, &t1, &z3)
+	f.mul(&y3, &x3, &z3)
+	f.add(&t1, &t0, &t0)
+	f.add(&t1, &t1, &t0)
+	f.mul(&t2, &c.am, &t2)
+	f.mul(&t4, &c.b3, &t4)
+	f.add(&t1, &t1, &t2)
+	f.sub(&t2, &t0, &t2)
+	f.mul(&t2, &c.am, &t2)
+	f.add(&t4, &t4, &t2)
+	f.mul(&t0, &t1, &t4)
+	f.add(&y3, &y3, &t0)
+	f.mul(&t0, &t5, &t4)
+	f.mul(&x3, &x3, &t3)
+	f.sub(&x3, &x3, &t0)
+	f.mul(&t0, &t3, &t1)
+	f.mul(&z3, &z3, &t5)
+	f.add(&z3, &z3, &t0)
+
+	r.x, r.y, r.z = x3, y3, z3
+}
@@ -0,0 +1,96 @@
+package weierstrass_test
+
+import (
+	"crypto/elliptic"
+	"crypto/sha256"
+	"math/big"
+	"testing"
+
+	"github.com/nspcc-dev/rfc6979/internal/weierstrass"
+)
+
+// TestNIST checks the arithmetic against the standard library on the NIST
+// curves, which are y² = x³ - 3x + b.
+func TestNIST(t *testing.T) {
+	for _, std := range []elliptic.Curve{elliptic.P224(), elliptic.P256(), elliptic.P384(), elliptic.P521()} {
+		params := std.Params()
+		name := params.Name
+		c := weierstrass.New(params, new(big.Int).Sub(params.P, big.NewInt(3)))
+
+		size := (params.N.BitLen() + 7) / 8
+		nMinus1 := new(big.Int).Sub(params.N, big.NewInt(1))
+		scalars := [][]byte{
+			{0},
+			{1},
+			{2},
+			nMinus1.Bytes(),
+			// Leading zeros and scalars longer than the order are allowed.
+			make([]byte, size+3),
+			append(make([]byte, 5), 7),
+		}
+		seed := []byte(name)
+		for i := 0; i < 8; i++ {
+			h := sha256.Sum256(seed)
+			seed = h[:]
+			k := make([]byte, size)
+			for j := range k {
+				k[j] = seed[j%len(seed)] ^ byte(j)
+			}
+			scalars = append(scalars, k)
+		}
+
+		for _, k := range scalars {
+			x, y := c.ScalarBaseMult(k)
+			ex, ey := std.ScalarBaseMult(k)
+			if x.Cmp(ex) != 0 || y.Cmp(ey) != 0 {
+				t.Errorf("%s: ScalarBaseMult(%X): expected (%X, %X), got (%X, %X)", name, k, ex, ey, x, y)
+			}
+
+			px, py := std.ScalarBaseMult([]byte{3})
+			x, y = c.ScalarMult(px, py, k)
+			ex, ey = std.ScalarMult(px, py, k)
+			if x.Cmp(ex) != 0 || y.Cmp(ey) != 0 {
+				t.Errorf("%s: ScalarMult(%X): expected (%X, %X), got (%X, %X)", name, k, ex, ey, x, y)
+			}
+
+			x, y = c.Add(x, y, px, py)
+			ex, ey = std.Add(ex, ey, px, py)
+			if x.Cmp(ex) != 0 || y.Cmp(ey) != 0 {
+				t.Errorf("%s: Add: expected (%X, %X), got (%X, %X)", name, ex, ey, x, y)
+			}
+
+			x, y = c.Double(x, y)
+			ex, ey = std.Double(ex, ey)
+			if x.Cmp(ex) != 0 || y.Cmp(ey) != 0 {
+				t.Errorf("%s: Double: expected (%X, %X), got (%X, %X)", name, ex, ey, x, y)
+			}
+		}
+
+		// P + (-P) = O, O + P = P, 2·O = O.
+		gx, gy := params.Gx, params.Gy
+		negY := new(big.Int).Sub(params.P, gy)
+		if x, y := c.Add(gx, gy, gx, negY); x.Sign() != 0 || y.Sign() != 0 {
+			t.Errorf("%s: Expected G - G to be the point at infinity, got (%X, %X)", name, x, y)
+		}
+		if x, y := c.Add(new(big.Int), new(big.Int), gx, gy); x.Cmp(gx) != 0 || y.Cmp(gy) != 0 {
+			t.Errorf("%s: Expected O + G = G, got (%X, %X)", name, x, y)
+		}
+		if x, y := c.Double(new(big.Int), new(big.Int)); x.Sign() != 0 || y.Sign() != 0 {
+			t.Errorf("%s: Expected 2·O to be the point at infinity, got (%X, %X)", name, x, y)
+		}
+		if x, y := c.ScalarBaseMult(params.N.Bytes()); x.Sign() != 0 || y.Sign() != 0 {
+			t.Errorf("%s: Expected N·G to be the point at infinity, got (%X, %X)", name, x, y)
+		}
+	}
+}
+
+func BenchmarkScalarBaseMult(b *testing.B) {
+	params := elliptic.P256().Params()
+	c := weierstrass.New(params, new(big.Int).Sub(params.P, big.NewInt(3)))
+	k := sha256.Sum256([]byte("sample"))
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		c.ScalarBaseMult(k[:])
+	}
+}
@@ -0,0 +1,94 @@
+package rfc6979
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"hash"
+	"math/big"
+)
+
+// ErrInvalidJWK is returned when a JSON Web Key can't be used for signing.
+var ErrInvalidJWK = errors.New("rfc6979: invalid EC private JWK")
+
+// jwk is the subset of RFC 7517 JSON Web Key members used for EC keys.
+type jwk struct {
+	Kty string `json:"kty"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+	D   string `json:"d"`
+}
+
+// jwkCurves maps RFC 7518 curve names to curves.
+var jwkCurves = map[string]func() elliptic.Curve{
+	"P-256": elliptic.P256,
+	"P-384": elliptic.P384,
+	"P-521": elliptic.P521,
+}
+
+// SignECDSAFromJWK parses an EC private key in JSON Web Key format (RFC 7517
+// and RFC 7518 section 6.2) and signs the digest with it like SignECDSA does.
+// The key must have the "kty", "crv", "x", "y" and "d" members with
+// correctly sized coordinates and the public point must match d.
+func SignECDSAFromJWK(key []byte, digest []byte, alg func() hash.Hash) (Signature, error) {
+	priv, err := parseJWK(key)
+	if err != nil {
+		return Signature{}, err
+	}
+
+	r, s := SignECDSA(priv, digest, alg)
+	return Signature{R: r, S: s, Curve: priv.Curve}, nil
+}
+
+// parseJWK decodes and validates an EC private JWK.
+func parseJWK(data []byte) (*ecdsa.PrivateKey, error) {
+	var k jwk
+	if err := json.Unmarshal(data, &k); err != nil {
+		return nil, ErrInvalidJWK
+	}
+
+	newCurve, ok := jwkCurves[k.Crv]
+	if k.Kty != "EC" || !ok || k.D == "" {
+		return nil, ErrInvalidJWK
+	}
+	c := newCurve()
+	params := c.Params()
+
+	x, err := jwkInt(k.X, (params.BitSize+7)/8)
+	if err != nil {
+		return nil, err
+	}
+	y, err := jwkInt(k.Y, (params.BitSize+7)/8)
+	if err != nil {
+		return nil, err
+	}
+	d, err := jwkInt(k.D, (params.N.BitLen()+7)/8)
+	if err != nil {
+		return nil, err
+	}
+
+	if d.Sign() == 0 || d.Cmp(params.N) >= 0 || !c.IsOnCurve(x, y) {
+		return nil, ErrInvalidJWK
+	}
+	if px, py := c.ScalarBaseMult(d.Bytes()); px.Cmp(x) != 0 || py.Cmp(y) != 0 {
+		return nil, ErrInvalidJWK
+	}
+
+	return &ecdsa.PrivateKey{
+		PublicKey: ecdsa.PublicKey{Curve: c, X: x, Y: y},
+		D:         d,
+	}, nil
+}
+
+// jwkInt decodes a base64url encoded big-endian integer of exactly size
+// bytes.
+func jwkInt(s string, size int) (*big.Int, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil || len(b) != size {
+		return nil, ErrInvalidJWK
+	}
+	return new(big.Int).SetBytes(b), nil
+}
@@ -0,0 +1,78 @@
+package rfc6979_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/nspcc-dev/rfc6979"
+)
+
+func jwkEncode(v *big.Int, size int) string {
+	b := make([]byte, size)
+	v.FillBytes(b)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func TestSignECDSAFromJWK(t *testing.T) {
+	k, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pub := map[string]string{
+		"kty": "EC",
+		"crv": "P-256",
+		"x":   jwkEncode(k.X, 32),
+		"y":   jwkEncode(k.Y, 32),
+	}
+	priv := map[string]string{"d": jwkEncode(k.D, 32)}
+	for name, v := range pub {
+		priv[name] = v
+	}
+
+	privJSON, _ := json.Marshal(priv)
+	pubJSON, _ := json.Marshal(pub)
+	digest := sha256.Sum256([]byte("sample"))
+
+	sig, err := rfc6979.SignECDSAFromJWK(privJSON, digest[:], sha256.New)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Verify with the key decoded from the public JWK.
+	var decoded map[string]string
+	if err := json.Unmarshal(pubJSON, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	x, _ := base64.RawURLEncoding.DecodeString(decoded["x"])
+	y, _ := base64.RawURLEncoding.DecodeString(decoded["y"])
+	verifier := &ecdsa.PublicKey{
+		Curve: elliptic.P256(),
+		X:     new(big.Int).SetBytes(x),
+		Y:     new(big.Int).SetBytes(y),
+	}
+	if !ecdsa.Verify(verifier, digest[:], sig.R, sig.S) {
+		t.Errorf("Invalid signature")
+	}
+
+	r, s := rfc6979.SignECDSA(k, digest[:], sha256.New)
+	if sig.R.Cmp(r) != 0 || sig.S.Cmp(s) != 0 {
+		t.Errorf("Expected JWK signature to match SignECDSA")
+	}
+
+	if _, err := rfc6979.SignECDSAFromJWK(pubJSON, digest[:], sha256.New); err != rfc6979.ErrInvalidJWK {
+		t.Errorf("Expected public JWK to be rejected, got %v", err)
+	}
+
+	priv["x"] = jwkEncode(new(big.Int).Add(k.X, big.NewInt(1)), 32)
+	mismatched, _ := json.Marshal(priv)
+	if _, err := rfc6979.SignECDSAFromJWK(mismatched, digest[:], sha256.New); err != rfc6979.ErrInvalidJWK {
+		t.Errorf("Expected inconsistent JWK to be rejected, got %v", err)
+	}
+}
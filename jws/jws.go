@@ -0,0 +1,138 @@
+/*
+Package jws produces compact JSON Web Signatures (RFC 7515) with the ECDSA
+algorithms of RFC 7518 section 3.4, using RFC 6979 deterministic signatures.
+Tokens over the same header and payload are identical, which makes them
+suitable for reproducible builds and caching.
+*/
+package jws
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	_ "crypto/sha256" // ES256
+	_ "crypto/sha512" // ES384, ES512
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+
+	"github.com/nspcc-dev/rfc6979"
+)
+
+var (
+	// ErrInvalidAlgorithm is returned for unknown algorithms and ones that
+	// don't match the curve of the key.
+	ErrInvalidAlgorithm = errors.New("jws: invalid algorithm")
+
+	// ErrInvalidToken is returned for malformed tokens.
+	ErrInvalidToken = errors.New("jws: invalid token")
+)
+
+// algorithm describes a JWS algorithm.
+type algorithm struct {
+	curve func() elliptic.Curve
+	hash  crypto.Hash
+
+	// lowS requires s to be at most N/2.
+	lowS bool
+}
+
+// algorithms lists the supported algorithms by their "alg" values.
+var algorithms = map[string]algorithm{
+	"ES256": {elliptic.P256, crypto.SHA256, false},
+	"ES384": {elliptic.P384, crypto.SHA384, false},
+	"ES512": {elliptic.P521, crypto.SHA512, false},
+	// RFC 8812 section 3.2, low S is required for compatibility with
+	// Bitcoin and Ethereum tooling, which rejects high values.
+	"ES256K": {rfc6979.Secp256k1, crypto.SHA256, true},
+}
+
+// lookup returns the algorithm by name, checking that it's meant for c.
+func lookup(alg string, c elliptic.Curve) (algorithm, error) {
+	a, ok := algorithms[alg]
+	if !ok || a.curve().Params().Name != c.Params().Name {
+		return algorithm{}, ErrInvalidAlgorithm
+	}
+	return a, nil
+}
+
+// encoding is the unpadded base64url encoding of JWS.
+var encoding = base64.RawURLEncoding.Strict()
+
+// Sign returns the compact serialization of a JWS over the payload signed
+// with the private key, priv, using alg, which must be the algorithm of the
+// key's curve: ES256 for P-256, ES384 for P-384, ES512 for P-521 and ES256K
+// for secp256k1, whose signatures are low-S normalized. The
+// protected header holds "alg" and the members of header, if any, which
+// must not set "alg" itself; members are sorted, so the token only depends
+// on the inputs.
+func Sign(priv *ecdsa.PrivateKey, alg string, header map[string]interface{}, payload []byte) (string, error) {
+	a, err := lookup(alg, priv.Curve)
+	if err != nil {
+		return "", err
+	}
+	if _, ok := header["alg"]; ok {
+		return "", ErrInvalidAlgorithm
+	}
+
+	h := map[string]interface{}{"alg": alg}
+	for k, v := range header {
+		h[k] = v
+	}
+	protected, err := json.Marshal(h)
+	if err != nil {
+		return "", err
+	}
+
+	input := encoding.EncodeToString(protected) + "." + encoding.EncodeToString(payload)
+	r, s := rfc6979.SignECDSAWithOptions(priv, digest(a, input), a.hash.New, &rfc6979.Options{LowS: a.lowS})
+	sig, err := rfc6979.EncodeP1363(priv.Curve, r, s)
+	if err != nil {
+		return "", err
+	}
+	return input + "." + encoding.EncodeToString(sig), nil
+}
+
+// Verify verifies a compact JWS with the public key, pub, and returns its
+// protected header and payload. The "alg" header must be the algorithm of
+// the key's curve, the signature must be r || s as long as the algorithm
+// prescribes, and low-S for ES256K.
+func Verify(pub *ecdsa.PublicKey, token string) (header map[string]interface{}, payload []byte, err error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, nil, ErrInvalidToken
+	}
+	protected, err := encoding.DecodeString(parts[0])
+	if err != nil || json.Unmarshal(protected, &header) != nil {
+		return nil, nil, ErrInvalidToken
+	}
+	if payload, err = encoding.DecodeString(parts[1]); err != nil {
+		return nil, nil, ErrInvalidToken
+	}
+	sig, err := encoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, nil, ErrInvalidToken
+	}
+
+	alg, _ := header["alg"].(string)
+	a, err := lookup(alg, pub.Curve)
+	if err != nil {
+		return nil, nil, err
+	}
+	r, s, err := rfc6979.DecodeP1363(pub.Curve, sig)
+	if err != nil {
+		return nil, nil, ErrInvalidToken
+	}
+	if (a.lowS && !rfc6979.IsLowS(pub.Curve, s)) || !rfc6979.VerifyECDSA(pub, digest(a, parts[0]+"."+parts[1]), r, s) {
+		return nil, nil, rfc6979.ErrInvalidSignature
+	}
+	return header, payload, nil
+}
+
+// digest hashes the signing input.
+func digest(a algorithm, input string) []byte {
+	h := a.hash.New()
+	h.Write([]byte(input))
+	return h.Sum(nil)
+}
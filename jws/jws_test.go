@@ -0,0 +1,154 @@
+package jws_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"encoding/base64"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/nspcc-dev/rfc6979"
+	"github.com/nspcc-dev/rfc6979/jws"
+)
+
+func b64Int(s string) *big.Int {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		panic(err)
+	}
+	return new(big.Int).SetBytes(b)
+}
+
+// The key of RFC 7515 appendix A.3.
+var rfcKey = &ecdsa.PrivateKey{
+	PublicKey: ecdsa.PublicKey{
+		Curve: elliptic.P256(),
+		X:     b64Int("f83OJ3D2xF1Bg8vub9tLe1gHMzV76e8Tus9uPHvRVEU"),
+		Y:     b64Int("x_FEzRu9m36HLN_tue659LNpXW6pCyStikYjKIWI5a0"),
+	},
+	D: b64Int("jpsQnnGQmL-YBIffH1136cspYG6-0iY7X1fCE9-E9LI"),
+}
+
+func TestVerifyRFC7515(t *testing.T) {
+	token := "eyJhbGciOiJFUzI1NiJ9" +
+		".eyJpc3MiOiJqb2UiLA0KICJleHAiOjEzMDA4MTkzODAsDQogImh0dHA6Ly9leGFtcGxlLmNvbS9pc19yb290Ijp0cnVlfQ" +
+		".DtEhU3ljbEg8L38VWAfUAqOyKAM6-Xx-F4GawxaepmXFCgfTjDxw5djxLa8ISlSApmWQxfKTUJqPP3-Kg6NU1Q"
+
+	header, payload, err := jws.Verify(&rfcKey.PublicKey, token)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if header["alg"] != "ES256" || !strings.HasPrefix(string(payload), `{"iss":"joe",`) {
+		t.Errorf("Unexpected header %v and payload %q", header, payload)
+	}
+}
+
+func TestSign(t *testing.T) {
+	payload := []byte(`{"iss":"joe"}`)
+	for alg, c := range map[string]elliptic.Curve{"ES256": elliptic.P256(), "ES384": elliptic.P384(), "ES512": elliptic.P521()} {
+		key := &ecdsa.PrivateKey{D: big.NewInt(0x1234567)}
+		key.Curve = c
+		key.X, key.Y = c.ScalarBaseMult(key.D.Bytes())
+
+		token, err := jws.Sign(key, alg, map[string]interface{}{"typ": "JWT", "kid": "1"}, payload)
+		if err != nil {
+			t.Fatalf("%s: %v", alg, err)
+		}
+		again, _ := jws.Sign(key, alg, map[string]interface{}{"kid": "1", "typ": "JWT"}, payload)
+		if token != again {
+			t.Errorf("%s: Expected deterministic tokens", alg)
+		}
+
+		parts := strings.Split(token, ".")
+		if expected := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"` + alg + `","kid":"1","typ":"JWT"}`)); parts[0] != expected {
+			t.Errorf("%s: Expected header %s, got %s", alg, expected, parts[0])
+		}
+		sig, _ := base64.RawURLEncoding.DecodeString(parts[2])
+		if len(sig) != 2*rfc6979.OrderSize(c) {
+			t.Errorf("%s: Unexpected signature length %d", alg, len(sig))
+		}
+
+		header, got, err := jws.Verify(&key.PublicKey, token)
+		if err != nil || header["typ"] != "JWT" || string(got) != string(payload) {
+			t.Errorf("%s: Expected round trip, got %v", alg, err)
+		}
+
+		tampered := parts[0] + "." + base64.RawURLEncoding.EncodeToString([]byte(`{"iss":"eve"}`)) + "." + parts[2]
+		if _, _, err := jws.Verify(&key.PublicKey, tampered); err != rfc6979.ErrInvalidSignature {
+			t.Errorf("%s: Expected %v, got %v", alg, rfc6979.ErrInvalidSignature, err)
+		}
+	}
+}
+
+func TestInvalid(t *testing.T) {
+	key := rfcKey
+	for alg, header := range map[string]map[string]interface{}{
+		"ES384":  nil,
+		"ES256K": nil, // P-256 key
+		"none":   nil,
+		"ES256":  {"alg": "none"},
+	} {
+		if _, err := jws.Sign(key, alg, header, nil); err != jws.ErrInvalidAlgorithm {
+			t.Errorf("%s: Expected %v, got %v", alg, jws.ErrInvalidAlgorithm, err)
+		}
+	}
+
+	token, _ := jws.Sign(key, "ES256", nil, []byte("payload"))
+	parts := strings.Split(token, ".")
+	unsigned := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"none"}`)) + "." + parts[1] + "."
+	if _, _, err := jws.Verify(&key.PublicKey, unsigned); err != jws.ErrInvalidAlgorithm {
+		t.Errorf("Expected %v for alg none, got %v", jws.ErrInvalidAlgorithm, err)
+	}
+
+	for name, bad := range map[string]string{
+		"two parts":        parts[0] + "." + parts[1],
+		"padded signature": token + "==",
+		"short signature":  parts[0] + "." + parts[1] + "." + parts[2][4:],
+		"header":           "e30." + parts[1] + "." + parts[2][1:],
+	} {
+		if _, _, err := jws.Verify(&key.PublicKey, bad); err == nil {
+			t.Errorf("%s: Expected an error", name)
+		}
+	}
+}
+
+func TestES256K(t *testing.T) {
+	c := rfc6979.Secp256k1()
+	key := &ecdsa.PrivateKey{D: big.NewInt(0x1234567)}
+	key.Curve = c
+	key.X, key.Y = c.ScalarBaseMult(key.D.Bytes())
+	N := c.Params().N
+
+	for i := 0; i < 16; i++ {
+		payload := []byte{byte(i)}
+		token, err := jws.Sign(key, "ES256K", nil, payload)
+		if err != nil {
+			t.Fatal(err)
+		}
+		parts := strings.Split(token, ".")
+		sig, _ := base64.RawURLEncoding.DecodeString(parts[2])
+		if len(sig) != 64 {
+			t.Fatalf("#%d: Expected 64 bytes, got %d", i, len(sig))
+		}
+		s := new(big.Int).SetBytes(sig[32:])
+		if !rfc6979.IsLowS(c, s) {
+			t.Errorf("#%d: Expected low S", i)
+		}
+
+		if _, got, err := jws.Verify(&key.PublicKey, token); err != nil || string(got) != string(payload) {
+			t.Errorf("#%d: Expected round trip, got %v", i, err)
+		}
+
+		// The high-S twin is mathematically valid, but rejected.
+		s.Sub(N, s).FillBytes(sig[32:])
+		high := parts[0] + "." + parts[1] + "." + base64.RawURLEncoding.EncodeToString(sig)
+		if _, _, err := jws.Verify(&key.PublicKey, high); err != rfc6979.ErrInvalidSignature {
+			t.Errorf("#%d: Expected %v for high S, got %v", i, rfc6979.ErrInvalidSignature, err)
+		}
+	}
+
+	if _, err := jws.Sign(key, "ES256", nil, nil); err != jws.ErrInvalidAlgorithm {
+		t.Errorf("Expected %v for ES256 with a secp256k1 key, got %v", jws.ErrInvalidAlgorithm, err)
+	}
+}
@@ -0,0 +1,30 @@
+package rfc6979
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// ErrWeakKey is returned for private keys that are invalid or trivially
+// weak, which usually indicates broken key generation or corruption.
+var ErrWeakKey = errors.New("rfc6979: weak private key")
+
+// CheckPrivateKey returns an error wrapping ErrWeakKey if the scalar of priv
+// is outside of [1, N-1] or is one of the trivial values 1 and N-1, whose
+// public keys are ±G.
+func CheckPrivateKey(priv *ecdsa.PrivateKey) error {
+	N := priv.Curve.Params().N
+	switch d := priv.D; {
+	case d == nil || d.Sign() == 0:
+		return fmt.Errorf("%w: D is zero", ErrWeakKey)
+	case d.Sign() < 0 || d.Cmp(N) >= 0:
+		return fmt.Errorf("%w: D is out of range", ErrWeakKey)
+	case d.Cmp(one) == 0:
+		return fmt.Errorf("%w: D is one", ErrWeakKey)
+	case new(big.Int).Sub(N, d).Cmp(one) == 0:
+		return fmt.Errorf("%w: D is N-1", ErrWeakKey)
+	}
+	return nil
+}
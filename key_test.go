@@ -0,0 +1,39 @@
+package rfc6979_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/sha256"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/nspcc-dev/rfc6979"
+)
+
+func TestSignECDSAStrict(t *testing.T) {
+	N := p256.key.Curve.Params().N
+	digest := sha256.Sum256([]byte("sample"))
+
+	weak := map[string]*big.Int{
+		"zero":     big.NewInt(0),
+		"one":      big.NewInt(1),
+		"N-1":      new(big.Int).Sub(N, big.NewInt(1)),
+		"N":        new(big.Int).Set(N),
+		"negative": big.NewInt(-1),
+	}
+
+	for name, d := range weak {
+		key := &ecdsa.PrivateKey{PublicKey: p256.key.PublicKey, D: d}
+		if _, _, err := rfc6979.SignECDSAStrict(key, digest[:], sha256.New); !errors.Is(err, rfc6979.ErrWeakKey) {
+			t.Errorf("%s: Expected %v, got %v", name, rfc6979.ErrWeakKey, err)
+		}
+	}
+
+	r, s, err := rfc6979.SignECDSAStrict(p256.key, digest[:], sha256.New)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ecdsa.Verify(&p256.key.PublicKey, digest[:], r, s) {
+		t.Errorf("Invalid signature")
+	}
+}
@@ -0,0 +1,154 @@
+/*
+Package legacy provides prime curves of SEC 2 version 1 that are too weak or
+too rare for new designs but still found in old embedded protocols,
+blockchains and RFID systems: P-192 (secp192r1), secp160r1, secp160r2,
+secp128r1, secp112r1 and the Koblitz curves secp160k1 and secp224k1. They're
+kept out of the rfc6979 package so that using them takes an explicit import;
+signing and verification are done with the rfc6979 functions taking an
+elliptic.Curve, like SignECDSA, VerifyCurve and RecoverPublicKey.
+
+Of the random curves only the ones with a = -3 are provided. All the curves,
+the Koblitz ones included, share the constant-time arithmetic of the rfc6979
+prime curves, like Secp256k1.
+*/
+package legacy
+
+import (
+	"crypto/elliptic"
+	"math/big"
+	"sync"
+
+	"github.com/nspcc-dev/rfc6979/internal/weierstrass"
+)
+
+var (
+	once      sync.Once
+	p192      *weierstrass.Curve
+	secp160r1 *weierstrass.Curve
+	secp160r2 *weierstrass.Curve
+	secp128r1 *weierstrass.Curve
+	secp112r1 *weierstrass.Curve
+	secp160k1 *weierstrass.Curve
+	secp224k1 *weierstrass.Curve
+)
+
+// newParams returns the curve parameters given in hex.
+func newParams(name string, bits int, p, n, b, gx, gy string) *elliptic.CurveParams {
+	params := &elliptic.CurveParams{Name: name, BitSize: bits}
+	params.P, _ = new(big.Int).SetString(p, 16)
+	params.N, _ = new(big.Int).SetString(n, 16)
+	params.B, _ = new(big.Int).SetString(b, 16)
+	params.Gx, _ = new(big.Int).SetString(gx, 16)
+	params.Gy, _ = new(big.Int).SetString(gy, 16)
+	return params
+}
+
+// newCurve returns a curve y² = x³ - 3x + b with the parameters given in
+// hex.
+func newCurve(name string, bits int, p, n, b, gx, gy string) *weierstrass.Curve {
+	params := newParams(name, bits, p, n, b, gx, gy)
+	return weierstrass.New(params, new(big.Int).Sub(params.P, big.NewInt(3)))
+}
+
+// newKoblitz returns a curve y² = x³ + b with the parameters given in hex.
+func newKoblitz(name string, bits int, p, n, b, gx, gy string) *weierstrass.Curve {
+	return weierstrass.New(newParams(name, bits, p, n, b, gx, gy), new(big.Int))
+}
+
+func initAll() {
+	p192 = newCurve("P-192", 192,
+		"FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFEFFFFFFFFFFFFFFFF",
+		"FFFFFFFFFFFFFFFFFFFFFFFF99DEF836146BC9B1B4D22831",
+		"64210519E59C80E70FA7E9AB72243049FEB8DEECC146B9B1",
+		"188DA80EB03090F67CBF20EB43A18800F4FF0AFD82FF1012",
+		"07192B95FFC8DA78631011ED6B24CDD573F977A11E794811")
+	secp160r1 = newCurve("secp160r1", 160,
+		"FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFF7FFFFFFF",
+		"0100000000000000000001F4C8F927AED3CA752257",
+		"1C97BEFC54BD7A8B65ACF89F81D4D4ADC565FA45",
+		"4A96B5688EF573284664698968C38BB913CBFC82",
+		"23A628553168947D59DCC912042351377AC5FB32")
+	secp160r2 = newCurve("secp160r2", 160,
+		"FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFEFFFFAC73",
+		"0100000000000000000000351EE786A818F3A1A16B",
+		"B4E134D3FB59EB8BAB57274904664D5AF50388BA",
+		"52DCB034293A117E1F4FF11B30F7199D3144CE6D",
+		"FEAFFEF2E331F296E071FA0DF9982CFEA7D43F2E")
+	secp128r1 = newCurve("secp128r1", 128,
+		"FFFFFFFDFFFFFFFFFFFFFFFFFFFFFFFF",
+		"FFFFFFFE0000000075A30D1B9038A115",
+		"E87579C11079F43DD824993C2CEE5ED3",
+		"161FF7528B899B2D0C28607CA52C5B86",
+		"CF5AC8395BAFEB13C02DA292DDED7A83")
+	secp112r1 = newCurve("secp112r1", 112,
+		"DB7C2ABF62E35E668076BEAD208B",
+		"DB7C2ABF62E35E7628DFAC6561C5",
+		"659EF8BA043916EEDE8911702B22",
+		"09487239995A5EE76B55F9C2F098",
+		"A89CE5AF8724C0A23E0E0FF77500")
+	secp160k1 = newKoblitz("secp160k1", 160,
+		"FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFEFFFFAC73",
+		"0100000000000000000001B8FA16DFAB9ACA16B6B3",
+		"7",
+		"3B4C382CE37AA192A4019E763036F4F5DD4D7EBB",
+		"938CF935318FDCED6BC28286531733C3F03C4FEE")
+	secp224k1 = newKoblitz("secp224k1", 224,
+		"FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFEFFFFE56D",
+		"010000000000000000000000000001DCE8D2EC6184CAF0A971769FB1F7",
+		"5",
+		"A1455B334DF099DF30FC28A169A467E9E47075A90F7E650EB6B7A45C",
+		"7E089FED7FBA344282CAFBD6F7E319F7C0B0BD59E2CA4BDB556D61A5")
+}
+
+// P192 returns an elliptic.Curve implementing P-192 (FIPS 186-4 section
+// D.1.2.1), also known as secp192r1 and prime192v1, used by RFC 6979
+// appendix A.2.3. Multiple invocations return the same value.
+func P192() elliptic.Curve {
+	once.Do(initAll)
+	return p192
+}
+
+// Secp160r1 returns an elliptic.Curve implementing secp160r1 (SEC 2
+// version 1 section 2.4.2). Its order is 161 bits long, one bit longer than
+// the field.
+func Secp160r1() elliptic.Curve {
+	once.Do(initAll)
+	return secp160r1
+}
+
+// Secp160r2 returns an elliptic.Curve implementing secp160r2 (SEC 2
+// version 1 section 2.4.3). Like secp160r1, it has a 161-bit order.
+func Secp160r2() elliptic.Curve {
+	once.Do(initAll)
+	return secp160r2
+}
+
+// Secp128r1 returns an elliptic.Curve implementing secp128r1 (SEC 2
+// version 1 section 2.3.1).
+func Secp128r1() elliptic.Curve {
+	once.Do(initAll)
+	return secp128r1
+}
+
+// Secp112r1 returns an elliptic.Curve implementing secp112r1 (SEC 2
+// version 1 section 2.2.1).
+func Secp112r1() elliptic.Curve {
+	once.Do(initAll)
+	return secp112r1
+}
+
+// Secp160k1 returns an elliptic.Curve implementing the Koblitz curve
+// secp160k1 (SEC 2 version 1 section 2.4.1), y² = x³ + 7. Like secp160r1, it
+// has a 161-bit order.
+func Secp160k1() elliptic.Curve {
+	once.Do(initAll)
+	return secp160k1
+}
+
+// Secp224k1 returns an elliptic.Curve implementing the Koblitz curve
+// secp224k1 (SEC 2 version 1 section 2.6.1), y² = x³ + 5, with a 225-bit
+// order.
+func Secp224k1() elliptic.Curve {
+	once.Do(initAll)
+	return secp224k1
+}
@@ -0,0 +1,247 @@
+package legacy_test
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"hash"
+	"math/big"
+	"testing"
+
+	"github.com/nspcc-dev/rfc6979"
+	"github.com/nspcc-dev/rfc6979/internal/ecdsatest"
+	"github.com/nspcc-dev/rfc6979/legacy"
+)
+
+func loadInt(s string) *big.Int {
+	n, _ := new(big.Int).SetString(s, 16)
+	return n
+}
+
+// newKey returns the key with the private scalar d, given in hex, on the
+// curve c.
+func newKey(c elliptic.Curve, d string) *ecdsa.PrivateKey {
+	priv := &ecdsa.PrivateKey{D: loadInt(d)}
+	priv.PublicKey.Curve = c
+	priv.PublicKey.X, priv.PublicKey.Y = c.ScalarBaseMult(priv.D.Bytes())
+	return priv
+}
+
+func TestCurves(t *testing.T) {
+	for _, newCurve := range []func() elliptic.Curve{
+		legacy.P192, legacy.Secp160r1, legacy.Secp160r2, legacy.Secp128r1, legacy.Secp112r1,
+		legacy.Secp160k1, legacy.Secp224k1,
+	} {
+		c := newCurve()
+		params := c.Params()
+		name := params.Name
+
+		if newCurve() != c {
+			t.Errorf("%s: Expected the same value on every call", name)
+		}
+		if !c.IsOnCurve(params.Gx, params.Gy) {
+			t.Fatalf("%s: Expected generator to be on the curve", name)
+		}
+		if x, y := c.ScalarBaseMult(params.N.Bytes()); x.Sign() != 0 || y.Sign() != 0 {
+			t.Errorf("%s: Expected N·G to be the point at infinity, got (%X, %X)", name, x, y)
+		}
+		if rfc6979.CoordinateSize(c) != (params.P.BitLen()+7)/8 || rfc6979.OrderSize(c) != (params.N.BitLen()+7)/8 {
+			t.Errorf("%s: Unexpected sizes %d and %d", name, rfc6979.CoordinateSize(c), rfc6979.OrderSize(c))
+		}
+	}
+}
+
+func TestRandomCurves(t *testing.T) {
+	for _, newCurve := range []func() elliptic.Curve{
+		legacy.P192, legacy.Secp160r1, legacy.Secp160r2, legacy.Secp128r1, legacy.Secp112r1,
+	} {
+		c := newCurve()
+		name := c.Params().Name
+
+		// CurveParams implements the a = -3 arithmetic generically.
+		generic := *c.Params()
+		k := []byte("some fixed scalar")
+		x, y := c.ScalarBaseMult(k)
+		gx, gy := generic.ScalarBaseMult(k)
+		if x.Cmp(gx) != 0 || y.Cmp(gy) != 0 {
+			t.Errorf("%s: Expected a = -3", name)
+		}
+	}
+}
+
+// p192Fixtures are taken from RFC 6979 appendix A.2.3.
+var p192Fixtures = []struct {
+	name    string
+	alg     func() hash.Hash
+	message string
+	r, s    string
+}{
+	{
+		name:    "P192/SHA-1 #1",
+		alg:     sha1.New,
+		message: "sample",
+		r:       "98C6BD12B23EAF5E2A2045132086BE3EB8EBD62ABF6698FF",
+		s:       "57A22B07DEA9530F8DE9471B1DC6624472E8E2844BC25B64",
+	},
+	{
+		name:    "P192/SHA-224 #1",
+		alg:     sha256.New224,
+		message: "sample",
+		r:       "A1F00DAD97AEEC91C95585F36200C65F3C01812AA60378F5",
+		s:       "E07EC1304C7C6C9DEBBE980B9692668F81D4DE7922A0F97A",
+	},
+	{
+		name:    "P192/SHA-256 #1",
+		alg:     sha256.New,
+		message: "sample",
+		r:       "4B0B8CE98A92866A2820E20AA6B75B56382E0F9BFD5ECB55",
+		s:       "CCDB006926EA9565CBADC840829D8C384E06DE1F1E381B85",
+	},
+	{
+		name:    "P192/SHA-384 #1",
+		alg:     sha512.New384,
+		message: "sample",
+		r:       "DA63BF0B9ABCF948FBB1E9167F136145F7A20426DCC287D5",
+		s:       "C3AA2C960972BD7A2003A57E1C4C77F0578F8AE95E31EC5E",
+	},
+	{
+		name:    "P192/SHA-512 #1",
+		alg:     sha512.New,
+		message: "sample",
+		r:       "4D60C5AB1996BD848343B31C00850205E2EA6922DAC2E4B8",
+		s:       "3F6E837448F027A1BF4B34E796E32A811CBB4050908D8F67",
+	},
+	{
+		name:    "P192/SHA-1 #2",
+		alg:     sha1.New,
+		message: "test",
+		r:       "0F2141A0EBBC44D2E1AF90A50EBCFCE5E197B3B7D4DE036D",
+		s:       "EB18BC9E1F3D7387500CB99CF5F7C157070A8961E38700B7",
+	},
+	{
+		name:    "P192/SHA-224 #2",
+		alg:     sha256.New224,
+		message: "test",
+		r:       "6945A1C1D1B2206B8145548F633BB61CEF04891BAF26ED34",
+		s:       "B7FB7FDFC339C0B9BD61A9F5A8EAF9BE58FC5CBA2CB15293",
+	},
+	{
+		name:    "P192/SHA-256 #2",
+		alg:     sha256.New,
+		message: "test",
+		r:       "3A718BD8B4926C3B52EE6BBE67EF79B18CB6EB62B1AD97AE",
+		s:       "5662E6848A4A19B1F1AE2F72ACD4B8BBE50F1EAC65D9124F",
+	},
+	{
+		name:    "P192/SHA-384 #2",
+		alg:     sha512.New384,
+		message: "test",
+		r:       "B234B60B4DB75A733E19280A7A6034BD6B1EE88AF5332367",
+		s:       "7994090B2D59BB782BE57E74A44C9A1C700413F8ABEFE77A",
+	},
+	{
+		name:    "P192/SHA-512 #2",
+		alg:     sha512.New,
+		message: "test",
+		r:       "FE4F4AE86A58B6507946715934FE2D8FF9D95B6B098FE739",
+		s:       "74CF5605C98FBA0E1EF34D4B5A1577A7DCF59457CAE52290",
+	},
+}
+
+func TestP192(t *testing.T) {
+	key := newKey(legacy.P192(), "6FAB034934E4C0FC9AE67F5B5659A9D7D1FEFD187EE09FD4")
+	if key.X.Cmp(loadInt("AC2C77F529F91689FEA0EA5EFEC7F210D8EEA0B9E047ED56")) != 0 ||
+		key.Y.Cmp(loadInt("3BC723E57670BD4887EBC732C523063D0A7C957BC97C1C43")) != 0 {
+		t.Fatalf("Unexpected public key (%X, %X)", key.X, key.Y)
+	}
+
+	for _, f := range p192Fixtures {
+		h := f.alg()
+		h.Write([]byte(f.message))
+		digest := h.Sum(nil)
+
+		r, s := rfc6979.SignECDSA(key, digest, f.alg)
+		if r.Cmp(loadInt(f.r)) != 0 || s.Cmp(loadInt(f.s)) != 0 {
+			t.Errorf("%s: Expected (%s, %s), got (%X, %X)", f.name, f.r, f.s, r, s)
+		}
+		if !ecdsa.Verify(&key.PublicKey, digest, r, s) || !rfc6979.VerifyCurve(key.Curve, key.X, key.Y, digest, r, s) {
+			t.Errorf("%s: Invalid signature", f.name)
+		}
+	}
+}
+
+// vectors are signatures made with keys generated by OpenSSL 3.0, Sig being
+// a signature of OpenSSL over "sample\n". R and S were computed by libgcrypt
+// 1.10.1 with (flags rfc6979); it doesn't know these curves, so they were
+// given by their SEC 2 parameters as in
+// (private-key (ecc (p …) (a …) (b …) (g …) (n …) (h #01#) (q …) (d …))).
+var vectors = []ecdsatest.Vector{
+	{
+		Curve:   legacy.Secp160r1,
+		Hash:    crypto.SHA1,
+		D:       "A4D9D59BDF741C117694B3C968376E035863BDD",
+		Pub:     "04FF85D0ACD160FCA087A8A0CBBD23A0994F2D6A231F1CF07BE9D1B7761F1E52B38AEB2EFB39FC96DB",
+		Sig:     "302D021500BCB2A123D9E70980FD6EA8DC3425AE2DD1D4144F02140F9C4FDAF977DD1A2871BD0653B5C05B7D90AFE5",
+		Message: "sample\n",
+		R:       "72A376A87289E0010161A8ADADFBEA2AAD1824C6",
+		S:       "72B7D6BE9DA395742EE31597BA1D0D837B673D2",
+	},
+	{
+		Curve:   legacy.Secp160r2,
+		Hash:    crypto.SHA256,
+		D:       "6B3B35DDDC3A9FA8B2598269AFB3AA8AE8A71233",
+		Pub:     "04239239DD07F48C310B9D99E667B279F84E8BC5DFE639B41FC555C33E5420C0FCDB3E378CFAC47D62",
+		Sig:     "302E02150090F8DF5337D24CCBD3ABE8D5D9C39D854D540B17021500BCAE4784D23446ED197A67B3E175482E69991A3A",
+		Message: "sample\n",
+		R:       "FFE133D19674335D324421BA8B8C905F225AAC06",
+		S:       "C69C65DF51990EF5406C55443AB8F8C8E0FF9BD2",
+	},
+	{
+		Curve:   legacy.Secp128r1,
+		Hash:    crypto.SHA256,
+		D:       "E1AB5FD8318939095279170FF8A44A32",
+		Pub:     "043D60DB36C41A9B88B873CAB9F3B5FE47827D4140BBA6C8D05CFCDF92B61124EA",
+		Sig:     "3025021041ED5EDD19EA3CB71AA4F94C8A2C27C7021100EF340686E8E4CCA6AFCC89615F99449F",
+		Message: "sample\n",
+		R:       "33D2008C6C619F1964370C998B842E21",
+		S:       "1FD5B06C246CACF0D3D523AD03DFC114",
+	},
+	{
+		Curve:   legacy.Secp112r1,
+		Hash:    crypto.SHA1,
+		D:       "A3FD0963272291379B0263102751",
+		Pub:     "048D835A48DAD77F979751D19C0E89604E3820F223430B70697131959E",
+		Sig:     "3020020E0C26C4AC79C29335DDDE6C41FA0A020E2635039388D8364E3840D2AAB6B3",
+		Message: "sample\n",
+		R:       "CA46151D20218F05BC6447623B49",
+		S:       "6F271795BDF97E19B049CCBB31D0",
+	},
+	{
+		Curve:   legacy.Secp160k1,
+		Hash:    crypto.SHA1,
+		D:       "F997D718B804876844D6787E78DD4D78DAAF4578",
+		Pub:     "04CE203A2919FA137DA3E318D3EBDCC188FC287F094EE5579DFE6C72F89565CC5A7A48EEAC3BFB8364",
+		Sig:     "302E021500D50DA81D9C46F64DEEF0177BF480190AA7DF9539021500D47FA0F664E13C2523CBDA501D17D371B82AAA2B",
+		Message: "sample\n",
+		R:       "25C2A8D95A6295211AD6C473DD5D9BD5F33AD0C0",
+		S:       "97D01FA4C1E7970EE91876DF8E3D5C58895B6AED",
+	},
+	{
+		Curve:   legacy.Secp224k1,
+		Hash:    crypto.SHA256,
+		D:       "4BFD8F699FA04300A7B7F3EF3F3B789DAD465D4ACE53C326084B021D",
+		Pub:     "043FA0D760F8A84F43AA8A4E6589010BF70BC859C2C150AB790FF57D3C2E357DCB956FBB7BADF7748A6CEC95EAC3D7AC394FD8063B5DCC999F",
+		Sig:     "303C021C48499D33179E3E12ED27260902A3A02B3782C16B4780313F156BA83F021C6802B8FDC8EFB30A16982149CFE9B800BD1DE81D71FA60EC6A83BD2F",
+		Message: "sample\n",
+		R:       "98DE0FC9A80DFFE897184EFFF1EF012998B32291BEBBE1A1330D1A7",
+		S:       "3C0E8A0A92DFE5691B5CE715E84E5F13A1300C04DC1461FFEFA4B09",
+	},
+}
+
+func TestVectors(t *testing.T) {
+	ecdsatest.Check(t, vectors)
+	ecdsatest.CheckRecovery(t, vectors)
+}
@@ -0,0 +1,32 @@
+package rfc6979
+
+import (
+	"crypto/elliptic"
+	"math/big"
+)
+
+// IsLowS reports whether s is in the lower half of the range, i.e. s <= N/2
+// with N being the order of the curve, as required by low-S policies (BIP
+// 62, EIP-2). Since N is odd, N/2 is rounded down and is itself low.
+func IsLowS(c elliptic.Curve, s *big.Int) bool {
+	return s.Cmp(new(big.Int).Rsh(c.Params().N, 1)) <= 0
+}
+
+// NormalizeS returns s if it's low and N - s otherwise. Both values make a
+// valid signature with the same r, so normalization doesn't invalidate it,
+// but any recovery id has to be adjusted by flipping its lowest bit.
+func NormalizeS(c elliptic.Curve, s *big.Int) *big.Int {
+	if IsLowS(c, s) {
+		return new(big.Int).Set(s)
+	}
+	return new(big.Int).Sub(c.Params().N, s)
+}
+
+// NormalizeSignature returns the low-S form of the signature (r, s), as
+// NormalizeS does, and reports whether s had to be changed, e.g. for relay
+// nodes canonicalizing signatures before broadcasting them further. The
+// returned integers are copies. The signature is not verified.
+func NormalizeSignature(c elliptic.Curve, r, s *big.Int) (r2, s2 *big.Int, changed bool) {
+	s2 = NormalizeS(c, s)
+	return new(big.Int).Set(r), s2, s2.Cmp(s) != 0
+}
@@ -0,0 +1,83 @@
+package rfc6979_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/sha256"
+	"math/big"
+	"testing"
+
+	"github.com/nspcc-dev/rfc6979"
+)
+
+func TestIsLowS(t *testing.T) {
+	c := p256.key.Curve
+	N := c.Params().N
+	half := new(big.Int).Rsh(N, 1)
+
+	tests := []struct {
+		name string
+		s    *big.Int
+		low  bool
+	}{
+		{"one", big.NewInt(1), true},
+		{"N/2 - 1", new(big.Int).Sub(half, big.NewInt(1)), true},
+		{"N/2", half, true},
+		{"N/2 + 1", new(big.Int).Add(half, big.NewInt(1)), false},
+		{"N - 1", new(big.Int).Sub(N, big.NewInt(1)), false},
+	}
+
+	for _, tc := range tests {
+		if low := rfc6979.IsLowS(c, tc.s); low != tc.low {
+			t.Errorf("%s: Expected %t, got %t", tc.name, tc.low, low)
+		}
+
+		n := rfc6979.NormalizeS(c, tc.s)
+		if !rfc6979.IsLowS(c, n) {
+			t.Errorf("%s: Expected normalized value to be low", tc.name)
+		}
+		if tc.low && n.Cmp(tc.s) != 0 {
+			t.Errorf("%s: Expected low value to be kept", tc.name)
+		}
+	}
+}
+
+func TestNormalizeSVerifies(t *testing.T) {
+	key := p256.key
+	digest := sha256.Sum256([]byte("sample"))
+	r, s := rfc6979.SignECDSA(key, digest[:], sha256.New)
+
+	n := rfc6979.NormalizeS(key.Curve, s)
+	if !ecdsa.Verify(&key.PublicKey, digest[:], r, n) {
+		t.Errorf("Invalid normalized signature")
+	}
+
+	_, lowS := rfc6979.SignECDSAWithOptions(key, digest[:], sha256.New, &rfc6979.Options{LowS: true})
+	if lowS.Cmp(n) != 0 {
+		t.Errorf("Expected normalization to match the LowS option")
+	}
+}
+
+func TestNormalizeSignature(t *testing.T) {
+	key := p256.key
+	N := key.Curve.Params().N
+	digest := sha256.Sum256([]byte("sample"))
+
+	r, s := rfc6979.SignECDSAWithOptions(key, digest[:], sha256.New, &rfc6979.Options{LowS: true})
+	high := new(big.Int).Sub(N, s)
+
+	r2, s2, changed := rfc6979.NormalizeSignature(key.Curve, r, s)
+	if changed || r2.Cmp(r) != 0 || s2.Cmp(s) != 0 {
+		t.Errorf("Expected canonical signature to be kept as is")
+	}
+	if r2 == r || s2 == s {
+		t.Errorf("Expected copies to be returned")
+	}
+
+	r2, s2, changed = rfc6979.NormalizeSignature(key.Curve, r, high)
+	if !changed || r2.Cmp(r) != 0 || s2.Cmp(s) != 0 {
+		t.Errorf("Expected non-canonical signature to be normalized")
+	}
+	if !ecdsa.Verify(&key.PublicKey, digest[:], r2, s2) {
+		t.Errorf("Invalid normalized signature")
+	}
+}
@@ -0,0 +1,19 @@
+package rfc6979
+
+import (
+	"crypto/ecdsa"
+	"hash"
+	"math/big"
+)
+
+// SignMerkleRoot signs a Merkle tree root using the private key, priv, like
+// SignECDSA does with root as the hash; alg is only used for nonce
+// generation. It also returns e, the integer actually signed, so that
+// inclusion-proof verifiers can check it against the root. The root is
+// expected to be as long as the curve order (32 bytes for P-256), a longer
+// one is truncated to its leftmost bits, and e is then smaller than the
+// root.
+func SignMerkleRoot(priv *ecdsa.PrivateKey, root []byte, alg func() hash.Hash) (r, s, e *big.Int) {
+	r, s = SignECDSA(priv, root, alg)
+	return r, s, hashToInt(root, priv.Curve)
+}
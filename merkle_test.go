@@ -0,0 +1,31 @@
+package rfc6979_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/sha256"
+	"math/big"
+	"testing"
+
+	"github.com/nspcc-dev/rfc6979"
+)
+
+func TestSignMerkleRoot(t *testing.T) {
+	root := sha256.Sum256([]byte("merkle root"))
+
+	for _, key := range []*ecdsaKey{p224, p256, p384} {
+		name := key.key.Curve.Params().Name
+		r, s, e := rfc6979.SignMerkleRoot(key.key, root[:], sha256.New)
+
+		if !ecdsa.Verify(&key.key.PublicKey, root[:], r, s) {
+			t.Errorf("%s: Invalid signature", name)
+		}
+
+		expected := new(big.Int).SetBytes(root[:])
+		if excess := 256 - key.key.Curve.Params().N.BitLen(); excess > 0 {
+			expected.Rsh(expected, uint(excess))
+		}
+		if e.Cmp(expected) != 0 {
+			t.Errorf("%s: Expected signed integer %X, got %X", name, expected, e)
+		}
+	}
+}
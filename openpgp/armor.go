@@ -0,0 +1,47 @@
+package openpgp
+
+import (
+	"encoding/base64"
+	"strings"
+)
+
+// Armor block types, RFC 4880 section 6.2.
+const (
+	BlockSignature = "PGP SIGNATURE"
+	BlockPublicKey = "PGP PUBLIC KEY BLOCK"
+)
+
+// lineLength is the width of armored lines, as GnuPG makes them.
+const lineLength = 64
+
+// Armor returns the packets as an ASCII armored block of the type, e.g.
+// BlockSignature for "git commit -S" compatible output. The block has no
+// headers and ends with the CRC-24 checksum.
+func Armor(blockType string, packets []byte) []byte {
+	enc := base64.StdEncoding.EncodeToString(packets)
+	var b strings.Builder
+	b.WriteString("-----BEGIN " + blockType + "-----\n\n")
+	for len(enc) > lineLength {
+		b.WriteString(enc[:lineLength] + "\n")
+		enc = enc[lineLength:]
+	}
+	sum := crc24(packets)
+	b.WriteString(enc + "\n=" + base64.StdEncoding.EncodeToString([]byte{byte(sum >> 16), byte(sum >> 8), byte(sum)}))
+	b.WriteString("\n-----END " + blockType + "-----\n")
+	return []byte(b.String())
+}
+
+// crc24 returns the armor checksum of the data, RFC 4880 section 6.1.
+func crc24(data []byte) uint32 {
+	crc := uint32(0xb704ce)
+	for _, c := range data {
+		crc ^= uint32(c) << 16
+		for i := 0; i < 8; i++ {
+			crc <<= 1
+			if crc&0x1000000 != 0 {
+				crc ^= 0x1864cfb
+			}
+		}
+	}
+	return crc & 0xffffff
+}
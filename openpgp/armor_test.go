@@ -0,0 +1,29 @@
+package openpgp_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nspcc-dev/rfc6979/openpgp"
+)
+
+func TestArmor(t *testing.T) {
+	// As armored by ProtonMail's go-crypto.
+	expected := `-----BEGIN PGP SIGNATURE-----
+
+wnUEABMIAB0FAmVT/xAWIQRZ1NxuMutfvehzRtSjBunM7XeDOAAKCRCjBunM7XeD
+OCK+AQDUXynfKOfHF/zQ3juuu4oCBTvpQBMYny33qsDGvCnpqwEA9gS1KjiwR6DG
+vcH0ruKgSm/f+s5Jd7+LAI1olXb34No=
+=l1S3
+-----END PGP SIGNATURE-----
+`
+	sig := primary.SignDetached(message, created.Add(time.Hour))
+	if armored := string(openpgp.Armor(openpgp.BlockSignature, sig)); armored != expected {
+		t.Errorf("Expected\n%s, got\n%s", expected, armored)
+	}
+
+	empty := "-----BEGIN PGP PUBLIC KEY BLOCK-----\n\n\n=twTO\n-----END PGP PUBLIC KEY BLOCK-----\n"
+	if armored := string(openpgp.Armor(openpgp.BlockPublicKey, nil)); armored != empty {
+		t.Errorf("Expected\n%s, got\n%s", empty, armored)
+	}
+}
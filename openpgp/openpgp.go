@@ -0,0 +1,219 @@
+/*
+Package openpgp produces OpenPGP v4 ECDSA signature packets (RFC 4880 and RFC
+6637) using RFC 6979 deterministic signatures: detached signatures over
+binary documents and subkey binding signatures, along with the public key
+packets they refer to. Signatures over the same data with the same creation
+time are identical. The packets are readable by GnuPG and
+github.com/ProtonMail/go-crypto.
+*/
+package openpgp
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/sha1"
+	_ "crypto/sha256" // P-256
+	_ "crypto/sha512" // P-384, P-521
+	"encoding/binary"
+	"errors"
+	"math/big"
+	"time"
+)
+
+// Packet tags, RFC 4880 section 4.3.
+const (
+	TagSignature    = 2
+	TagPublicKey    = 6
+	TagPublicSubkey = 14
+)
+
+// algECDSA is the public-key algorithm id of ECDSA, RFC 6637 section 5.
+const algECDSA = 19
+
+var (
+	// ErrInvalidKey is returned for keys on curves without an OpenPGP
+	// identifier, only P-256, P-384 and P-521 have one.
+	ErrInvalidKey = errors.New("openpgp: invalid key")
+
+	// ErrInvalidPacket is returned for malformed packets.
+	ErrInvalidPacket = errors.New("openpgp: invalid packet")
+)
+
+// curve describes an OpenPGP ECDSA curve, RFC 6637 sections 11 and 12.2.2.
+type curve struct {
+	curve func() elliptic.Curve
+	oid   []byte
+	hash  crypto.Hash
+}
+
+// curves lists the supported curves.
+var curves = []curve{
+	{elliptic.P256, []byte{0x2a, 0x86, 0x48, 0xce, 0x3d, 0x03, 0x01, 0x07}, crypto.SHA256},
+	{elliptic.P384, []byte{0x2b, 0x81, 0x04, 0x00, 0x22}, crypto.SHA384},
+	{elliptic.P521, []byte{0x2b, 0x81, 0x04, 0x00, 0x23}, crypto.SHA512},
+}
+
+// lookup returns the description of the curve c.
+func lookup(c elliptic.Curve) (curve, error) {
+	for _, k := range curves {
+		if k.curve().Params().Name == c.Params().Name {
+			return k, nil
+		}
+	}
+	return curve{}, ErrInvalidKey
+}
+
+// PublicKey is an ECDSA public key with the creation time it's known by in
+// OpenPGP, which is part of its fingerprint.
+type PublicKey struct {
+	Key     *ecdsa.PublicKey
+	Created time.Time
+
+	curve curve
+	body  []byte
+}
+
+// NewPublicKey returns the OpenPGP form of the public key, pub, created at
+// the given time, which is truncated to seconds.
+func NewPublicKey(pub *ecdsa.PublicKey, created time.Time) (*PublicKey, error) {
+	k, err := lookup(pub.Curve)
+	if err != nil {
+		return nil, err
+	}
+
+	// RFC 4880 section 5.5.2 and RFC 6637 section 9.
+	body := []byte{4}
+	body = appendUint32(body, uint32(created.Unix()))
+	body = append(body, algECDSA, byte(len(k.oid)))
+	body = append(body, k.oid...)
+	body = appendMPI(body, new(big.Int).SetBytes(elliptic.Marshal(pub.Curve, pub.X, pub.Y)))
+	return &PublicKey{Key: pub, Created: time.Unix(created.Unix(), 0), curve: k, body: body}, nil
+}
+
+// Fingerprint returns the v4 fingerprint of the key, RFC 4880 section
+// 12.2.
+func (pk *PublicKey) Fingerprint() [20]byte {
+	return sha1.Sum(pk.hashedBody())
+}
+
+// KeyID returns the key id of the key, the low 64 bits of its fingerprint.
+func (pk *PublicKey) KeyID() uint64 {
+	fp := pk.Fingerprint()
+	return binary.BigEndian.Uint64(fp[12:])
+}
+
+// Packet returns the key as a public key packet, the first packet of a
+// transferable public key.
+func (pk *PublicKey) Packet() []byte {
+	return appendPacket(nil, TagPublicKey, pk.body)
+}
+
+// SubkeyPacket returns the key as a public subkey packet, to be followed by
+// its binding signature.
+func (pk *PublicKey) SubkeyPacket() []byte {
+	return appendPacket(nil, TagPublicSubkey, pk.body)
+}
+
+// hashedBody returns the key as it's hashed for fingerprints and key
+// signatures.
+func (pk *PublicKey) hashedBody() []byte {
+	b := []byte{0x99, byte(len(pk.body) >> 8), byte(len(pk.body))}
+	return append(b, pk.body...)
+}
+
+// PrivateKey is an ECDSA private key with its OpenPGP public key.
+type PrivateKey struct {
+	*PublicKey
+	priv *ecdsa.PrivateKey
+}
+
+// NewPrivateKey returns the OpenPGP form of the private key, priv, created
+// at the given time, see NewPublicKey.
+func NewPrivateKey(priv *ecdsa.PrivateKey, created time.Time) (*PrivateKey, error) {
+	pk, err := NewPublicKey(&priv.PublicKey, created)
+	if err != nil {
+		return nil, err
+	}
+	return &PrivateKey{PublicKey: pk, priv: priv}, nil
+}
+
+// appendUint32 appends a big-endian uint32.
+func appendUint32(b []byte, v uint32) []byte {
+	var buf [4]byte
+	binary.BigEndian.PutUint32(buf[:], v)
+	return append(b, buf[:]...)
+}
+
+// appendMPI appends a multiprecision integer, RFC 4880 section 3.2.
+func appendMPI(b []byte, v *big.Int) []byte {
+	n := v.BitLen()
+	return append(append(b, byte(n>>8), byte(n)), v.Bytes()...)
+}
+
+// appendPacket appends a packet with a new format header, RFC 4880 section
+// 4.2.2.
+func appendPacket(b []byte, tag byte, body []byte) []byte {
+	b = append(b, 0xc0|tag)
+	switch n := len(body); {
+	case n < 192:
+		b = append(b, byte(n))
+	case n < 8384:
+		n -= 192
+		b = append(b, byte(n>>8)+192, byte(n))
+	default:
+		b = appendUint32(append(b, 0xff), uint32(n))
+	}
+	return append(b, body...)
+}
+
+// readPacket reads a packet from the beginning of b. Both header formats
+// are accepted, but partial and indeterminate lengths aren't.
+func readPacket(b []byte) (tag byte, body, rest []byte, err error) {
+	if len(b) < 2 || b[0]&0x80 == 0 {
+		return 0, nil, nil, ErrInvalidPacket
+	}
+
+	var n int
+	if b[0]&0x40 != 0 {
+		tag = b[0] & 0x3f
+		switch l := b[1]; {
+		case l < 192:
+			n, b = int(l), b[2:]
+		case l < 224:
+			if len(b) < 3 {
+				return 0, nil, nil, ErrInvalidPacket
+			}
+			n, b = (int(l)-192)<<8+int(b[2])+192, b[3:]
+		case l == 255:
+			if len(b) < 6 {
+				return 0, nil, nil, ErrInvalidPacket
+			}
+			n, b = int(binary.BigEndian.Uint32(b[2:])), b[6:]
+		default:
+			return 0, nil, nil, ErrInvalidPacket
+		}
+	} else {
+		tag = b[0] >> 2 & 0xf
+		switch b[0] & 3 {
+		case 0:
+			n, b = int(b[1]), b[2:]
+		case 1:
+			if len(b) < 3 {
+				return 0, nil, nil, ErrInvalidPacket
+			}
+			n, b = int(binary.BigEndian.Uint16(b[1:])), b[3:]
+		case 2:
+			if len(b) < 5 {
+				return 0, nil, nil, ErrInvalidPacket
+			}
+			n, b = int(binary.BigEndian.Uint32(b[1:])), b[5:]
+		default:
+			return 0, nil, nil, ErrInvalidPacket
+		}
+	}
+	if n < 0 || n > len(b) {
+		return 0, nil, nil, ErrInvalidPacket
+	}
+	return tag, b[:n], b[n:], nil
+}
@@ -0,0 +1,74 @@
+package openpgp_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"encoding/hex"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/nspcc-dev/rfc6979/openpgp"
+)
+
+func fromHex(s string) []byte {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+func newKey(c elliptic.Curve, d string, created time.Time) *openpgp.PrivateKey {
+	priv := &ecdsa.PrivateKey{D: new(big.Int).SetBytes(fromHex(d))}
+	priv.Curve = c
+	priv.X, priv.Y = c.ScalarBaseMult(priv.D.Bytes())
+	k, err := openpgp.NewPrivateKey(priv, created)
+	if err != nil {
+		panic(err)
+	}
+	return k
+}
+
+var created = time.Unix(1700000000, 0)
+
+// The P-256 key of RFC 6979 appendix A.2.5.
+var primary = newKey(elliptic.P256(), "C9AFA9D845BA75166B5C215767B1D6934E50C3DB36E89B127B8A622B120F6721", created)
+
+func TestPublicKey(t *testing.T) {
+	// Fingerprints as computed by ProtonMail's go-crypto.
+	expected := "c652046553f10013082a8648ce3d03010702030460fed4ba255a9d31c961eb74c6356d68c049b8923b61fa6ce669622e60f29fb67903fe1008b8bc99a41ae9e95628bc64f2f1b20c2d7e9f5177a3c294d4462299"
+	if p := hex.EncodeToString(primary.Packet()); p != expected {
+		t.Errorf("Expected %s, got %s", expected, p)
+	}
+	sub := primary.SubkeyPacket()
+	if sub[0] != 0xc0|openpgp.TagPublicSubkey || hex.EncodeToString(sub[1:]) != expected[2:] {
+		t.Errorf("Expected a subkey packet with the same body, got %x", sub)
+	}
+	fp := primary.Fingerprint()
+	if f := hex.EncodeToString(fp[:]); f != "59d4dc6e32eb5fbde87346d4a306e9cced778338" {
+		t.Errorf("Unexpected fingerprint %s", f)
+	}
+	if id := primary.KeyID(); id != 0xa306e9cced778338 {
+		t.Errorf("Unexpected key id %X", id)
+	}
+
+	// The creation time is part of the fingerprint.
+	later := newKey(elliptic.P256(), "C9AFA9D845BA75166B5C215767B1D6934E50C3DB36E89B127B8A622B120F6721", created.Add(time.Second))
+	if later.Fingerprint() == fp {
+		t.Error("Expected the fingerprint to depend on the creation time")
+	}
+
+	for _, c := range []elliptic.Curve{elliptic.P384(), elliptic.P521()} {
+		k := newKey(c, "0102030405", created)
+		p := k.Packet()
+		if size := 2 + 1 + 4 + 1 + 1 + 5 + 2 + 1 + 2*((c.Params().BitSize+7)/8); len(p) != size {
+			t.Errorf("%s: Expected %d bytes, got %d", c.Params().Name, size, len(p))
+		}
+	}
+
+	p224 := elliptic.P224().Params()
+	if _, err := openpgp.NewPublicKey(&ecdsa.PublicKey{Curve: p224, X: p224.Gx, Y: p224.Gy}, created); err != openpgp.ErrInvalidKey {
+		t.Errorf("Expected %v, got %v", openpgp.ErrInvalidKey, err)
+	}
+}
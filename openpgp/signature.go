@@ -0,0 +1,331 @@
+package openpgp
+
+import (
+	"bytes"
+	"crypto"
+	"encoding/binary"
+	"math/big"
+	"time"
+
+	"github.com/nspcc-dev/rfc6979"
+)
+
+// Signature types, RFC 4880 section 5.2.1.
+const (
+	SigBinary            = 0x00
+	SigSubkeyBinding     = 0x18
+	SigPrimaryKeyBinding = 0x19
+)
+
+// Key flags, RFC 4880 section 5.2.3.21.
+const (
+	FlagCertify      = 0x01
+	FlagSign         = 0x02
+	FlagAuthenticate = 0x20
+)
+
+// Signature subpacket types, RFC 4880 section 5.2.3.1 and RFC 9580 section
+// 5.2.3.35.
+const (
+	subpacketCreationTime      = 2
+	subpacketIssuer            = 16
+	subpacketKeyFlags          = 27
+	subpacketEmbedded          = 32
+	subpacketIssuerFingerprint = 33
+)
+
+// hashIDs are the OpenPGP identifiers of the hashes, RFC 4880 section 9.4.
+var hashIDs = map[crypto.Hash]byte{
+	crypto.SHA256: 8,
+	crypto.SHA384: 9,
+	crypto.SHA512: 10,
+	crypto.SHA224: 11,
+}
+
+// SignDetached returns a signature packet over a binary document, the
+// message, created at the given time. The message is hashed with the hash
+// of the key's curve: SHA-256 for P-256, SHA-384 for P-384 and SHA-512 for
+// P-521. The signature holds the issuer fingerprint in its hashed area and
+// the issuer key id in the unhashed one.
+func (k *PrivateKey) SignDetached(message []byte, created time.Time) []byte {
+	return appendPacket(nil, TagSignature, k.sign(SigBinary, message, created, nil))
+}
+
+// SignSubkeyBinding returns a subkey binding signature packet binding the
+// subkey to the primary key, k, with the key flags, created at the given
+// time. A subkey allowed to sign, i.e. with FlagSign, also makes a primary
+// key binding signature, embedded into the hashed area, as RFC 4880 section
+// 11.1 requires. The transferable key is the primary key packet, its user
+// ids with their certifications, then subkey.SubkeyPacket() followed by
+// the binding signature.
+func (k *PrivateKey) SignSubkeyBinding(subkey *PrivateKey, flags byte, created time.Time) []byte {
+	data := append(k.hashedBody(), subkey.hashedBody()...)
+	sub := appendSubpacket(nil, subpacketKeyFlags, []byte{flags})
+	if flags&FlagSign != 0 {
+		sub = appendSubpacket(sub, subpacketEmbedded, subkey.sign(SigPrimaryKeyBinding, data, created, nil))
+	}
+	return appendPacket(nil, TagSignature, k.sign(SigSubkeyBinding, data, created, sub))
+}
+
+// sign returns the body of a signature packet of the type over the data,
+// with the creation time, the issuer fingerprint and the extra hashed
+// subpackets.
+func (k *PrivateKey) sign(sigType byte, data []byte, created time.Time, extra []byte) []byte {
+	fp := k.Fingerprint()
+	hashed := appendSubpacket(nil, subpacketCreationTime, appendUint32(nil, uint32(created.Unix())))
+	hashed = append(hashed, extra...)
+	hashed = appendSubpacket(hashed, subpacketIssuerFingerprint, append([]byte{4}, fp[:]...))
+	var unhashed [8]byte
+	binary.BigEndian.PutUint64(unhashed[:], k.KeyID())
+
+	h := k.curve.hash
+	body := []byte{4, sigType, algECDSA, hashIDs[h], byte(len(hashed) >> 8), byte(len(hashed))}
+	body = append(body, hashed...)
+	digest := digest(h, data, body)
+	r, s := rfc6979.SignECDSA(k.priv, digest, h.New)
+
+	unhashedArea := appendSubpacket(nil, subpacketIssuer, unhashed[:])
+	body = append(body, byte(len(unhashedArea)>>8), byte(len(unhashedArea)))
+	body = append(body, unhashedArea...)
+	body = append(body, digest[:2]...)
+	return appendMPI(appendMPI(body, r), s)
+}
+
+// VerifyDetached verifies a signature packet over a binary document, the
+// message, made with the key. Signatures naming another issuer or not
+// matching the message are rejected with rfc6979.ErrInvalidSignature,
+// malformed ones and ones of other types with ErrInvalidPacket.
+func (pk *PublicKey) VerifyDetached(message, packet []byte) error {
+	sig, err := readSignature(packet)
+	if err != nil {
+		return err
+	}
+	if sig.sigType != SigBinary {
+		return ErrInvalidPacket
+	}
+	return pk.verify(sig, message)
+}
+
+// VerifySubkeyBinding verifies a subkey binding signature made with the
+// primary key, pk, for the subkey, including the embedded primary key
+// binding signature if the subkey is allowed to sign.
+func (pk *PublicKey) VerifySubkeyBinding(subkey *PublicKey, packet []byte) error {
+	sig, err := readSignature(packet)
+	if err != nil {
+		return err
+	}
+	if sig.sigType != SigSubkeyBinding {
+		return ErrInvalidPacket
+	}
+	data := append(pk.hashedBody(), subkey.hashedBody()...)
+	if err := pk.verify(sig, data); err != nil {
+		return err
+	}
+	if sig.flags&FlagSign == 0 {
+		return nil
+	}
+
+	if sig.embedded == nil {
+		return ErrInvalidPacket
+	}
+	back, err := parseSignature(sig.embedded)
+	if err != nil {
+		return err
+	}
+	if back.sigType != SigPrimaryKeyBinding {
+		return ErrInvalidPacket
+	}
+	return subkey.verify(back, data)
+}
+
+// verify verifies the signature over the data with the key.
+func (pk *PublicKey) verify(sig *signature, data []byte) error {
+	fp := pk.Fingerprint()
+	if (sig.issuerFingerprint != nil && !bytes.Equal(sig.issuerFingerprint, fp[:])) ||
+		(sig.hasIssuer && sig.issuer != pk.KeyID()) {
+		return rfc6979.ErrInvalidSignature
+	}
+	digest := digest(sig.hash, data, sig.hashed)
+	if !bytes.Equal(digest[:2], sig.left16) || !rfc6979.VerifyECDSA(pk.Key, digest, sig.r, sig.s) {
+		return rfc6979.ErrInvalidSignature
+	}
+	return nil
+}
+
+// signature is a parsed v4 ECDSA signature packet.
+type signature struct {
+	sigType byte
+	hash    crypto.Hash
+
+	// hashed is the hashed part of the packet, from the version to the
+	// end of the hashed subpackets.
+	hashed []byte
+	left16 []byte
+	r, s   *big.Int
+
+	issuerFingerprint []byte
+	issuer            uint64
+	hasIssuer         bool
+	flags             byte
+	embedded          []byte
+}
+
+// readSignature reads a packet holding a signature.
+func readSignature(packet []byte) (*signature, error) {
+	tag, body, rest, err := readPacket(packet)
+	if err != nil || tag != TagSignature || len(rest) != 0 {
+		return nil, ErrInvalidPacket
+	}
+	return parseSignature(body)
+}
+
+// parseSignature parses the body of a signature packet.
+func parseSignature(body []byte) (*signature, error) {
+	if len(body) < 6 || body[0] != 4 || body[2] != algECDSA {
+		return nil, ErrInvalidPacket
+	}
+	sig := &signature{sigType: body[1]}
+	for h, id := range hashIDs {
+		if id == body[3] {
+			sig.hash = h
+		}
+	}
+	if sig.hash == 0 {
+		return nil, ErrInvalidPacket
+	}
+
+	n := 6 + int(binary.BigEndian.Uint16(body[4:]))
+	if len(body) < n+2 {
+		return nil, ErrInvalidPacket
+	}
+	sig.hashed = body[:n]
+	if err := sig.parseSubpackets(body[6:n], true); err != nil {
+		return nil, err
+	}
+	m := n + 2 + int(binary.BigEndian.Uint16(body[n:]))
+	if len(body) < m+2 {
+		return nil, ErrInvalidPacket
+	}
+	if err := sig.parseSubpackets(body[n+2:m], false); err != nil {
+		return nil, err
+	}
+
+	sig.left16 = body[m : m+2]
+	rest := body[m+2:]
+	var ok bool
+	if sig.r, rest, ok = readMPI(rest); !ok {
+		return nil, ErrInvalidPacket
+	}
+	if sig.s, rest, ok = readMPI(rest); !ok || len(rest) != 0 {
+		return nil, ErrInvalidPacket
+	}
+	return sig, nil
+}
+
+// parseSubpackets parses a subpacket area. The issuer is taken from either
+// area, other subpackets only from the hashed one; unknown subpackets are
+// skipped unless they're critical.
+func (sig *signature) parseSubpackets(b []byte, hashed bool) error {
+	for len(b) > 0 {
+		var n int
+		switch {
+		case b[0] < 192:
+			n, b = int(b[0]), b[1:]
+		case b[0] < 255:
+			if len(b) < 2 {
+				return ErrInvalidPacket
+			}
+			n, b = (int(b[0])-192)<<8+int(b[1])+192, b[2:]
+		default:
+			if len(b) < 5 {
+				return ErrInvalidPacket
+			}
+			n, b = int(binary.BigEndian.Uint32(b[1:])), b[5:]
+		}
+		if n < 1 || n > len(b) {
+			return ErrInvalidPacket
+		}
+		typ, data := b[0], b[1:n]
+		b = b[n:]
+
+		critical := typ&0x80 != 0
+		switch typ &^ 0x80 {
+		case subpacketIssuer:
+			if len(data) != 8 {
+				return ErrInvalidPacket
+			}
+			sig.issuer, sig.hasIssuer = binary.BigEndian.Uint64(data), true
+			continue
+		case subpacketIssuerFingerprint:
+			if len(data) != 21 || data[0] != 4 {
+				return ErrInvalidPacket
+			}
+			sig.issuerFingerprint = data[1:]
+			continue
+		}
+		if !hashed {
+			if critical {
+				return ErrInvalidPacket
+			}
+			continue
+		}
+		switch typ &^ 0x80 {
+		case subpacketCreationTime:
+			if len(data) != 4 {
+				return ErrInvalidPacket
+			}
+		case subpacketKeyFlags:
+			if len(data) > 0 {
+				sig.flags = data[0]
+			}
+		case subpacketEmbedded:
+			sig.embedded = data
+		default:
+			if critical {
+				return ErrInvalidPacket
+			}
+		}
+	}
+	return nil
+}
+
+// appendSubpacket appends a signature subpacket, RFC 4880 section 5.2.3.1.
+func appendSubpacket(b []byte, typ byte, data []byte) []byte {
+	switch n := len(data) + 1; {
+	case n < 192:
+		b = append(b, byte(n))
+	case n < 16320:
+		n -= 192
+		b = append(b, byte(n>>8)+192, byte(n))
+	default:
+		b = appendUint32(append(b, 0xff), uint32(n))
+	}
+	return append(append(b, typ), data...)
+}
+
+// readMPI reads a multiprecision integer in its minimal encoding.
+func readMPI(b []byte) (v *big.Int, rest []byte, ok bool) {
+	if len(b) < 2 {
+		return nil, nil, false
+	}
+	bits := int(binary.BigEndian.Uint16(b))
+	n := (bits + 7) / 8
+	if len(b) < 2+n {
+		return nil, nil, false
+	}
+	v = new(big.Int).SetBytes(b[2 : 2+n])
+	if v.BitLen() != bits {
+		return nil, nil, false
+	}
+	return v, b[2+n:], true
+}
+
+// digest hashes the signed data followed by the hashed part of the
+// signature and the v4 trailer, RFC 4880 section 5.2.4.
+func digest(h crypto.Hash, data, hashed []byte) []byte {
+	d := h.New()
+	d.Write(data)
+	d.Write(hashed)
+	d.Write(appendUint32([]byte{4, 0xff}, uint32(len(hashed))))
+	return d.Sum(nil)
+}
@@ -0,0 +1,103 @@
+package openpgp_test
+
+import (
+	"bytes"
+	"crypto/elliptic"
+	"encoding/hex"
+	"testing"
+	"time"
+
+	"github.com/nspcc-dev/rfc6979"
+	"github.com/nspcc-dev/rfc6979/openpgp"
+)
+
+var message = []byte("hello, world\n")
+
+func TestSignDetached(t *testing.T) {
+	// Verified with ProtonMail's go-crypto.
+	expected := "c27504001308001d05026553ff1016210459d4dc6e32eb5fbde87346d4a306e9cced778338000a0910a306e9cced77833822be0100d45f29df28e7c717fcd0de3baebb8a02053be94013189f2df7aac0c6bc29e9ab0100f604b52a38b047a0c6bdc1f4aee2a04a6fdfface4977bf8b008d689576f7e0da"
+	sig := primary.SignDetached(message, created.Add(time.Hour))
+	if s := hex.EncodeToString(sig); s != expected {
+		t.Errorf("Expected %s, got %s", expected, s)
+	}
+	if err := primary.VerifyDetached(message, sig); err != nil {
+		t.Fatal(err)
+	}
+	if err := primary.VerifyDetached([]byte("hello, world"), sig); err != rfc6979.ErrInvalidSignature {
+		t.Errorf("Expected %v, got %v", rfc6979.ErrInvalidSignature, err)
+	}
+	other := newKey(elliptic.P256(), "0102030405", created)
+	if err := other.VerifyDetached(message, sig); err != rfc6979.ErrInvalidSignature {
+		t.Errorf("Expected %v, got %v", rfc6979.ErrInvalidSignature, err)
+	}
+
+	for _, c := range []elliptic.Curve{elliptic.P384(), elliptic.P521()} {
+		k := newKey(c, "0102030405", created)
+		sig := k.SignDetached(message, created)
+		if !bytes.Equal(sig, k.SignDetached(message, created)) {
+			t.Errorf("%s: Expected a deterministic signature", c.Params().Name)
+		}
+		if err := k.VerifyDetached(message, sig); err != nil {
+			t.Errorf("%s: %v", c.Params().Name, err)
+		}
+	}
+}
+
+func TestVerifyDetached(t *testing.T) {
+	// Made by ProtonMail's go-crypto, with a critical creation time and a
+	// salt notation.
+	sig := fromHex("c2ab04001308005d05826553ff100910a306e9cced778338351400000000001c001073616c74406e6f746174696f6e732e6f70656e7067706a732e6f72677c07eb64d8641e095cccaa817e0b5ff316210459d4dc6e32eb5fbde87346d4a306e9cced7783380000c23f01009eaa5fcb79cce176f7278c0d25d03997ff9f3f4f7f13f3474373132538cff1d900ff4c624042bb145e5cb8c17cb817fb34da5df71c64c75fc1632113e995eb00f80a")
+	if err := primary.VerifyDetached(message, sig); err != nil {
+		t.Fatal(err)
+	}
+
+	// Old format header.
+	own := primary.SignDetached(message, created)
+	old := append([]byte{0x88, own[1]}, own[2:]...)
+	if err := primary.VerifyDetached(message, old); err != nil {
+		t.Errorf("Old format: %v", err)
+	}
+
+	binding := primary.SignSubkeyBinding(primary, 0, created)
+	for name, bad := range map[string][]byte{
+		"empty":      nil,
+		"truncated":  own[:len(own)-1],
+		"trailing":   append(append([]byte{}, own...), 0),
+		"other tag":  append([]byte{0xc0 | openpgp.TagPublicKey}, own[1:]...),
+		"version 3":  append(append([]byte{}, own[:2]...), append([]byte{3}, own[3:]...)...),
+		"other type": binding,
+		// The critical bit set on the salt notation.
+		"critical": bytes.Replace(sig, fromHex("3514"), fromHex("3594"), 1),
+	} {
+		if err := primary.VerifyDetached(message, bad); err != openpgp.ErrInvalidPacket {
+			t.Errorf("%s: Expected %v, got %v", name, openpgp.ErrInvalidPacket, err)
+		}
+	}
+}
+
+func TestSignSubkeyBinding(t *testing.T) {
+	sub := newKey(elliptic.P256(), "0102030405", created)
+	other := newKey(elliptic.P256(), "0607080910", created)
+
+	for _, flags := range []byte{openpgp.FlagSign, openpgp.FlagAuthenticate, openpgp.FlagSign | openpgp.FlagAuthenticate} {
+		sig := primary.SignSubkeyBinding(sub, flags, created)
+		if !bytes.Equal(sig, primary.SignSubkeyBinding(sub, flags, created)) {
+			t.Errorf("%#x: Expected a deterministic signature", flags)
+		}
+		if err := primary.VerifySubkeyBinding(sub.PublicKey, sig); err != nil {
+			t.Errorf("%#x: %v", flags, err)
+		}
+		if err := primary.VerifySubkeyBinding(other.PublicKey, sig); err != rfc6979.ErrInvalidSignature {
+			t.Errorf("%#x: Expected %v, got %v", flags, rfc6979.ErrInvalidSignature, err)
+		}
+		if err := sub.VerifySubkeyBinding(primary.PublicKey, sig); err != rfc6979.ErrInvalidSignature {
+			t.Errorf("%#x: Expected %v, got %v", flags, rfc6979.ErrInvalidSignature, err)
+		}
+	}
+
+	// Binding signatures aren't signatures over documents.
+	good := primary.SignSubkeyBinding(sub, openpgp.FlagSign, created)
+	if err := primary.VerifyDetached(message, good); err != openpgp.ErrInvalidPacket {
+		t.Errorf("Expected %v, got %v", openpgp.ErrInvalidPacket, err)
+	}
+}
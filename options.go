@@ -0,0 +1,85 @@
+package rfc6979
+
+import "hash"
+
+// HMACFunc constructs an HMAC for the hash function h keyed with key. Its
+// signature matches crypto/hmac.New. Like crypto/hmac.New, it must not retain
+// key, which is reused by the caller.
+type HMACFunc func(h func() hash.Hash, key []byte) hash.Hash
+
+// TruncationStrategy defines how a hash longer than the group order is
+// converted to an integer.
+type TruncationStrategy int
+
+const (
+	// TruncateBits keeps the leftmost bits of the hash as many as the bit
+	// length of the group order, as specified by RFC 6979 and FIPS 186-4.
+	// It is the default.
+	TruncateBits TruncationStrategy = iota
+
+	// ReduceMod reduces the whole hash modulo the group order. This is NOT
+	// compliant with RFC 6979 or FIPS 186-4 and exists for interoperability
+	// with nonstandard ECDSA implementations. The nonce is derived as
+	// specified by RFC 6979 either way.
+	ReduceMod
+
+	// NoTruncate asserts that the hash is not longer than the group order,
+	// so none of its bits are discarded, e.g. SHA-256 with P-256 passes, but
+	// SHA-256 with P-224 fails with ErrTruncation. SignECDSAChecked returns
+	// that error, functions unable to report errors panic with it instead.
+	NoTruncate
+)
+
+// Options tweaks the signing procedure. A nil *Options, as well as the zero
+// value, selects the behaviour described in RFC 6979.
+type Options struct {
+	// Truncation selects how the hash is converted to an integer, see
+	// TruncationStrategy.
+	Truncation TruncationStrategy
+
+	// LowS makes the signer replace s with N - s whenever s is greater than
+	// N/2, as required by Bitcoin and Ethereum to prevent malleability. The
+	// result is still a valid ECDSA signature for the same hash.
+	LowS bool
+
+	// HMAC replaces crypto/hmac in the nonce generator, e.g. to route it
+	// through a validated cryptographic module. It must compute a standard
+	// HMAC for the output to be RFC 6979 compliant; nil means crypto/hmac.
+	HMAC HMACFunc
+
+	// KeyOctets overrides the length of the private key encoding,
+	// int2octets(x), in the nonce generator seed, which is the byte length
+	// of the group order by default. This is an advanced debugging aid: any
+	// other value breaks RFC 6979 compliance, but reproduces implementations
+	// that encode the key incorrectly (e.g. without leading zeros), which
+	// helps to diagnose why their signatures differ.
+	KeyOctets int
+
+	// AdditionalInput is passed as additional_input to every Generate call
+	// of the HMAC_DRBG (NIST SP 800-90A section 10.1.2.5), which RFC 6979
+	// doesn't use. Setting it makes signatures deviate from RFC 6979 and is
+	// only meant for experiments with SP 800-90A variants.
+	AdditionalInput []byte
+
+	// ExtraData is appended to the nonce generator seed, making it
+	// int2octets(x) || bits2octets(h1) || k', as allowed by RFC 6979
+	// section 3.6. Signatures remain valid, but only match the RFC 6979
+	// ones when it's empty.
+	ExtraData []byte
+
+	// LengthPrefix makes the functions hashing messages themselves, like
+	// SignECDSAPartsWithOptions, write the length of every part as an 8-byte
+	// big-endian unsigned integer before the part itself, so that e.g.
+	// ("ab", "c") and ("a", "bc") hash differently. Verifiers must hash the
+	// same way. Functions taking a ready hash ignore it.
+	LengthPrefix bool
+
+	// noise, if set, switches the nonce generator seed to the layout of
+	// the "deterministic signatures with noise" construction, see
+	// SignECDSANoisy.
+	noise []byte
+
+	// trace, if set, receives every candidate T produced in step H2, see
+	// SignECDSATrace.
+	trace func(t []byte)
+}
@@ -0,0 +1,46 @@
+package rfc6979
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/sha256"
+	"math/big"
+	"testing"
+)
+
+// Reproduces a common bug of encoding the private key with x.Bytes(),
+// without padding it to the byte length of the order.
+func TestOptionsKeyOctets(t *testing.T) {
+	c := elliptic.P256()
+	N := c.Params().N
+	d := big.NewInt(0x1337)
+	x, y := c.ScalarBaseMult(d.Bytes())
+	priv := &ecdsa.PrivateKey{PublicKey: ecdsa.PublicKey{Curve: c, X: x, Y: y}, D: d}
+
+	digest := sha256.Sum256([]byte("sample"))
+	rolen := (N.BitLen() + 7) >> 3
+
+	// Buggy seed: unpadded key followed by the correct bits2octets(h1).
+	seed := append(d.Bytes(), bits2octets(digest[:], N, N.BitLen(), rolen)...)
+	var buggyK *big.Int
+	nextSecret(newDRBG(sha256.New, nil, seed), N, func(k *big.Int) bool {
+		buggyK = k
+		return true
+	})
+	buggyR, _ := c.ScalarBaseMult(buggyK.Bytes())
+	buggyR.Mod(buggyR, N)
+
+	r, s := SignECDSAWithOptions(priv, digest[:], sha256.New, &Options{KeyOctets: len(d.Bytes())})
+	if r.Cmp(buggyR) != 0 {
+		t.Errorf("Expected R of %X, got %X", buggyR, r)
+	}
+	if !ecdsa.Verify(&priv.PublicKey, digest[:], r, s) {
+		t.Errorf("Invalid signature")
+	}
+
+	defaultR, _ := SignECDSAWithOptions(priv, digest[:], sha256.New, &Options{KeyOctets: rolen})
+	expectedR, _ := SignECDSA(priv, digest[:], sha256.New)
+	if defaultR.Cmp(expectedR) != 0 || r.Cmp(expectedR) == 0 {
+		t.Errorf("Expected only the incorrect key length to change the nonce")
+	}
+}
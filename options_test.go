@@ -0,0 +1,178 @@
+package rfc6979_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/sha512"
+	"hash"
+	"math/big"
+	"testing"
+
+	"github.com/nspcc-dev/rfc6979"
+)
+
+func TestOptionsDefault(t *testing.T) {
+	for _, f := range fixtures {
+		h := f.alg()
+		h.Write([]byte(f.message))
+		digest := h.Sum(nil)
+
+		r, s := rfc6979.SignECDSAWithOptions(f.key.key, digest, f.alg, &rfc6979.Options{})
+		if r.Cmp(ecdsaLoadInt(f.r)) != 0 || s.Cmp(ecdsaLoadInt(f.s)) != 0 {
+			t.Errorf("%s: Expected default options to match the RFC vector", f.name)
+		}
+	}
+}
+
+func TestOptionsReduceMod(t *testing.T) {
+	key := p256.key
+	N := key.Curve.Params().N
+
+	h := sha512.New()
+	h.Write([]byte("sample"))
+	digest := h.Sum(nil)
+
+	r1, s1 := rfc6979.SignECDSA(key, digest, sha512.New)
+	r2, s2 := rfc6979.SignECDSAWithOptions(key, digest, sha512.New, &rfc6979.Options{Truncation: rfc6979.ReduceMod})
+
+	if r1.Cmp(r2) != 0 {
+		t.Errorf("Expected the nonce not to depend on the reduction method")
+	}
+
+	if s1.Cmp(s2) == 0 {
+		t.Errorf("Expected truncation and reduction modulo N to diverge")
+	}
+
+	// Reduced signature is a regular one over the reduced hash value.
+	e := new(big.Int).SetBytes(digest)
+	e.Mod(e, N)
+	reduced := make([]byte, (N.BitLen()+7)/8)
+	e.FillBytes(reduced)
+
+	if !ecdsa.Verify(&key.PublicKey, reduced, r2, s2) {
+		t.Errorf("Invalid signature over the reduced digest")
+	}
+
+	if ecdsa.Verify(&key.PublicKey, digest, r2, s2) {
+		t.Errorf("Expected reduced signature not to verify with truncation")
+	}
+}
+
+func TestOptionsHMAC(t *testing.T) {
+	for _, f := range fixtures {
+		h := f.alg()
+		h.Write([]byte(f.message))
+		digest := h.Sum(nil)
+
+		calls := 0
+		opts := &rfc6979.Options{
+			HMAC: func(h func() hash.Hash, key []byte) hash.Hash {
+				calls++
+				return hmac.New(h, key)
+			},
+		}
+
+		r, s := rfc6979.SignECDSAWithOptions(f.key.key, digest, f.alg, opts)
+		if r.Cmp(ecdsaLoadInt(f.r)) != 0 || s.Cmp(ecdsaLoadInt(f.s)) != 0 {
+			t.Errorf("%s: Expected injected HMAC to match the RFC vector", f.name)
+		}
+
+		if calls == 0 {
+			t.Errorf("%s: Expected injected HMAC to be used, got %d calls", f.name, calls)
+		}
+	}
+}
+
+func TestOptionsNoTruncate(t *testing.T) {
+	opts := &rfc6979.Options{Truncation: rfc6979.NoTruncate}
+
+	tests := []struct {
+		key *ecdsaKey
+		alg func() hash.Hash
+		err error
+	}{
+		{p256, sha256.New, nil},
+		{p521, sha512.New, nil},
+		{p384, sha256.New, nil},
+		{p224, sha256.New, rfc6979.ErrTruncation},
+		{p256, sha512.New, rfc6979.ErrTruncation},
+	}
+
+	for _, tc := range tests {
+		h := tc.alg()
+		h.Write([]byte("sample"))
+		digest := h.Sum(nil)
+
+		r, s, err := rfc6979.SignECDSAChecked(tc.key.key, digest, tc.alg, opts)
+		if err != tc.err {
+			t.Errorf("%s/%d: Expected %v, got %v", tc.key.key.Curve.Params().Name, h.Size()*8, tc.err, err)
+			continue
+		}
+
+		if err == nil && !ecdsa.Verify(&tc.key.key.PublicKey, digest, r, s) {
+			t.Errorf("%s/%d: Invalid signature", tc.key.key.Curve.Params().Name, h.Size()*8)
+		}
+
+		// Functions unable to return the error must not ignore the assertion.
+		func() {
+			defer func() {
+				if p := recover(); p != tc.err {
+					t.Errorf("%s/%d: Expected panic with %v, got %v", tc.key.key.Curve.Params().Name, h.Size()*8, tc.err, p)
+				}
+			}()
+			rfc6979.SignECDSAWithOptions(tc.key.key, digest, tc.alg, opts)
+		}()
+	}
+}
+
+func TestOptionsAdditionalInput(t *testing.T) {
+	for _, f := range fixtures {
+		h := f.alg()
+		h.Write([]byte(f.message))
+		digest := h.Sum(nil)
+
+		r, s := rfc6979.SignECDSAWithOptions(f.key.key, digest, f.alg, &rfc6979.Options{AdditionalInput: []byte{}})
+		if r.Cmp(ecdsaLoadInt(f.r)) != 0 || s.Cmp(ecdsaLoadInt(f.s)) != 0 {
+			t.Errorf("%s: Expected empty additional input to match the RFC vector", f.name)
+		}
+
+		r, s = rfc6979.SignECDSAWithOptions(f.key.key, digest, f.alg, &rfc6979.Options{AdditionalInput: []byte("extra")})
+		if r.Cmp(ecdsaLoadInt(f.r)) == 0 {
+			t.Errorf("%s: Expected additional input to change the nonce", f.name)
+		}
+		if !ecdsa.Verify(&f.key.key.PublicKey, digest, r, s) {
+			t.Errorf("%s: Invalid signature", f.name)
+		}
+	}
+}
+
+func TestOptionsTruncation(t *testing.T) {
+	for _, f := range fixtures {
+		h := f.alg()
+		h.Write([]byte(f.message))
+		digest := h.Sum(nil)
+		tooLong := len(digest)*8 > f.key.key.Curve.Params().N.BitLen()
+
+		for _, strategy := range []rfc6979.TruncationStrategy{rfc6979.TruncateBits, rfc6979.ReduceMod, rfc6979.NoTruncate} {
+			r, s, err := rfc6979.SignECDSAChecked(f.key.key, digest, f.alg, &rfc6979.Options{Truncation: strategy})
+
+			if strategy == rfc6979.NoTruncate && tooLong {
+				if err != rfc6979.ErrTruncation {
+					t.Errorf("%s/%d: Expected %v, got %v", f.name, strategy, rfc6979.ErrTruncation, err)
+				}
+				continue
+			}
+			if err != nil {
+				t.Errorf("%s/%d: %v", f.name, strategy, err)
+				continue
+			}
+
+			// Strategies only differ for hashes longer than the order.
+			matches := r.Cmp(ecdsaLoadInt(f.r)) == 0 && s.Cmp(ecdsaLoadInt(f.s)) == 0
+			if expected := strategy != rfc6979.ReduceMod || !tooLong; matches != expected {
+				t.Errorf("%s/%d: Expected RFC vector match to be %t", f.name, strategy, expected)
+			}
+		}
+	}
+}
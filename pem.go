@@ -0,0 +1,116 @@
+package rfc6979
+
+import (
+	"crypto"
+	"encoding/pem"
+	"errors"
+)
+
+// PEMType is the type of PEM blocks holding signatures.
+const PEMType = "ECDSA SIGNATURE"
+
+// PEM headers describing the signature.
+const (
+	PEMHeaderCurve = "Curve"
+	PEMHeaderHash  = "Hash"
+	PEMHeaderKeyID = "Key-Id"
+)
+
+// ErrInvalidPEM is returned when a PEM block can't be decoded as a signature.
+var ErrInvalidPEM = errors.New("rfc6979: invalid PEM signature")
+
+// PEMSignature is a detached signature stored in a PEM block along with
+// optional metadata: the curve of the signature, the hash function of the
+// signed digest and an identifier of the signing key. Zero values are
+// omitted.
+type PEMSignature struct {
+	Signature
+	Hash  crypto.Hash
+	KeyID string
+}
+
+// EncodePEM returns the signature as a PEM block of PEMType, the body being
+// the DER encoding of (R, S). Curve, Hash and KeyID are written as the
+// headers, e.g.
+//
+//	-----BEGIN ECDSA SIGNATURE-----
+//	Curve: P-256
+//	Hash: SHA-256
+//	Key-Id: release-2024
+//
+//	MEYCIQDv1IsqrLao/RFA3ZzUXoHWnSyHe1aq+ZHDTQ6oTq83FgIhAPfLHJQtZXxB
+//	1DbHobbin2Xz6QDbua/0Bk3Eqy+EOs2o
+//	-----END ECDSA SIGNATURE-----
+//
+// The curve must be one of those Signature can be unmarshaled for.
+func EncodePEM(sig *PEMSignature) ([]byte, error) {
+	if sig.R == nil || sig.S == nil {
+		return nil, ErrInvalidPEM
+	}
+	der, err := MarshalSignatureDER(sig.R, sig.S)
+	if err != nil {
+		return nil, err
+	}
+
+	headers := make(map[string]string)
+	if sig.Curve != nil {
+		name := sig.Curve.Params().Name
+		if curveByName(name) == nil {
+			return nil, ErrInvalidPEM
+		}
+		headers[PEMHeaderCurve] = name
+	}
+	if sig.Hash != 0 {
+		if !sig.Hash.Available() {
+			return nil, ErrInvalidPEM
+		}
+		headers[PEMHeaderHash] = sig.Hash.String()
+	}
+	if sig.KeyID != "" {
+		headers[PEMHeaderKeyID] = sig.KeyID
+	}
+	out := pem.EncodeToMemory(&pem.Block{Type: PEMType, Headers: headers, Bytes: der})
+	if out == nil {
+		return nil, ErrInvalidPEM
+	}
+	return out, nil
+}
+
+// DecodePEM finds the next PEM block in data, which must be of PEMType, and
+// decodes the signature produced by EncodePEM from it, returning the rest of
+// the data like pem.Decode does. The body must be strict DER, see
+// ParseDERStrict. Unknown headers are ignored, known ones must be valid.
+func DecodePEM(data []byte) (sig *PEMSignature, rest []byte, err error) {
+	block, rest := pem.Decode(data)
+	if block == nil || block.Type != PEMType {
+		return nil, data, ErrInvalidPEM
+	}
+
+	sig = new(PEMSignature)
+	if sig.R, sig.S, err = ParseDERStrict(block.Bytes); err != nil {
+		return nil, data, err
+	}
+	if name, ok := block.Headers[PEMHeaderCurve]; ok {
+		if sig.Curve = curveByName(name); sig.Curve == nil {
+			return nil, data, ErrInvalidPEM
+		}
+	}
+	if name, ok := block.Headers[PEMHeaderHash]; ok {
+		if sig.Hash = hashByName(name); sig.Hash == 0 {
+			return nil, data, ErrInvalidPEM
+		}
+	}
+	sig.KeyID = block.Headers[PEMHeaderKeyID]
+	return sig, rest, nil
+}
+
+// hashByName returns the available hash function with the given name, as
+// returned by crypto.Hash.String, or 0 if there's none.
+func hashByName(name string) crypto.Hash {
+	for h := crypto.MD4; h <= crypto.BLAKE2b_512; h++ {
+		if h.Available() && h.String() == name {
+			return h
+		}
+	}
+	return 0
+}
@@ -0,0 +1,77 @@
+package rfc6979_test
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/sha256"
+	"testing"
+
+	"github.com/nspcc-dev/rfc6979"
+)
+
+func TestPEM(t *testing.T) {
+	digest := sha256.Sum256([]byte("sample"))
+	r, s := rfc6979.SignECDSA(p256.key, digest[:], sha256.New)
+	sig := &rfc6979.PEMSignature{
+		Signature: rfc6979.Signature{R: r, S: s, Curve: p256.key.Curve},
+		Hash:      crypto.SHA256,
+		KeyID:     "release-2024",
+	}
+
+	data, err := rfc6979.EncodePEM(sig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `-----BEGIN ECDSA SIGNATURE-----
+Curve: P-256
+Hash: SHA-256
+Key-Id: release-2024
+
+MEYCIQDv1IsqrLao/RFA3ZzUXoHWnSyHe1aq+ZHDTQ6oTq83FgIhAPfLHJQtZXxB
+1DbHobbin2Xz6QDbua/0Bk3Eqy+EOs2o
+-----END ECDSA SIGNATURE-----
+`
+	if string(data) != expected {
+		t.Errorf("Expected\n%s\ngot\n%s", expected, data)
+	}
+
+	got, rest, err := rfc6979.DecodePEM(append(data, "trailer"...))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.R.Cmp(r) != 0 || got.S.Cmp(s) != 0 || got.Curve != p256.key.Curve || got.Hash != crypto.SHA256 || got.KeyID != "release-2024" {
+		t.Errorf("Expected round trip, got %+v", got)
+	}
+	if string(rest) != "trailer" {
+		t.Errorf("Unexpected rest %q", rest)
+	}
+
+	// Headers are optional.
+	data, err = rfc6979.EncodePEM(&rfc6979.PEMSignature{Signature: rfc6979.Signature{R: r, S: s}})
+	if err != nil || bytes.Contains(data, []byte(":")) {
+		t.Fatalf("Unexpected %s, %v", data, err)
+	}
+	got, _, err = rfc6979.DecodePEM(data)
+	if err != nil || got.R.Cmp(r) != 0 || got.Curve != nil || got.Hash != 0 || got.KeyID != "" {
+		t.Errorf("Expected round trip, got %+v, %v", got, err)
+	}
+}
+
+func TestDecodePEMInvalid(t *testing.T) {
+	body := "\nMEYCIQDv1IsqrLao/RFA3ZzUXoHWnSyHe1aq+ZHDTQ6oTq83FgIhAPfLHJQtZXxB\n1DbHobbin2Xz6QDbua/0Bk3Eqy+EOs2o\n"
+	block := func(typ, headers, body string) string {
+		return "-----BEGIN " + typ + "-----\n" + headers + body + "-----END " + typ + "-----\n"
+	}
+
+	for name, data := range map[string]string{
+		"no block":      "MEYCIQDv",
+		"wrong type":    block("EC PRIVATE KEY", "", body),
+		"unknown curve": block(rfc6979.PEMType, "Curve: P-192\n", body),
+		"unknown hash":  block(rfc6979.PEMType, "Hash: SHA-999\n", body),
+		"BER body":      block(rfc6979.PEMType, "", "\nMIEGAgEBAgEH\n"),
+	} {
+		if _, rest, err := rfc6979.DecodePEM([]byte(data)); err == nil || string(rest) != data {
+			t.Errorf("%s: Expected an error and the data left intact, got %v", name, err)
+		}
+	}
+}
@@ -0,0 +1,10 @@
+module github.com/nspcc-dev/rfc6979/protosign
+
+go 1.24
+
+require (
+	github.com/nspcc-dev/rfc6979 v0.0.0
+	google.golang.org/protobuf v1.36.11
+)
+
+replace github.com/nspcc-dev/rfc6979 => ../
@@ -0,0 +1,44 @@
+/*
+Package protosign signs protocol buffer messages with RFC 6979 deterministic
+ECDSA and provides a canonical protobuf representation of signatures,
+defined in rfc6979/v1/signature.proto, for services passing them over gRPC.
+
+It lives in its own module to keep the protobuf dependency out of package
+rfc6979.
+*/
+package protosign
+
+import (
+	"crypto/ecdsa"
+	"hash"
+	"math/big"
+
+	"github.com/nspcc-dev/rfc6979"
+	"google.golang.org/protobuf/proto"
+)
+
+// marshal is the serialization used for signing. Deterministic marshalling
+// orders map entries, which the default one doesn't, but it is still only
+// stable for the same protobuf implementation and schema version.
+var marshal = proto.MarshalOptions{Deterministic: true}
+
+// Marshal returns the bytes SignMessage hashes for m. Verifiers must hash the
+// output of the same deterministic marshalling, not the bytes received over
+// the wire, which may have been produced differently.
+func Marshal(m proto.Message) ([]byte, error) {
+	return marshal.Marshal(m)
+}
+
+// SignMessage serializes m with deterministic marshalling, hashes the result
+// with alg and signs the digest using the private key, priv, like
+// rfc6979.SignECDSAMessage. It returns the signature as a pair of integers or
+// the marshalling error.
+func SignMessage(priv *ecdsa.PrivateKey, m proto.Message, alg func() hash.Hash) (r, s *big.Int, err error) {
+	data, err := Marshal(m)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r, s = rfc6979.SignECDSAMessage(priv, data, alg)
+	return r, s, nil
+}
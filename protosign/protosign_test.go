@@ -0,0 +1,56 @@
+package protosign_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/nspcc-dev/rfc6979/protosign"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func TestSignMessage(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Maps are the usual source of nondeterministic encodings.
+	fields := make(map[string]interface{})
+	for i := 0; i < 32; i++ {
+		fields[fmt.Sprintf("field%d", i)] = i
+	}
+
+	var expectedR, expectedS *big.Int
+	for i := 0; i < 10; i++ {
+		m, err := structpb.NewStruct(fields)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		r, s, err := protosign.SignMessage(key, m, sha256.New)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if i == 0 {
+			expectedR, expectedS = r, s
+
+			data, err := protosign.Marshal(m)
+			if err != nil {
+				t.Fatal(err)
+			}
+			digest := sha256.Sum256(data)
+			if !ecdsa.Verify(&key.PublicKey, digest[:], r, s) {
+				t.Fatal("Invalid signature")
+			}
+			continue
+		}
+		if r.Cmp(expectedR) != 0 || s.Cmp(expectedS) != 0 {
+			t.Fatalf("Run %d: Signature changed", i)
+		}
+	}
+}
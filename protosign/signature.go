@@ -0,0 +1,91 @@
+package protosign
+
+//go:generate protoc --go_out=. --go_opt=module=github.com/nspcc-dev/rfc6979/protosign rfc6979/v1/signature.proto
+
+import (
+	"crypto"
+	"crypto/elliptic"
+	"errors"
+
+	"github.com/nspcc-dev/rfc6979"
+)
+
+// ErrInvalidSignature is returned when a signature can't be converted.
+var ErrInvalidSignature = errors.New("protosign: invalid signature")
+
+// curves maps Curve values to curves.
+var curves = map[Curve]func() elliptic.Curve{
+	Curve_CURVE_P224:      elliptic.P224,
+	Curve_CURVE_P256:      elliptic.P256,
+	Curve_CURVE_P384:      elliptic.P384,
+	Curve_CURVE_P521:      elliptic.P521,
+	Curve_CURVE_SECP256K1: rfc6979.Secp256k1,
+}
+
+// curveID returns the Curve value of c or CURVE_UNSPECIFIED if there's none.
+func curveID(c elliptic.Curve) Curve {
+	for id, newCurve := range curves {
+		if newCurve().Params().Name == c.Params().Name {
+			return id
+		}
+	}
+	return Curve_CURVE_UNSPECIFIED
+}
+
+// ToProto converts the signature made on sig.Curve over a digest computed
+// with h, which may be 0 if it's unknown, to its protobuf representation. The
+// recovery id isn't known to rfc6979.Signature, callers having one set it
+// with proto.Uint32.
+func ToProto(sig rfc6979.Signature, h crypto.Hash, enc Encoding) (*Signature, error) {
+	if sig.Curve == nil {
+		return nil, ErrInvalidSignature
+	}
+	id := curveID(sig.Curve)
+	if _, ok := Hash_name[int32(h)]; id == Curve_CURVE_UNSPECIFIED || !ok {
+		return nil, ErrInvalidSignature
+	}
+	if _, ok := Encoding_name[int32(enc)]; !ok {
+		return nil, ErrInvalidSignature
+	}
+
+	raw, err := rfc6979.MarshalCompact(sig.Curve, sig.R, sig.S)
+	if err != nil {
+		return nil, ErrInvalidSignature
+	}
+	size := len(raw) / 2
+	return &Signature{
+		Curve:    id,
+		Hash:     Hash(h),
+		R:        raw[:size],
+		S:        raw[size:],
+		Encoding: enc,
+	}, nil
+}
+
+// FromProto converts a protobuf signature back, returning the hash function
+// it names, 0 for HASH_UNSPECIFIED. The curve must be specified, r and s must
+// be exactly as long as ToProto makes them and within [1, N-1], and the
+// recovery id, if set, within [0, 3].
+func FromProto(p *Signature) (rfc6979.Signature, crypto.Hash, error) {
+	newCurve, ok := curves[p.GetCurve()]
+	if !ok {
+		return rfc6979.Signature{}, 0, ErrInvalidSignature
+	}
+	if _, ok := Hash_name[int32(p.GetHash())]; !ok {
+		return rfc6979.Signature{}, 0, ErrInvalidSignature
+	}
+	if _, ok := Encoding_name[int32(p.GetEncoding())]; !ok || p.GetRecoveryId() > 3 {
+		return rfc6979.Signature{}, 0, ErrInvalidSignature
+	}
+
+	c := newCurve()
+	size := rfc6979.OrderSize(c)
+	if len(p.GetR()) != size || len(p.GetS()) != size {
+		return rfc6979.Signature{}, 0, ErrInvalidSignature
+	}
+	r, s, err := rfc6979.UnmarshalCompact(c, append(append([]byte(nil), p.GetR()...), p.GetS()...))
+	if err != nil {
+		return rfc6979.Signature{}, 0, ErrInvalidSignature
+	}
+	return rfc6979.Signature{R: r, S: s, Curve: c}, crypto.Hash(p.GetHash()), nil
+}
@@ -0,0 +1,408 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: rfc6979/v1/signature.proto
+
+package protosign
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Curve identifies the elliptic curve of a signature. The values match the
+// curve identifiers of the rfc6979 envelope and versioned formats.
+type Curve int32
+
+const (
+	Curve_CURVE_UNSPECIFIED Curve = 0
+	Curve_CURVE_P224        Curve = 1
+	Curve_CURVE_P256        Curve = 2
+	Curve_CURVE_P384        Curve = 3
+	Curve_CURVE_P521        Curve = 4
+	Curve_CURVE_SECP256K1   Curve = 5
+)
+
+// Enum value maps for Curve.
+var (
+	Curve_name = map[int32]string{
+		0: "CURVE_UNSPECIFIED",
+		1: "CURVE_P224",
+		2: "CURVE_P256",
+		3: "CURVE_P384",
+		4: "CURVE_P521",
+		5: "CURVE_SECP256K1",
+	}
+	Curve_value = map[string]int32{
+		"CURVE_UNSPECIFIED": 0,
+		"CURVE_P224":        1,
+		"CURVE_P256":        2,
+		"CURVE_P384":        3,
+		"CURVE_P521":        4,
+		"CURVE_SECP256K1":   5,
+	}
+)
+
+func (x Curve) Enum() *Curve {
+	p := new(Curve)
+	*p = x
+	return p
+}
+
+func (x Curve) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Curve) Descriptor() protoreflect.EnumDescriptor {
+	return file_rfc6979_v1_signature_proto_enumTypes[0].Descriptor()
+}
+
+func (Curve) Type() protoreflect.EnumType {
+	return &file_rfc6979_v1_signature_proto_enumTypes[0]
+}
+
+func (x Curve) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Curve.Descriptor instead.
+func (Curve) EnumDescriptor() ([]byte, []int) {
+	return file_rfc6979_v1_signature_proto_rawDescGZIP(), []int{0}
+}
+
+// Hash identifies the hash function of the signed digest. The values match
+// the ones of Go's crypto.Hash.
+type Hash int32
+
+const (
+	Hash_HASH_UNSPECIFIED Hash = 0
+	Hash_HASH_SHA1        Hash = 3
+	Hash_HASH_SHA224      Hash = 4
+	Hash_HASH_SHA256      Hash = 5
+	Hash_HASH_SHA384      Hash = 6
+	Hash_HASH_SHA512      Hash = 7
+	Hash_HASH_SHA3_224    Hash = 10
+	Hash_HASH_SHA3_256    Hash = 11
+	Hash_HASH_SHA3_384    Hash = 12
+	Hash_HASH_SHA3_512    Hash = 13
+	Hash_HASH_SHA512_224  Hash = 14
+	Hash_HASH_SHA512_256  Hash = 15
+)
+
+// Enum value maps for Hash.
+var (
+	Hash_name = map[int32]string{
+		0:  "HASH_UNSPECIFIED",
+		3:  "HASH_SHA1",
+		4:  "HASH_SHA224",
+		5:  "HASH_SHA256",
+		6:  "HASH_SHA384",
+		7:  "HASH_SHA512",
+		10: "HASH_SHA3_224",
+		11: "HASH_SHA3_256",
+		12: "HASH_SHA3_384",
+		13: "HASH_SHA3_512",
+		14: "HASH_SHA512_224",
+		15: "HASH_SHA512_256",
+	}
+	Hash_value = map[string]int32{
+		"HASH_UNSPECIFIED": 0,
+		"HASH_SHA1":        3,
+		"HASH_SHA224":      4,
+		"HASH_SHA256":      5,
+		"HASH_SHA384":      6,
+		"HASH_SHA512":      7,
+		"HASH_SHA3_224":    10,
+		"HASH_SHA3_256":    11,
+		"HASH_SHA3_384":    12,
+		"HASH_SHA3_512":    13,
+		"HASH_SHA512_224":  14,
+		"HASH_SHA512_256":  15,
+	}
+)
+
+func (x Hash) Enum() *Hash {
+	p := new(Hash)
+	*p = x
+	return p
+}
+
+func (x Hash) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Hash) Descriptor() protoreflect.EnumDescriptor {
+	return file_rfc6979_v1_signature_proto_enumTypes[1].Descriptor()
+}
+
+func (Hash) Type() protoreflect.EnumType {
+	return &file_rfc6979_v1_signature_proto_enumTypes[1]
+}
+
+func (x Hash) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Hash.Descriptor instead.
+func (Hash) EnumDescriptor() ([]byte, []int) {
+	return file_rfc6979_v1_signature_proto_rawDescGZIP(), []int{1}
+}
+
+// Encoding is the serialization the signature is presented in outside of
+// this message, e.g. to verifiers expecting bytes.
+type Encoding int32
+
+const (
+	Encoding_ENCODING_UNSPECIFIED Encoding = 0
+	// ASN.1 SEQUENCE of two INTEGERs.
+	Encoding_ENCODING_DER Encoding = 1
+	// r || s, as used by WebCrypto and JOSE.
+	Encoding_ENCODING_P1363 Encoding = 2
+	// recovery_id || r || s.
+	Encoding_ENCODING_COMPACT_RECOVERABLE Encoding = 3
+)
+
+// Enum value maps for Encoding.
+var (
+	Encoding_name = map[int32]string{
+		0: "ENCODING_UNSPECIFIED",
+		1: "ENCODING_DER",
+		2: "ENCODING_P1363",
+		3: "ENCODING_COMPACT_RECOVERABLE",
+	}
+	Encoding_value = map[string]int32{
+		"ENCODING_UNSPECIFIED":         0,
+		"ENCODING_DER":                 1,
+		"ENCODING_P1363":               2,
+		"ENCODING_COMPACT_RECOVERABLE": 3,
+	}
+)
+
+func (x Encoding) Enum() *Encoding {
+	p := new(Encoding)
+	*p = x
+	return p
+}
+
+func (x Encoding) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Encoding) Descriptor() protoreflect.EnumDescriptor {
+	return file_rfc6979_v1_signature_proto_enumTypes[2].Descriptor()
+}
+
+func (Encoding) Type() protoreflect.EnumType {
+	return &file_rfc6979_v1_signature_proto_enumTypes[2]
+}
+
+func (x Encoding) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Encoding.Descriptor instead.
+func (Encoding) EnumDescriptor() ([]byte, []int) {
+	return file_rfc6979_v1_signature_proto_rawDescGZIP(), []int{2}
+}
+
+// Signature is an ECDSA signature.
+type Signature struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Curve Curve                  `protobuf:"varint,1,opt,name=curve,proto3,enum=rfc6979.v1.Curve" json:"curve,omitempty"`
+	Hash  Hash                   `protobuf:"varint,2,opt,name=hash,proto3,enum=rfc6979.v1.Hash" json:"hash,omitempty"`
+	// r and s are big-endian, left-padded with zeros to the byte length of the
+	// curve order.
+	R []byte `protobuf:"bytes,3,opt,name=r,proto3" json:"r,omitempty"`
+	S []byte `protobuf:"bytes,4,opt,name=s,proto3" json:"s,omitempty"`
+	// recovery_id is the public key recovery id within [0, 3], if known.
+	RecoveryId    *uint32  `protobuf:"varint,5,opt,name=recovery_id,json=recoveryId,proto3,oneof" json:"recovery_id,omitempty"`
+	Encoding      Encoding `protobuf:"varint,6,opt,name=encoding,proto3,enum=rfc6979.v1.Encoding" json:"encoding,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Signature) Reset() {
+	*x = Signature{}
+	mi := &file_rfc6979_v1_signature_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Signature) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Signature) ProtoMessage() {}
+
+func (x *Signature) ProtoReflect() protoreflect.Message {
+	mi := &file_rfc6979_v1_signature_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Signature.ProtoReflect.Descriptor instead.
+func (*Signature) Descriptor() ([]byte, []int) {
+	return file_rfc6979_v1_signature_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Signature) GetCurve() Curve {
+	if x != nil {
+		return x.Curve
+	}
+	return Curve_CURVE_UNSPECIFIED
+}
+
+func (x *Signature) GetHash() Hash {
+	if x != nil {
+		return x.Hash
+	}
+	return Hash_HASH_UNSPECIFIED
+}
+
+func (x *Signature) GetR() []byte {
+	if x != nil {
+		return x.R
+	}
+	return nil
+}
+
+func (x *Signature) GetS() []byte {
+	if x != nil {
+		return x.S
+	}
+	return nil
+}
+
+func (x *Signature) GetRecoveryId() uint32 {
+	if x != nil && x.RecoveryId != nil {
+		return *x.RecoveryId
+	}
+	return 0
+}
+
+func (x *Signature) GetEncoding() Encoding {
+	if x != nil {
+		return x.Encoding
+	}
+	return Encoding_ENCODING_UNSPECIFIED
+}
+
+var File_rfc6979_v1_signature_proto protoreflect.FileDescriptor
+
+const file_rfc6979_v1_signature_proto_rawDesc = "" +
+	"\n" +
+	"\x1arfc6979/v1/signature.proto\x12\n" +
+	"rfc6979.v1\"\xde\x01\n" +
+	"\tSignature\x12'\n" +
+	"\x05curve\x18\x01 \x01(\x0e2\x11.rfc6979.v1.CurveR\x05curve\x12$\n" +
+	"\x04hash\x18\x02 \x01(\x0e2\x10.rfc6979.v1.HashR\x04hash\x12\f\n" +
+	"\x01r\x18\x03 \x01(\fR\x01r\x12\f\n" +
+	"\x01s\x18\x04 \x01(\fR\x01s\x12$\n" +
+	"\vrecovery_id\x18\x05 \x01(\rH\x00R\n" +
+	"recoveryId\x88\x01\x01\x120\n" +
+	"\bencoding\x18\x06 \x01(\x0e2\x14.rfc6979.v1.EncodingR\bencodingB\x0e\n" +
+	"\f_recovery_id*s\n" +
+	"\x05Curve\x12\x15\n" +
+	"\x11CURVE_UNSPECIFIED\x10\x00\x12\x0e\n" +
+	"\n" +
+	"CURVE_P224\x10\x01\x12\x0e\n" +
+	"\n" +
+	"CURVE_P256\x10\x02\x12\x0e\n" +
+	"\n" +
+	"CURVE_P384\x10\x03\x12\x0e\n" +
+	"\n" +
+	"CURVE_P521\x10\x04\x12\x13\n" +
+	"\x0fCURVE_SECP256K1\x10\x05*\xe5\x01\n" +
+	"\x04Hash\x12\x14\n" +
+	"\x10HASH_UNSPECIFIED\x10\x00\x12\r\n" +
+	"\tHASH_SHA1\x10\x03\x12\x0f\n" +
+	"\vHASH_SHA224\x10\x04\x12\x0f\n" +
+	"\vHASH_SHA256\x10\x05\x12\x0f\n" +
+	"\vHASH_SHA384\x10\x06\x12\x0f\n" +
+	"\vHASH_SHA512\x10\a\x12\x11\n" +
+	"\rHASH_SHA3_224\x10\n" +
+	"\x12\x11\n" +
+	"\rHASH_SHA3_256\x10\v\x12\x11\n" +
+	"\rHASH_SHA3_384\x10\f\x12\x11\n" +
+	"\rHASH_SHA3_512\x10\r\x12\x13\n" +
+	"\x0fHASH_SHA512_224\x10\x0e\x12\x13\n" +
+	"\x0fHASH_SHA512_256\x10\x0f*l\n" +
+	"\bEncoding\x12\x18\n" +
+	"\x14ENCODING_UNSPECIFIED\x10\x00\x12\x10\n" +
+	"\fENCODING_DER\x10\x01\x12\x12\n" +
+	"\x0eENCODING_P1363\x10\x02\x12 \n" +
+	"\x1cENCODING_COMPACT_RECOVERABLE\x10\x03B(Z&github.com/nspcc-dev/rfc6979/protosignb\x06proto3"
+
+var (
+	file_rfc6979_v1_signature_proto_rawDescOnce sync.Once
+	file_rfc6979_v1_signature_proto_rawDescData []byte
+)
+
+func file_rfc6979_v1_signature_proto_rawDescGZIP() []byte {
+	file_rfc6979_v1_signature_proto_rawDescOnce.Do(func() {
+		file_rfc6979_v1_signature_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_rfc6979_v1_signature_proto_rawDesc), len(file_rfc6979_v1_signature_proto_rawDesc)))
+	})
+	return file_rfc6979_v1_signature_proto_rawDescData
+}
+
+var file_rfc6979_v1_signature_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_rfc6979_v1_signature_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_rfc6979_v1_signature_proto_goTypes = []any{
+	(Curve)(0),        // 0: rfc6979.v1.Curve
+	(Hash)(0),         // 1: rfc6979.v1.Hash
+	(Encoding)(0),     // 2: rfc6979.v1.Encoding
+	(*Signature)(nil), // 3: rfc6979.v1.Signature
+}
+var file_rfc6979_v1_signature_proto_depIdxs = []int32{
+	0, // 0: rfc6979.v1.Signature.curve:type_name -> rfc6979.v1.Curve
+	1, // 1: rfc6979.v1.Signature.hash:type_name -> rfc6979.v1.Hash
+	2, // 2: rfc6979.v1.Signature.encoding:type_name -> rfc6979.v1.Encoding
+	3, // [3:3] is the sub-list for method output_type
+	3, // [3:3] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_rfc6979_v1_signature_proto_init() }
+func file_rfc6979_v1_signature_proto_init() {
+	if File_rfc6979_v1_signature_proto != nil {
+		return
+	}
+	file_rfc6979_v1_signature_proto_msgTypes[0].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_rfc6979_v1_signature_proto_rawDesc), len(file_rfc6979_v1_signature_proto_rawDesc)),
+			NumEnums:      3,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_rfc6979_v1_signature_proto_goTypes,
+		DependencyIndexes: file_rfc6979_v1_signature_proto_depIdxs,
+		EnumInfos:         file_rfc6979_v1_signature_proto_enumTypes,
+		MessageInfos:      file_rfc6979_v1_signature_proto_msgTypes,
+	}.Build()
+	File_rfc6979_v1_signature_proto = out.File
+	file_rfc6979_v1_signature_proto_goTypes = nil
+	file_rfc6979_v1_signature_proto_depIdxs = nil
+}
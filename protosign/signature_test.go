@@ -0,0 +1,103 @@
+package protosign_test
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"math/big"
+	"testing"
+
+	"github.com/nspcc-dev/rfc6979"
+	"github.com/nspcc-dev/rfc6979/protosign"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestSignatureProto(t *testing.T) {
+	digest := sha256.Sum256([]byte("sample"))
+	for _, c := range []elliptic.Curve{elliptic.P224(), elliptic.P256(), elliptic.P384(), elliptic.P521(), rfc6979.Secp256k1()} {
+		name := c.Params().Name
+		key := &ecdsa.PrivateKey{D: big.NewInt(0x1234567)}
+		key.Curve = c
+		key.X, key.Y = c.ScalarBaseMult(key.D.Bytes())
+
+		r, s, recid := rfc6979.SignECDSARecoverable(key, digest[:], sha256.New, nil)
+		p, err := protosign.ToProto(rfc6979.Signature{R: r, S: s, Curve: c}, crypto.SHA256, protosign.Encoding_ENCODING_COMPACT_RECOVERABLE)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		p.RecoveryId = proto.Uint32(uint32(recid))
+
+		data, err := proto.Marshal(p)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		decoded := new(protosign.Signature)
+		if err := proto.Unmarshal(data, decoded); err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+
+		sig, h, err := protosign.FromProto(decoded)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if sig.Curve != c || sig.R.Cmp(r) != 0 || sig.S.Cmp(s) != 0 || h != crypto.SHA256 {
+			t.Errorf("%s: Expected round trip, got %+v, %v", name, sig, h)
+		}
+		if decoded.GetRecoveryId() != uint32(recid) || decoded.GetEncoding() != protosign.Encoding_ENCODING_COMPACT_RECOVERABLE {
+			t.Errorf("%s: Expected recovery id and encoding to be preserved", name)
+		}
+		if size := rfc6979.OrderSize(c); len(decoded.R) != size || len(decoded.S) != size {
+			t.Errorf("%s: Expected %d-byte components", name, size)
+		}
+	}
+}
+
+func TestSignatureProtoInvalid(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	digest := sha256.Sum256([]byte("sample"))
+	r, s := rfc6979.SignECDSA(key, digest[:], sha256.New)
+	sig := rfc6979.Signature{R: r, S: s, Curve: key.Curve}
+	unknown := *elliptic.P256().Params()
+	unknown.Name = "P-192"
+
+	for name, f := range map[string]func() (*protosign.Signature, error){
+		"no curve":     func() (*protosign.Signature, error) { return protosign.ToProto(rfc6979.Signature{R: r, S: s}, 0, 0) },
+		"unknown hash": func() (*protosign.Signature, error) { return protosign.ToProto(sig, crypto.MD5, 0) },
+		"zero s": func() (*protosign.Signature, error) {
+			return protosign.ToProto(rfc6979.Signature{R: r, S: new(big.Int), Curve: key.Curve}, 0, 0)
+		},
+		"bad encoding": func() (*protosign.Signature, error) { return protosign.ToProto(sig, 0, 42) },
+		"unknown curve": func() (*protosign.Signature, error) {
+			return protosign.ToProto(rfc6979.Signature{R: r, S: s, Curve: &unknown}, 0, 0)
+		},
+	} {
+		if _, err := f(); err != protosign.ErrInvalidSignature {
+			t.Errorf("%s: Expected %v, got %v", name, protosign.ErrInvalidSignature, err)
+		}
+	}
+
+	valid, err := protosign.ToProto(sig, crypto.SHA256, protosign.Encoding_ENCODING_DER)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, mutate := range map[string]func(p *protosign.Signature){
+		"no curve":     func(p *protosign.Signature) { p.Curve = protosign.Curve_CURVE_UNSPECIFIED },
+		"wrong curve":  func(p *protosign.Signature) { p.Curve = protosign.Curve_CURVE_P384 },
+		"unknown hash": func(p *protosign.Signature) { p.Hash = 1 },
+		"short r":      func(p *protosign.Signature) { p.R = p.R[1:] },
+		"zero s":       func(p *protosign.Signature) { p.S = make([]byte, 32) },
+		"recovery id":  func(p *protosign.Signature) { p.RecoveryId = proto.Uint32(4) },
+		"encoding":     func(p *protosign.Signature) { p.Encoding = 42 },
+	} {
+		p := proto.Clone(valid).(*protosign.Signature)
+		mutate(p)
+		if _, _, err := protosign.FromProto(p); err != protosign.ErrInvalidSignature {
+			t.Errorf("%s: Expected %v, got %v", name, protosign.ErrInvalidSignature, err)
+		}
+	}
+}
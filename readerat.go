@@ -0,0 +1,40 @@
+package rfc6979
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"hash"
+	"io"
+	"math/big"
+)
+
+// readAtChunk is the size of the chunks SignECDSAReaderAt hashes.
+const readAtChunk = 64 << 10
+
+// SignECDSAReaderAt hashes size bytes of r starting at offset zero with alg
+// and signs the digest using the private key, priv, like SignECDSAMessage. The
+// data is read in chunks with ReadAt, so r can be a memory-mapped file. An
+// error is returned if reading fails, io.ErrUnexpectedEOF if r holds less than
+// size bytes.
+func SignECDSAReaderAt(priv *ecdsa.PrivateKey, r io.ReaderAt, size int64, alg func() hash.Hash) (*big.Int, *big.Int, error) {
+	h := alg()
+	buf := make([]byte, readAtChunk)
+	for off := int64(0); off < size; {
+		chunk := buf
+		if rest := size - off; rest < int64(len(chunk)) {
+			chunk = chunk[:rest]
+		}
+		n, err := r.ReadAt(chunk, off)
+		h.Write(chunk[:n])
+		off += int64(n)
+		if err != nil && (off < size || !errors.Is(err, io.EOF)) {
+			if errors.Is(err, io.EOF) {
+				err = io.ErrUnexpectedEOF
+			}
+			return nil, nil, err
+		}
+	}
+
+	sigR, sigS := SignECDSA(priv, h.Sum(nil), alg)
+	return sigR, sigS, nil
+}
@@ -0,0 +1,62 @@
+package rfc6979_test
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/nspcc-dev/rfc6979"
+)
+
+type failingReaderAt struct{}
+
+var errReadAt = errors.New("read failed")
+
+func (failingReaderAt) ReadAt(p []byte, off int64) (int, error) {
+	return 0, errReadAt
+}
+
+func TestSignECDSAReaderAt(t *testing.T) {
+	// Large enough to span several chunks, with a partial last one.
+	data := bytes.Repeat([]byte("memory-mapped artifact "), 10000)
+
+	for _, key := range []*ecdsaKey{p256, p384} {
+		name := key.key.Curve.Params().Name
+		expectedR, expectedS := rfc6979.SignECDSAMessage(key.key, data, sha256.New)
+
+		r, s, err := rfc6979.SignECDSAReaderAt(key.key, bytes.NewReader(data), int64(len(data)), sha256.New)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if r.Cmp(expectedR) != 0 || s.Cmp(expectedS) != 0 {
+			t.Errorf("%s: Signature differs from one-shot hashing", name)
+		}
+	}
+}
+
+func TestSignECDSAReaderAtPrefix(t *testing.T) {
+	data := []byte("signed part|ignored part")
+
+	r, s, err := rfc6979.SignECDSAReaderAt(p256.key, bytes.NewReader(data), 11, sha256.New)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedR, expectedS := rfc6979.SignECDSAMessage(p256.key, data[:11], sha256.New)
+	if r.Cmp(expectedR) != 0 || s.Cmp(expectedS) != 0 {
+		t.Error("Signature doesn't cover exactly size bytes")
+	}
+}
+
+func TestSignECDSAReaderAtErrors(t *testing.T) {
+	_, _, err := rfc6979.SignECDSAReaderAt(p256.key, failingReaderAt{}, 10, sha256.New)
+	if err != errReadAt {
+		t.Errorf("Expected %v, got %v", errReadAt, err)
+	}
+
+	_, _, err = rfc6979.SignECDSAReaderAt(p256.key, bytes.NewReader([]byte("short")), 10, sha256.New)
+	if err != io.ErrUnexpectedEOF {
+		t.Errorf("Expected %v, got %v", io.ErrUnexpectedEOF, err)
+	}
+}
@@ -0,0 +1,93 @@
+package rfc6979
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"errors"
+	"hash"
+	"math/big"
+
+	"github.com/nspcc-dev/rfc6979/internal/weierstrass"
+)
+
+// ErrRecovery is returned when a public key can't be recovered from a
+// signature.
+var ErrRecovery = errors.New("rfc6979: public key recovery failed")
+
+// SignECDSARecoverable is like SignECDSAWithOptions, but additionally returns
+// the recovery id needed by RecoverPublicKey. The recovery id is adjusted when
+// opts.LowS flips s, so it always matches the returned signature.
+func SignECDSARecoverable(priv *ecdsa.PrivateKey, hash []byte, alg func() hash.Hash, opts *Options) (r, s *big.Int, recid byte) {
+	r, s = new(big.Int), new(big.Int)
+	recid = signECDSA(priv, hash, alg, opts, r, s, nil)
+	return
+}
+
+// RecoverPublicKey returns the public key that produced the signature (r, s)
+// with the recovery id, recid, over the hash, as described in SEC 1 section
+// 4.1.6. Only curves over prime fields of the form y² = x³ - 3x + b, like
+// the NIST ones, or y² = x³ + ax + b, like secp256k1, the Brainpool curves
+// and the ones of the legacy package, are supported; the binary curves, like
+// K163 and B163, are rejected with ErrRecovery.
+func RecoverPublicKey(c elliptic.Curve, hash []byte, r, s *big.Int, recid byte) (*ecdsa.PublicKey, error) {
+	params := c.Params()
+	N := params.N
+	if _, ok := c.(*binaryCurve); ok || recid > 3 || r.Sign() <= 0 || s.Sign() <= 0 || r.Cmp(N) >= 0 || s.Cmp(N) >= 0 {
+		return nil, ErrRecovery
+	}
+
+	x := new(big.Int).Set(r)
+	if recid&2 != 0 {
+		x.Add(x, N)
+	}
+	if x.Cmp(params.P) >= 0 {
+		return nil, ErrRecovery
+	}
+
+	y := curveY(c, x)
+	if y == nil {
+		return nil, ErrRecovery
+	}
+	if y.Bit(0) != uint(recid&1) {
+		y.Sub(params.P, y)
+	}
+
+	// Q = r⁻¹(sR - eG)
+	rInv := new(big.Int).ModInverse(r, N)
+	u1 := new(big.Int).Mul(hashToInt(hash, c), rInv)
+	u1.Neg(u1).Mod(u1, N)
+	u2 := new(big.Int).Mul(s, rInv)
+	u2.Mod(u2, N)
+
+	x1, y1 := c.ScalarBaseMult(u1.Bytes())
+	x2, y2 := c.ScalarMult(x, y, u2.Bytes())
+	qx, qy := c.Add(x1, y1, x2, y2)
+	if qx.Sign() == 0 && qy.Sign() == 0 {
+		return nil, ErrRecovery
+	}
+
+	return &ecdsa.PublicKey{Curve: c, X: qx, Y: qy}, nil
+}
+
+// curveY returns one of the y coordinates corresponding to x on the curve
+// y² = x³ - 3x + b, or y² = x³ + ax + b for weierstrass.Curve ones, or nil
+// if there is no such point.
+func curveY(c elliptic.Curve, x *big.Int) *big.Int {
+	params := c.Params()
+	y2 := new(big.Int).Mul(x, x)
+
+	if w, ok := c.(*weierstrass.Curve); ok {
+		y2.Add(y2, w.A())
+		y2.Mul(y2, x)
+	} else {
+		y2.Mul(y2, x)
+		threeX := new(big.Int).Lsh(x, 1)
+		threeX.Add(threeX, x)
+		y2.Sub(y2, threeX)
+	}
+
+	y2.Add(y2, params.B)
+	y2.Mod(y2, params.P)
+
+	return new(big.Int).ModSqrt(y2, params.P)
+}
@@ -0,0 +1,71 @@
+package rfc6979_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/sha256"
+	"math/big"
+	"strconv"
+	"testing"
+
+	"github.com/nspcc-dev/rfc6979"
+)
+
+func TestRecoverPublicKey(t *testing.T) {
+	keys := []*ecdsa.PrivateKey{p224.key, p256.key, p384.key, p521.key, secp256k1Key(secp256k1Vectors[1].d)}
+	for _, key := range keys {
+		for i := 0; i < 8; i++ {
+			digest := sha256.Sum256([]byte(strconv.Itoa(i)))
+			r, s, recid := rfc6979.SignECDSARecoverable(key, digest[:], sha256.New, nil)
+
+			pub, err := rfc6979.RecoverPublicKey(key.Curve, digest[:], r, s, recid)
+			if err != nil {
+				t.Errorf("%s #%d: %v", key.Curve.Params().Name, i, err)
+				continue
+			}
+
+			if pub.X.Cmp(key.X) != 0 || pub.Y.Cmp(key.Y) != 0 {
+				t.Errorf("%s #%d: Recovered wrong public key", key.Curve.Params().Name, i)
+			}
+		}
+	}
+}
+
+func TestRecoverLowS(t *testing.T) {
+	key := p256.key
+	N := key.Curve.Params().N
+	half := new(big.Int).Rsh(N, 1)
+
+	// Find a message producing s in the upper half of the range.
+	var digest [32]byte
+	for i := 0; ; i++ {
+		digest = sha256.Sum256([]byte(strconv.Itoa(i)))
+		if _, s := rfc6979.SignECDSA(key, digest[:], sha256.New); s.Cmp(half) > 0 {
+			break
+		}
+	}
+
+	r, highS, highID := rfc6979.SignECDSARecoverable(key, digest[:], sha256.New, nil)
+	lr, lowS, lowID := rfc6979.SignECDSARecoverable(key, digest[:], sha256.New, &rfc6979.Options{LowS: true})
+
+	if lr.Cmp(r) != 0 || new(big.Int).Add(lowS, highS).Cmp(N) != 0 {
+		t.Fatalf("Expected low-S normalization to replace s with N - s")
+	}
+
+	if lowID != highID^1 {
+		t.Errorf("Expected recovery id parity to flip, got %d and %d", highID, lowID)
+	}
+
+	pub, err := rfc6979.RecoverPublicKey(key.Curve, digest[:], r, lowS, lowID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pub.X.Cmp(key.X) != 0 || pub.Y.Cmp(key.Y) != 0 {
+		t.Errorf("Recovered wrong public key with the adjusted recovery id")
+	}
+
+	// The unadjusted recovery id yields some other key.
+	pub, err = rfc6979.RecoverPublicKey(key.Curve, digest[:], r, lowS, highID)
+	if err == nil && pub.X.Cmp(key.X) == 0 && pub.Y.Cmp(key.Y) == 0 {
+		t.Errorf("Expected the unadjusted recovery id to recover another key")
+	}
+}
@@ -16,7 +16,6 @@ Provides functions similar to crypto/dsa and crypto/ecdsa.
 package rfc6979
 
 import (
-	"bytes"
 	"crypto/hmac"
 	"hash"
 	"math/big"
@@ -34,78 +33,169 @@ func bits2int(in []byte, qlen int) *big.Int {
 	vlen := len(in) * 8
 	v := new(big.Int).SetBytes(in)
 	if vlen > qlen {
-		v = new(big.Int).Rsh(v, uint(vlen-qlen))
+		v.Rsh(v, uint(vlen-qlen))
 	}
 	return v
 }
 
 // https://tools.ietf.org/html/rfc6979#section-2.3.3
 func int2octets(v *big.Int, rolen int) []byte {
-	out := v.Bytes()
+	return appendInt2Octets(nil, v, rolen)
+}
 
-	// pad with zeros if it's too short
-	if len(out) < rolen {
-		out2 := make([]byte, rolen)
-		copy(out2[rolen-len(out):], out)
-		return out2
-	}
+// appendInt2Octets appends int2octets(v, rolen) to dst.
+func appendInt2Octets(dst []byte, v *big.Int, rolen int) []byte {
+	n := len(dst)
+	dst = grow(dst, rolen)
 
 	// drop most significant bytes if it's too long
-	if len(out) > rolen {
-		out2 := make([]byte, rolen)
-		copy(out2, out[len(out)-rolen:])
-		return out2
+	if v.BitLen() > 8*rolen {
+		b := v.Bytes()
+		copy(dst[n:], b[len(b)-rolen:])
+		return dst
 	}
 
-	return out
+	// pad with zeros if it's too short
+	v.FillBytes(dst[n:])
+	return dst
 }
 
 // https://tools.ietf.org/html/rfc6979#section-2.3.4
 func bits2octets(in []byte, q *big.Int, qlen, rolen int) []byte {
-	z1 := bits2int(in, qlen)
-	z2 := new(big.Int).Sub(z1, q)
-	if z2.Sign() < 0 {
-		return int2octets(z1, rolen)
+	return appendBits2Octets(nil, in, q, qlen, rolen)
+}
+
+// appendBits2Octets appends bits2octets(in) to dst.
+func appendBits2Octets(dst, in []byte, q *big.Int, qlen, rolen int) []byte {
+	z := bits2int(in, qlen)
+	if z.Cmp(q) >= 0 {
+		z.Sub(z, q)
 	}
-	return int2octets(z2, rolen)
+	return appendInt2Octets(dst, z, rolen)
+}
+
+// grow extends dst by n bytes, reallocating it only if its capacity is
+// insufficient.
+func grow(dst []byte, n int) []byte {
+	if l := len(dst) + n; l <= cap(dst) {
+		return dst[:l]
+	}
+	out := make([]byte, len(dst)+n)
+	copy(out, dst)
+	return out
 }
 
 var one = big.NewInt(1)
 
+// GenerateK returns the deterministic nonce k for the subgroup order q, the
+// private key x and the hash digest, as described in RFC 6979 section 3.2. It
+// is the first candidate within [1, q-1], which is the value used by
+// SignECDSA and SignDSA unless it yields a zero r or s.
+func GenerateK(q, x *big.Int, alg func() hash.Hash, hash []byte) *big.Int {
+	return GenerateKFunc(q, x, alg, hash, func(*big.Int) bool { return true })
+}
+
+// GenerateKFunc is like GenerateK, but lets the caller reject candidates,
+// e.g. when k yields a zero signature component in the scheme built upon it.
+// accept is called with every candidate within [1, q-1] until it returns
+// true, the generator state being updated in between as in step H3 of RFC
+// 6979 section 3.2. The accepted candidate is returned. This is the nonce
+// derivation of SignECDSA and SignDSA, independent of any key type.
+func GenerateKFunc(q, x *big.Int, alg func() hash.Hash, hash []byte, accept func(k *big.Int) bool) *big.Int {
+	var k *big.Int
+	generateSecret(q, x, alg, hash, func(secret *big.Int) bool {
+		if !accept(secret) {
+			return false
+		}
+		k = secret
+		return true
+	})
+	return k
+}
+
+// GenerateKFromSeed runs the RFC 6979 section 3.2 generator instantiated with
+// the caller-provided seed material and returns the first candidate within
+// [1, q-1]. The standard seed is int2octets(x) || bits2octets(h1), both
+// big-endian and rolen = ceil(qlen/8) bytes long, with GenerateK using exactly
+// that; RFC 6979 section 3.6 allows appending extra data k'. Any other layout
+// is up to the caller, the seed is used as is.
+func GenerateKFromSeed(q *big.Int, alg func() hash.Hash, seedMaterial []byte) *big.Int {
+	var k *big.Int
+	nextSecret(newDRBG(alg, nil, seedMaterial), q, func(secret *big.Int) bool {
+		k = secret
+		return true
+	})
+	return k
+}
+
 // https://tools.ietf.org/html/rfc6979#section-3.2
 func generateSecret(q, x *big.Int, alg func() hash.Hash, hash []byte, test func(*big.Int) bool) {
+	generateSecretOpts(q, x, alg, hash, nil, test)
+}
+
+// generateSecretOpts is like generateSecret, but applies the nonce
+// generation tweaks of opts, which may be nil.
+func generateSecretOpts(q, x *big.Int, alg func() hash.Hash, hash []byte, opts *Options, test func(*big.Int) bool) {
+	if opts == nil {
+		opts = new(Options)
+	}
+
 	qlen := q.BitLen()
-	holen := alg().Size()
 	rolen := (qlen + 7) >> 3
-	bx := append(int2octets(x, rolen), bits2octets(hash, q, qlen, rolen)...)
-
-	// Step B
-	v := bytes.Repeat([]byte{0x01}, holen)
+	xlen := rolen
+	if opts.KeyOctets > 0 {
+		xlen = opts.KeyOctets
+	}
 
-	// Step C
-	k := bytes.Repeat([]byte{0x00}, holen)
+	var bx []byte
+	if opts.noise != nil {
+		bx = noisySeed(alg, opts.noise, xlen)
+	}
+	xoff := len(bx)
+	bx = appendInt2Octets(bx, x, xlen)
+	if opts.noise != nil {
+		bx = appendZeroPad(bx, len(bx)-xoff, alg().BlockSize())
+	}
+	bx = appendBits2Octets(bx, hash, q, qlen, rolen)
+	bx = append(bx, opts.ExtraData...)
 
-	// Step D
-	k = mac(alg, k, append(append(v, 0x00), bx...), k)
+	d := newDRBG(alg, opts.HMAC, bx)
+	d.additional = opts.AdditionalInput
+	d.trace = opts.trace
+	nextSecret(d, q, test)
+}
 
-	// Step E
-	v = mac(alg, k, v, v)
+// noisySeed returns the beginning of the seed of the "deterministic
+// signatures with noise" construction, Z || 000..., padded so that V || 0x00
+// || Z || 000... fills whole blocks of the hash.
+func noisySeed(alg func() hash.Hash, z []byte, xlen int) []byte {
+	h := alg()
+	bx := make([]byte, 0, 3*h.BlockSize()+xlen+len(z))
+	bx = append(bx, z...)
+	return appendZeroPad(bx, h.Size()+1+len(bx), h.BlockSize())
+}
 
-	// Step F
-	k = mac(alg, k, append(append(v, 0x01), bx...), k)
+// appendZeroPad appends as many zero bytes to dst as needed to make n a
+// multiple of the block size.
+func appendZeroPad(dst []byte, n, block int) []byte {
+	for ; n%block != 0; n++ {
+		dst = append(dst, 0)
+	}
+	return dst
+}
 
-	// Step G
-	v = mac(alg, k, v, v)
+// nextSecret draws candidates from the generator d until one of them is
+// within [1, q-1] and passes the test.
+func nextSecret(d *drbg, q *big.Int, test func(*big.Int) bool) {
+	qlen := q.BitLen()
+	rolen := (qlen + 7) >> 3
 
 	// Step H
 	for {
-		// Step H1
-		var t []byte
-
-		// Step H2
-		for len(t) < qlen/8 {
-			v = mac(alg, k, v, v)
-			t = append(t, v...)
+		// Steps H1 and H2
+		t := d.generate(rolen)
+		if d.trace != nil {
+			d.trace(t)
 		}
 
 		// Step H3
@@ -113,7 +203,6 @@ func generateSecret(q, x *big.Int, alg func() hash.Hash, hash []byte, test func(
 		if secret.Cmp(one) >= 0 && secret.Cmp(q) < 0 && test(secret) {
 			return
 		}
-		k = mac(alg, k, append(v, 0x00), k)
-		v = mac(alg, k, v, v)
+		d.update()
 	}
 }
@@ -1,9 +1,11 @@
 package rfc6979
 
 import (
+	"bytes"
 	"crypto/sha256"
 	"encoding/hex"
 	"math/big"
+	"strings"
 	"testing"
 )
 
@@ -26,3 +28,146 @@ func TestGenerateSecret(t *testing.T) {
 		t.Errorf("Expected %x, got %x", expected, actual)
 	}
 }
+
+// https://tools.ietf.org/html/rfc6979#appendix-A.1
+// https://tools.ietf.org/html/rfc6979#appendix-A.2.5
+func TestGenerateKFromSeed(t *testing.T) {
+	p256 := sha256.Sum256([]byte("sample"))
+	for _, v := range []struct {
+		q, x, k string
+		hash    []byte
+	}{
+		{
+			q:    "4000000000000000000020108A2E0CC0D99F8A5EF",
+			x:    "09A4D6792295A7F730FC3F2B49CBC0F62E862272F",
+			k:    "23AF4074C90A02B3FE61D286D5C87F425E6BDD81B",
+			hash: mustDecodeHex("AF2BDBE1AA9B6EC1E2ADE1D694F41FC71A831D0268E9891562113D8A62ADD1BF"),
+		},
+		{
+			q:    "FFFFFFFF00000000FFFFFFFFFFFFFFFFBCE6FAADA7179E84F3B9CAC2FC632551",
+			x:    "C9AFA9D845BA75166B5C215767B1D6934E50C3DB36E89B127B8A622B120F6721",
+			k:    "A6E3C57DD01ABE90086538398355DD4C3B17AA873382B0F24D6129493D8AAD60",
+			hash: p256[:],
+		},
+	} {
+		q, _ := new(big.Int).SetString(v.q, 16)
+		x, _ := new(big.Int).SetString(v.x, 16)
+		expected, _ := new(big.Int).SetString(v.k, 16)
+		qlen := q.BitLen()
+		rolen := (qlen + 7) >> 3
+
+		seed := append(int2octets(x, rolen), bits2octets(v.hash, q, qlen, rolen)...)
+		if k := GenerateKFromSeed(q, sha256.New, seed); k.Cmp(expected) != 0 {
+			t.Errorf("Expected %X, got %X", expected, k)
+		}
+		if k := GenerateK(q, x, sha256.New, v.hash); k.Cmp(expected) != 0 {
+			t.Errorf("Expected GenerateK to return %X, got %X", expected, k)
+		}
+	}
+}
+
+func mustDecodeHex(s string) []byte {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// https://tools.ietf.org/html/rfc6979#appendix-A.1.2
+func TestGenerateSecretTrace(t *testing.T) {
+	q, _ := new(big.Int).SetString("4000000000000000000020108A2E0CC0D99F8A5EF", 16)
+	x, _ := new(big.Int).SetString("09A4D6792295A7F730FC3F2B49CBC0F62E862272F", 16)
+	hash := mustDecodeHex("AF2BDBE1AA9B6EC1E2ADE1D694F41FC71A831D0268E9891562113D8A62ADD1BF")
+
+	// The first two candidates are out of range.
+	expected := []string{
+		"9305A46DE7FF8EB107194DEBD3FD48AA20D5E7656CBE0EA69D2A8D4E7C67314A",
+		"C70C78608A3B5BE9289BE90EF6E81A9E2C1516D5751D2F75F50033E45F73BDEB",
+		"475E80E992140567FCC3A50DAB90FE84BCD7BB03638E9C4656A06F37F6508A7C",
+	}
+
+	var actual []string
+	opts := &Options{trace: func(t []byte) {
+		actual = append(actual, strings.ToUpper(hex.EncodeToString(t)))
+	}}
+	generateSecretOpts(q, x, sha256.New, hash, opts, func(*big.Int) bool { return true })
+
+	if strings.Join(actual, " ") != strings.Join(expected, " ") {
+		t.Errorf("Expected candidates %v, got %v", expected, actual)
+	}
+}
+
+// https://tools.ietf.org/html/rfc6979#appendix-A.1.2
+func TestBits2Octets(t *testing.T) {
+	q, _ := new(big.Int).SetString("4000000000000000000020108A2E0CC0D99F8A5EF", 16)
+	x, _ := new(big.Int).SetString("09A4D6792295A7F730FC3F2B49CBC0F62E862272F", 16)
+	qlen := q.BitLen()
+	rolen := (qlen + 7) >> 3
+
+	// The leftmost 163 bits of the hash exceed q, so q is subtracted.
+	hash, _ := hex.DecodeString("AF2BDBE1AA9B6EC1E2ADE1D694F41FC71A831D0268E9891562113D8A62ADD1BF")
+	if z := bits2int(hash, qlen); z.Cmp(q) < 0 {
+		t.Fatalf("Expected bits2int to exceed q, got %X", z)
+	}
+
+	expected, _ := hex.DecodeString("01795EDF0D54DB760F156D0DAC04C0322B3A204224")
+	actual := bits2octets(hash, q, qlen, rolen)
+	if !bytes.Equal(actual, expected) {
+		t.Errorf("Expected %X, got %X", expected, actual)
+	}
+
+	// The reduced octets are the ones used to seed the generator.
+	var k *big.Int
+	generateSecret(q, x, sha256.New, hash, func(secret *big.Int) bool {
+		k = secret
+		return true
+	})
+
+	var seeded *big.Int
+	nextSecret(newDRBG(sha256.New, nil, append(int2octets(x, rolen), expected...)), q, func(secret *big.Int) bool {
+		seeded = secret
+		return true
+	})
+
+	if k.Cmp(seeded) != 0 {
+		t.Errorf("Expected generator to be seeded with the reduced octets")
+	}
+
+	// Values below q are kept as is.
+	small := make([]byte, len(hash))
+	small[0] = 0x01
+	expected = int2octets(bits2int(small, qlen), rolen)
+	if actual := bits2octets(small, q, qlen, rolen); !bytes.Equal(actual, expected) {
+		t.Errorf("Expected %X, got %X", expected, actual)
+	}
+}
+
+func BenchmarkInt2Octets(b *testing.B) {
+	x, _ := new(big.Int).SetString("09A4D6792295A7F730FC3F2B49CBC0F62E862272F", 16)
+	buf := make([]byte, 0, 21)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		appendInt2Octets(buf, x, 21)
+	}
+}
+
+func BenchmarkBits2Octets(b *testing.B) {
+	q, _ := new(big.Int).SetString("4000000000000000000020108A2E0CC0D99F8A5EF", 16)
+	hash, _ := hex.DecodeString("AF2BDBE1AA9B6EC1E2ADE1D694F41FC71A831D0268E9891562113D8A62ADD1BF")
+	buf := make([]byte, 0, 21)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		appendBits2Octets(buf, hash, q, 163, 21)
+	}
+}
+
+func BenchmarkGenerateSecret(b *testing.B) {
+	q, _ := new(big.Int).SetString("4000000000000000000020108A2E0CC0D99F8A5EF", 16)
+	x, _ := new(big.Int).SetString("09A4D6792295A7F730FC3F2B49CBC0F62E862272F", 16)
+	hash, _ := hex.DecodeString("AF2BDBE1AA9B6EC1E2ADE1D694F41FC71A831D0268E9891562113D8A62ADD1BF")
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		generateSecret(q, x, sha256.New, hash, func(*big.Int) bool { return true })
+	}
+}
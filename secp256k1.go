@@ -0,0 +1,44 @@
+package rfc6979
+
+import (
+	"crypto/elliptic"
+	"math/big"
+	"sync"
+
+	"github.com/nspcc-dev/rfc6979/internal/weierstrass"
+)
+
+var (
+	secp256k1Once sync.Once
+	secp256k1     *weierstrass.Curve
+)
+
+func initSecp256k1() {
+	p := &elliptic.CurveParams{Name: "secp256k1", BitSize: 256}
+	p.P, _ = new(big.Int).SetString("FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFEFFFFFC2F", 16)
+	p.N, _ = new(big.Int).SetString("FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFEBAAEDCE6AF48A03BBFD25E8CD0364141", 16)
+	p.B = big.NewInt(7)
+	p.Gx, _ = new(big.Int).SetString("79BE667EF9DCBBAC55A06295CE870B07029BFCDB2DCE28D959F2815B16F81798", 16)
+	p.Gy, _ = new(big.Int).SetString("483ADA7726A3C4655DA4FBFC0E1108A8FD17B448A68554199C47D08FFB10D4B8", 16)
+	secp256k1 = weierstrass.New(p, new(big.Int))
+}
+
+// Secp256k1 returns an elliptic.Curve implementing secp256k1 (SEC 2 section
+// 2.4.1), y² = x³ + 7, used by Bitcoin and Ethereum. It can be used with
+// SignECDSA and crypto/ecdsa.Verify. Its scalar multiplications are
+// constant-time, like the ones of the standard library curves. Signing only
+// uses the curve to compute the nonce point, so any other elliptic.Curve
+// implementation of secp256k1, e.g. a faster one backed by a dedicated
+// library, can be passed to SignECDSA instead and yields the same signatures;
+// see IsSecp256k1. Multiple invocations return the same value.
+func Secp256k1() elliptic.Curve {
+	secp256k1Once.Do(initSecp256k1)
+	return secp256k1
+}
+
+// IsSecp256k1 reports whether c has the domain parameters of secp256k1,
+// whatever its implementation. Functions requiring secp256k1 keys use it, so
+// they accept keys on any secp256k1 backend, not just Secp256k1.
+func IsSecp256k1(c elliptic.Curve) bool {
+	return c != nil && sameCurve(c, Secp256k1())
+}
@@ -0,0 +1,142 @@
+package rfc6979_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/sha256"
+	"math/big"
+	"testing"
+
+	"github.com/nspcc-dev/rfc6979"
+)
+
+// secp256k1Key returns the secp256k1 key with the private scalar d, given in
+// hex.
+func secp256k1Key(d string) *ecdsa.PrivateKey {
+	c := rfc6979.Secp256k1()
+	priv := &ecdsa.PrivateKey{D: ecdsaLoadInt(d)}
+	priv.PublicKey.Curve = c
+	priv.PublicKey.X, priv.PublicKey.Y = c.ScalarBaseMult(priv.D.Bytes())
+	return priv
+}
+
+// otherSecp256k1 stands for another implementation of secp256k1, a distinct
+// elliptic.Curve with the same parameters.
+type otherSecp256k1 struct {
+	elliptic.Curve
+}
+
+// secp256k1Vectors are low-S signatures of SHA-256 digests, cross-checked
+// with libsecp256k1 compatible implementations: bitcoinjs and the
+// decred secp256k1 port, whose ecdsa.Sign derives nonces as
+// secp256k1_nonce_function_rfc6979 does.
+var secp256k1Vectors = []struct {
+	d, message, r, s string
+}{
+	{
+		d:       "1",
+		message: "Satoshi Nakamoto",
+		r:       "934B1EA10A4B3C1757E2B0C017D0B6143CE3C9A7E6A4A49860D7A6AB210EE3D8",
+		s:       "2442CE9D2B916064108014783E923EC36B49743E2FFA1C4496F01A512AAFD9E5",
+	},
+	{
+		d:       "C9AFA9D845BA75166B5C215767B1D6934E50C3DB36E89B127B8A622B120F6721",
+		message: "sample",
+		r:       "432310E32CB80EB6503A26CE83CC165C783B870845FB8AAD6D970889FCD7A6C8",
+		s:       "530128B6B81C548874A6305D93ED071CA6E05074D85863D4056CE89B02BFAB69",
+	},
+	{
+		d:       "FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFEBAAEDCE6AF48A03BBFD25E8CD0364140",
+		message: "Satoshi Nakamoto",
+		r:       "FD567D121DB66E382991534ADA77A6BD3106F0A1098C231E47993447CD6AF2D0",
+		s:       "6B39CD0EB1BC8603E159EF5C20A5C8AD685A45B06CE9BEBED3F153D10D93BED5",
+	},
+	{
+		d:       "1",
+		message: "All those moments will be lost in time, like tears in rain. Time to die...",
+		r:       "8600DBD41E348FE5C9465AB92D23E3DB8B98B873BEECD930736488696438CB6B",
+		s:       "547FE64427496DB33BF66019DACBF0039C04199ABB0122918601DB38A72CFC21",
+	},
+	{
+		d:       "2",
+		message: "test",
+		r:       "32D84FC48BA1D3787C3618B99D5F265D3C8F265BA75F34BF25DFE8D235B6D495",
+		s:       "7C2FC5920E461C98A5343676EB2C183164CC5155F63A2078BF34D1814071E9AD",
+	},
+	{
+		d:       "FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFEBAAEDCE6AF48A03BBFD25E8CD0364140",
+		message: "sample",
+		r:       "CC7C4B3EAD174E1DCC27848877ADB23E41DF74E365F5A8AC7106B930E061F0D2",
+		s:       "27916DEB83F42167970AB2EFAB2787323875D5E7FBB033DC1950EC2F4869FDBA",
+	},
+	{
+		d:       "E91671C46231F833A6406CCBEA0E3E392C76C167BAC1CB013F6F1013980455C2",
+		message: "Satoshi Nakamoto",
+		r:       "8AB5F3BD7E4BFE8CBB5DD40BF4515542366CAAF0EC91B97DFC54A6C630A44B26",
+		s:       "1A90CDD722B61D7DDAB1FCEB4FAC96A2B7562D506C31CEEB45338923CBBD23EC",
+	},
+}
+
+func TestSecp256k1(t *testing.T) {
+	c := rfc6979.Secp256k1()
+	params := c.Params()
+
+	if !c.IsOnCurve(params.Gx, params.Gy) {
+		t.Fatal("Expected generator to be on the curve")
+	}
+	if x, y := c.ScalarBaseMult(params.N.Bytes()); x.Sign() != 0 || y.Sign() != 0 {
+		t.Errorf("Expected N·G to be the point at infinity, got (%X, %X)", x, y)
+	}
+
+	// (N-1)·G = -G
+	nMinus1 := new(big.Int).Sub(params.N, big.NewInt(1))
+	x, y := c.ScalarBaseMult(nMinus1.Bytes())
+	if x.Cmp(params.Gx) != 0 || new(big.Int).Add(y, params.Gy).Cmp(params.P) != 0 {
+		t.Errorf("Expected (N-1)·G to be -G")
+	}
+
+	dx, dy := c.Double(params.Gx, params.Gy)
+	ax, ay := c.Add(params.Gx, params.Gy, params.Gx, params.Gy)
+	tx, ty := c.ScalarBaseMult([]byte{2})
+	if dx.Cmp(ax) != 0 || dy.Cmp(ay) != 0 || dx.Cmp(tx) != 0 || dy.Cmp(ty) != 0 {
+		t.Errorf("Expected 2·G to be consistent")
+	}
+	if !c.IsOnCurve(dx, dy) {
+		t.Errorf("Expected 2·G to be on the curve")
+	}
+}
+
+func TestSecp256k1Vectors(t *testing.T) {
+	for _, v := range secp256k1Vectors {
+		priv := secp256k1Key(v.d)
+		digest := sha256.Sum256([]byte(v.message))
+
+		r, s := rfc6979.SignECDSAWithOptions(priv, digest[:], sha256.New, &rfc6979.Options{LowS: true})
+		if r.Cmp(ecdsaLoadInt(v.r)) != 0 || s.Cmp(ecdsaLoadInt(v.s)) != 0 {
+			t.Errorf("%s/%q: Expected (%s, %s), got (%X, %X)", v.d, v.message, v.r, v.s, r, s)
+		}
+		if !ecdsa.Verify(&priv.PublicKey, digest[:], r, s) {
+			t.Errorf("%s/%q: Invalid signature", v.d, v.message)
+		}
+	}
+}
+
+func TestIsSecp256k1(t *testing.T) {
+	params := *rfc6979.Secp256k1().Params()
+	params.B = big.NewInt(5)
+
+	for _, v := range []struct {
+		c        elliptic.Curve
+		expected bool
+	}{
+		{rfc6979.Secp256k1(), true},
+		{otherSecp256k1{rfc6979.Secp256k1()}, true},
+		{elliptic.P256(), false},
+		{&params, false},
+		{nil, false},
+	} {
+		if res := rfc6979.IsSecp256k1(v.c); res != v.expected {
+			t.Errorf("%T: Expected %t, got %t", v.c, v.expected, res)
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package rfc6979_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/sha3"
+	"hash"
+	"testing"
+
+	"github.com/nspcc-dev/rfc6979"
+)
+
+var sha3Algs = []struct {
+	name string
+	alg  func() hash.Hash
+}{
+	{"SHA3-224", func() hash.Hash { return sha3.New224() }},
+	{"SHA3-256", func() hash.Hash { return sha3.New256() }},
+	{"SHA3-384", func() hash.Hash { return sha3.New384() }},
+	{"SHA3-512", func() hash.Hash { return sha3.New512() }},
+}
+
+// Cross-checks SignECDSA against a manual computation of (r, s) from the
+// nonce returned by GenerateK for every NIST curve and SHA-3 variant.
+func TestECDSASHA3(t *testing.T) {
+	for _, key := range []*ecdsaKey{p224, p256, p384, p521} {
+		for _, a := range sha3Algs {
+			for _, message := range []string{"sample", "test"} {
+				name := key.key.Curve.Params().Name + "/" + a.name + "/" + message
+
+				h := a.alg()
+				h.Write([]byte(message))
+				digest := h.Sum(nil)
+
+				r, s := rfc6979.SignECDSA(key.key, digest, a.alg)
+				expectedR, expectedS := manualECDSA(key.key, digest, a.alg)
+
+				if r.Cmp(expectedR) != 0 {
+					t.Errorf("%s: Expected R of %X, got %X", name, expectedR, r)
+				}
+
+				if s.Cmp(expectedS) != 0 {
+					t.Errorf("%s: Expected S of %X, got %X", name, expectedS, s)
+				}
+
+				if !ecdsa.Verify(&key.key.PublicKey, digest, r, s) {
+					t.Errorf("%s: Invalid signature", name)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,214 @@
+package rfc6979
+
+import (
+	"crypto/elliptic"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"strings"
+)
+
+// ErrInvalidJSON is returned when a JSON signature can't be decoded.
+var ErrInvalidJSON = errors.New("rfc6979: invalid JSON signature")
+
+// ErrInvalidText is returned when a hex or base64url signature can't be
+// decoded.
+var ErrInvalidText = errors.New("rfc6979: invalid textual signature")
+
+// Signature is an (EC)DSA signature. Curve is the curve an ECDSA signature
+// was made on, it's nil for DSA signatures and must be set for marshaling.
+type Signature struct {
+	R, S  *big.Int
+	Curve elliptic.Curve
+}
+
+// namedCurves lists the curves Signature can be unmarshaled for, they're
+// identified by their names.
+var namedCurves = []func() elliptic.Curve{
+	elliptic.P224,
+	elliptic.P256,
+	elliptic.P384,
+	elliptic.P521,
+	Secp256k1,
+	BrainpoolP256r1,
+	BrainpoolP384r1,
+	BrainpoolP512r1,
+	BrainpoolP256t1,
+	BrainpoolP384t1,
+	BrainpoolP512t1,
+	FRP256v1,
+}
+
+// curveByName returns the curve with the given name or nil if it's unknown.
+func curveByName(name string) elliptic.Curve {
+	for _, newCurve := range namedCurves {
+		if c := newCurve(); c.Params().Name == name {
+			return c
+		}
+	}
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler, encoding the signature
+// with MarshalVersioned, so the curve is preserved.
+func (sig Signature) MarshalBinary() ([]byte, error) {
+	if sig.Curve == nil || sig.R == nil || sig.S == nil {
+		return nil, ErrInvalidVersioned
+	}
+	return MarshalVersioned(sig.Curve, sig.R, sig.S)
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler, decoding the output
+// of MarshalBinary.
+func (sig *Signature) UnmarshalBinary(data []byte) error {
+	c, r, s, err := UnmarshalVersioned(data)
+	if err != nil {
+		return err
+	}
+	sig.R, sig.S, sig.Curve = r, s, c
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler, encoding the output of
+// MarshalBinary as lowercase hex.
+func (sig Signature) MarshalText() ([]byte, error) {
+	data, err := sig.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	out := make([]byte, hex.EncodedLen(len(data)))
+	hex.Encode(out, data)
+	return out, nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, decoding the output of
+// MarshalText. Both hex cases are accepted.
+func (sig *Signature) UnmarshalText(text []byte) error {
+	data := make([]byte, hex.DecodedLen(len(text)))
+	if _, err := hex.Decode(data, text); err != nil {
+		return ErrInvalidVersioned
+	}
+	return sig.UnmarshalBinary(data)
+}
+
+// jsonSignature is the JSON form of Signature.
+type jsonSignature struct {
+	Curve string `json:"curve"`
+	R     string `json:"r"`
+	S     string `json:"s"`
+}
+
+// MarshalJSON implements json.Marshaler. The signature is encoded as an
+// object with the curve name, e.g. "P-256" or "secp256k1", and r and s as
+// lowercase hex, left-padded with zeros to the byte length of the curve
+// order:
+//
+//	{"curve":"P-256","r":"efd4...","s":"f7cb..."}
+func (sig Signature) MarshalJSON() ([]byte, error) {
+	if sig.Curve == nil || curveByName(sig.Curve.Params().Name) == nil {
+		return nil, ErrInvalidJSON
+	}
+	raw, err := encodeRaw(sig.R, sig.S, OrderSize(sig.Curve))
+	if err != nil {
+		return nil, err
+	}
+
+	size := len(raw) / 2
+	return json.Marshal(jsonSignature{
+		Curve: sig.Curve.Params().Name,
+		R:     hex.EncodeToString(raw[:size]),
+		S:     hex.EncodeToString(raw[size:]),
+	})
+}
+
+// UnmarshalJSON implements json.Unmarshaler, decoding the output of
+// MarshalJSON. The curve must be known and r and s must be exactly as long
+// as MarshalJSON makes them.
+func (sig *Signature) UnmarshalJSON(data []byte) error {
+	var v jsonSignature
+	if err := json.Unmarshal(data, &v); err != nil {
+		return ErrInvalidJSON
+	}
+
+	c := curveByName(v.Curve)
+	if c == nil {
+		return ErrInvalidJSON
+	}
+	size := OrderSize(c)
+	r, err := hex.DecodeString(v.R)
+	if err != nil || len(r) != size {
+		return ErrInvalidJSON
+	}
+	s, err := hex.DecodeString(v.S)
+	if err != nil || len(s) != size {
+		return ErrInvalidJSON
+	}
+
+	sig.R, sig.S, sig.Curve = new(big.Int).SetBytes(r), new(big.Int).SetBytes(s), c
+	return nil
+}
+
+// HexString returns the signature as hex of r || s, the IEEE P1363 format
+// sized to the curve order. The empty string is returned if the signature
+// can't be encoded, i.e. Curve is nil or a component is outside [1, N-1].
+func (sig Signature) HexString() string {
+	raw, err := sig.compact()
+	if err != nil {
+		return ""
+	}
+	return hex.EncodeToString(raw)
+}
+
+// Base64URL is like HexString, but uses the unpadded base64url encoding, as
+// JWS does for ECDSA signatures.
+func (sig Signature) Base64URL() string {
+	raw, err := sig.compact()
+	if err != nil {
+		return ""
+	}
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+// compact returns r || s, validating the signature.
+func (sig Signature) compact() ([]byte, error) {
+	if sig.Curve == nil {
+		return nil, ErrInvalidCompact
+	}
+	return MarshalCompact(sig.Curve, sig.R, sig.S)
+}
+
+// ParseSignatureHex decodes the output of HexString for the curve c. Both
+// hex cases are accepted, but nothing else: no prefix, whitespace or
+// separators. The length must be exactly twice the byte length of the curve
+// order, hex-encoded, and both components must be within [1, N-1].
+func ParseSignatureHex(c elliptic.Curve, s string) (Signature, error) {
+	raw, err := hex.DecodeString(s)
+	if err != nil {
+		return Signature{}, ErrInvalidText
+	}
+	return parseCompact(c, raw)
+}
+
+// ParseSignatureBase64URL decodes the output of Base64URL for the curve c.
+// Padding, characters outside the base64url alphabet and non-zero trailing
+// bits are rejected, so are line breaks, which encoding/base64 would
+// otherwise skip. The length and the components are validated like by
+// ParseSignatureHex.
+func ParseSignatureBase64URL(c elliptic.Curve, s string) (Signature, error) {
+	raw, err := base64.RawURLEncoding.Strict().DecodeString(s)
+	if err != nil || strings.ContainsAny(s, "\r\n") {
+		return Signature{}, ErrInvalidText
+	}
+	return parseCompact(c, raw)
+}
+
+// parseCompact decodes r || s for the curve c.
+func parseCompact(c elliptic.Curve, raw []byte) (Signature, error) {
+	r, s, err := UnmarshalCompact(c, raw)
+	if err != nil {
+		return Signature{}, ErrInvalidText
+	}
+	return Signature{R: r, S: s, Curve: c}, nil
+}
@@ -0,0 +1,154 @@
+package rfc6979_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/nspcc-dev/rfc6979"
+)
+
+func TestSignatureMarshaling(t *testing.T) {
+	digest := sha256.Sum256([]byte("sample"))
+	keys := []*ecdsa.PrivateKey{p224.key, p256.key, p384.key, p521.key, secp256k1Key(secp256k1Vectors[1].d), frp256v1.key}
+
+	for _, key := range keys {
+		name := key.Curve.Params().Name
+		r, s := rfc6979.SignECDSA(key, digest[:], sha256.New)
+		sig := rfc6979.Signature{R: r, S: s, Curve: key.Curve}
+
+		check := func(format string, got rfc6979.Signature, err error) {
+			if err != nil {
+				t.Errorf("%s, %s: %v", name, format, err)
+			} else if got.Curve != key.Curve || got.R.Cmp(r) != 0 || got.S.Cmp(s) != 0 {
+				t.Errorf("%s, %s: Expected round trip, got %+v", name, format, got)
+			}
+		}
+
+		if key.Curve != rfc6979.Secp256k1() && key.Curve != rfc6979.FRP256v1() {
+			data, err := sig.MarshalBinary()
+			if err != nil {
+				t.Fatalf("%s: %v", name, err)
+			}
+			var got rfc6979.Signature
+			err = got.UnmarshalBinary(data)
+			check("binary", got, err)
+
+			text, err := sig.MarshalText()
+			if err != nil {
+				t.Fatalf("%s: %v", name, err)
+			}
+			got = rfc6979.Signature{}
+			err = got.UnmarshalText([]byte(strings.ToUpper(string(text))))
+			check("text", got, err)
+		}
+
+		data, err := json.Marshal(sig)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if !strings.Contains(string(data), `"curve":"`+name+`"`) {
+			t.Errorf("%s: Unexpected JSON %s", name, data)
+		}
+		var got rfc6979.Signature
+		err = json.Unmarshal(data, &got)
+		check("JSON", got, err)
+	}
+}
+
+func TestSignatureJSON(t *testing.T) {
+	r, s := ecdsaLoadInt("EFD48B2AACB6A8FD1140DD9CD45E81D69D2C877B56AAF991C34D0EA84EAF3716"), ecdsaLoadInt("F7CB1C942D657C41D436C7A1B6E29F65F3E900DBB9AFF4064DC4AB2F843ACDA8")
+	sig := rfc6979.Signature{R: r, S: s, Curve: p256.key.Curve}
+
+	data, err := json.Marshal(sig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `{"curve":"P-256","r":"efd48b2aacb6a8fd1140dd9cd45e81d69d2c877b56aaf991c34d0ea84eaf3716","s":"f7cb1c942d657c41d436c7a1b6e29f65f3e900dbb9aff4064dc4ab2f843acda8"}`
+	if string(data) != expected {
+		t.Errorf("Expected %s, got %s", expected, data)
+	}
+
+	for _, bad := range []string{
+		`[]`,
+		`{"curve":"P-192","r":"01","s":"01"}`,
+		`{"curve":"P-256","r":"01","s":"01"}`,
+		`{"curve":"P-256","r":"` + strings.Repeat("zz", 32) + `","s":"` + strings.Repeat("00", 32) + `"}`,
+	} {
+		var got rfc6979.Signature
+		if err := json.Unmarshal([]byte(bad), &got); err != rfc6979.ErrInvalidJSON {
+			t.Errorf("%s: Expected %v, got %v", bad, rfc6979.ErrInvalidJSON, err)
+		}
+	}
+
+	if _, err := json.Marshal(rfc6979.Signature{R: r, S: s}); err == nil {
+		t.Errorf("Expected an error without a curve")
+	}
+	if _, err := (rfc6979.Signature{R: r, S: s}).MarshalBinary(); err != rfc6979.ErrInvalidVersioned {
+		t.Errorf("Expected %v, got %v", rfc6979.ErrInvalidVersioned, err)
+	}
+}
+
+func TestSignatureText(t *testing.T) {
+	for _, f := range fixtures {
+		c := f.key.key.Curve
+		sig := rfc6979.Signature{R: ecdsaLoadInt(f.r), S: ecdsaLoadInt(f.s), Curve: c}
+		raw, _ := rfc6979.EncodeP1363(c, sig.R, sig.S)
+
+		h := sig.HexString()
+		if h != hex.EncodeToString(raw) {
+			t.Errorf("%s: Unexpected hex %s", f.name, h)
+		}
+		got, err := rfc6979.ParseSignatureHex(c, strings.ToUpper(h))
+		if err != nil || got.R.Cmp(sig.R) != 0 || got.S.Cmp(sig.S) != 0 || got.Curve != c {
+			t.Errorf("%s: Expected hex round trip, got %v", f.name, err)
+		}
+
+		b := sig.Base64URL()
+		if b != base64.RawURLEncoding.EncodeToString(raw) {
+			t.Errorf("%s: Unexpected base64url %s", f.name, b)
+		}
+		got, err = rfc6979.ParseSignatureBase64URL(c, b)
+		if err != nil || got.R.Cmp(sig.R) != 0 || got.S.Cmp(sig.S) != 0 || got.Curve != c {
+			t.Errorf("%s: Expected base64url round trip, got %v", f.name, err)
+		}
+
+		for _, bad := range []string{"", "0x" + h, h + "00", h[2:], h[:10] + " " + h[10:], h[:len(h)-2] + "zz"} {
+			if _, err := rfc6979.ParseSignatureHex(c, bad); err != rfc6979.ErrInvalidText {
+				t.Errorf("%s: %q: Expected %v, got %v", f.name, bad, rfc6979.ErrInvalidText, err)
+			}
+		}
+		padded := base64.URLEncoding.EncodeToString(raw)
+		for _, bad := range []string{"", b + "A", b[:10] + "\n" + b[10:], b[:len(b)-1] + "+"} {
+			if _, err := rfc6979.ParseSignatureBase64URL(c, bad); err != rfc6979.ErrInvalidText {
+				t.Errorf("%s: %q: Expected %v, got %v", f.name, bad, rfc6979.ErrInvalidText, err)
+			}
+		}
+		if padded != b {
+			if _, err := rfc6979.ParseSignatureBase64URL(c, padded); err != rfc6979.ErrInvalidText {
+				t.Errorf("%s: Expected padding to be rejected, got %v", f.name, err)
+			}
+		}
+	}
+
+	// 64 bytes don't fill the last base64 quantum, its spare bits must be 0.
+	b := strings.Repeat("A", 85) + "B"
+	if _, err := rfc6979.ParseSignatureBase64URL(p256.key.Curve, b); err != rfc6979.ErrInvalidText {
+		t.Errorf("Expected non-zero trailing bits to be rejected, got %v", err)
+	}
+
+	if s := (rfc6979.Signature{R: ecdsaLoadInt("01"), S: ecdsaLoadInt("01")}).HexString(); s != "" {
+		t.Errorf("Expected no encoding without a curve, got %s", s)
+	}
+	N := p256.key.Curve.Params().N
+	if s := (rfc6979.Signature{R: N, S: N, Curve: p256.key.Curve}).Base64URL(); s != "" {
+		t.Errorf("Expected no encoding for out of range components, got %s", s)
+	}
+	if _, err := rfc6979.ParseSignatureHex(p256.key.Curve, strings.Repeat("00", 64)); err != rfc6979.ErrInvalidText {
+		t.Errorf("Expected zero components to be rejected, got %v", err)
+	}
+}
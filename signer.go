@@ -0,0 +1,45 @@
+package rfc6979
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"errors"
+	"io"
+)
+
+// ErrUnsupportedHash is returned when the hash function requested by
+// crypto.SignerOpts is unknown or not linked into the binary.
+var ErrUnsupportedHash = errors.New("rfc6979: unsupported hash function")
+
+// Signer is a crypto.Signer producing deterministic ECDSA signatures, so it
+// can be used with crypto/tls, crypto/x509 and other packages accepting a
+// crypto.Signer.
+type Signer struct {
+	priv *ecdsa.PrivateKey
+}
+
+// NewSigner returns a Signer using the private key, priv.
+func NewSigner(priv *ecdsa.PrivateKey) *Signer {
+	return &Signer{priv: priv}
+}
+
+// Public implements crypto.Signer, returning the *ecdsa.PublicKey of the
+// private key.
+func (s *Signer) Public() crypto.PublicKey {
+	return &s.priv.PublicKey
+}
+
+// Sign implements crypto.Signer. The digest must be the result of hashing
+// with opts.HashFunc(), which is also used by the nonce generator; it must be
+// available, see crypto.Hash.Available. rand is ignored, the signature only
+// depends on the key and the digest. The signature is DER encoded, the same
+// way crypto/ecdsa does it.
+func (s *Signer) Sign(rand io.Reader, digest []byte, opts crypto.SignerOpts) ([]byte, error) {
+	h := opts.HashFunc()
+	if h == 0 || !h.Available() {
+		return nil, ErrUnsupportedHash
+	}
+
+	r, sig := SignECDSA(s.priv, digest, h.New)
+	return MarshalSignatureDER(r, sig)
+}
@@ -0,0 +1,89 @@
+package rfc6979_test
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/nspcc-dev/rfc6979"
+)
+
+// The interface is satisfied.
+var _ crypto.Signer = (*rfc6979.Signer)(nil)
+
+func TestSigner(t *testing.T) {
+	for _, f := range fixtures {
+		h := f.alg()
+		h.Write([]byte(f.message))
+		digest := h.Sum(nil)
+
+		var hf crypto.Hash
+		switch h.Size() {
+		case 20:
+			hf = crypto.SHA1
+		case 28:
+			hf = crypto.SHA224
+		case 32:
+			hf = crypto.SHA256
+		case 48:
+			hf = crypto.SHA384
+		case 64:
+			hf = crypto.SHA512
+		}
+
+		signer := rfc6979.NewSigner(f.key.key)
+		sig, err := signer.Sign(rand.Reader, digest, hf)
+		if err != nil {
+			t.Fatalf("%s: %v", f.name, err)
+		}
+
+		expected, _ := rfc6979.MarshalSignatureDER(ecdsaLoadInt(f.r), ecdsaLoadInt(f.s))
+		if string(sig) != string(expected) {
+			t.Errorf("%s: Expected %X, got %X", f.name, expected, sig)
+		}
+		if !ecdsa.VerifyASN1(signer.Public().(*ecdsa.PublicKey), digest, sig) {
+			t.Errorf("%s: Invalid signature", f.name)
+		}
+	}
+
+	digest := sha256.Sum256([]byte("sample"))
+	if _, err := rfc6979.NewSigner(p256.key).Sign(nil, digest[:], crypto.Hash(0)); err != rfc6979.ErrUnsupportedHash {
+		t.Errorf("Expected %v, got %v", rfc6979.ErrUnsupportedHash, err)
+	}
+}
+
+func TestSignerCertificate(t *testing.T) {
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "rfc6979"},
+		NotBefore:    time.Unix(0, 0),
+		NotAfter:     time.Unix(1<<31, 0),
+	}
+
+	signer := rfc6979.NewSigner(p256.key)
+	der1, err := x509.CreateCertificate(rand.Reader, template, template, signer.Public(), signer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der2, err := x509.CreateCertificate(rand.Reader, template, template, signer.Public(), signer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(der1) != string(der2) {
+		t.Errorf("Expected certificates to be deterministic")
+	}
+
+	cert, err := x509.ParseCertificate(der1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := cert.CheckSignature(cert.SignatureAlgorithm, cert.RawTBSCertificate, cert.Signature); err != nil {
+		t.Error(err)
+	}
+}
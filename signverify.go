@@ -0,0 +1,38 @@
+package rfc6979
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"hash"
+	"math/big"
+)
+
+// ErrFault is returned when a freshly made signature fails to verify, which
+// means a fault, natural or induced, corrupted the computation.
+var ErrFault = errors.New("rfc6979: signature failed self-verification")
+
+// SignAndVerify signs the hash using the private key, priv, like SignECDSA,
+// then verifies the signature against the public key of priv before returning
+// it, as fault attack countermeasures require. If the check fails, no
+// signature is returned, just ErrFault, since a faulty signature may leak the
+// key. A corrupted D is detected as well, provided the public key is intact.
+//
+// The signature is checked with crypto/ecdsa.VerifyASN1, like VerifyECDSA
+// does, its DER encoding being built in a local buffer instead of the fresh
+// slices ecdsa.Verify allocates, so the combined call allocates less than
+// SignECDSA followed by ecdsa.Verify.
+func SignAndVerify(priv *ecdsa.PrivateKey, hash []byte, alg func() hash.Hash) (Signature, error) {
+	r, s := new(big.Int), new(big.Int)
+	signECDSA(priv, hash, alg, nil, r, s, nil)
+	if r.Sign() == 0 || s.Sign() == 0 {
+		return Signature{}, ErrFault
+	}
+
+	// Large enough for the DER encoding of any supported order, up to the
+	// 571-bit binary curves.
+	var buf [160]byte
+	if !ecdsa.VerifyASN1(&priv.PublicKey, hash, appendSignatureDER(buf[:0], r, s, 0)) {
+		return Signature{}, ErrFault
+	}
+	return Signature{R: r, S: s, Curve: priv.Curve}, nil
+}
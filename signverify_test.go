@@ -0,0 +1,103 @@
+package rfc6979_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/sha256"
+	"flag"
+	"math/big"
+	"testing"
+
+	"github.com/nspcc-dev/rfc6979"
+)
+
+// faultyCurve flips the lowest bit of the x coordinate returned by the first
+// ScalarBaseMult, which is the nonce point computed while signing.
+type faultyCurve struct {
+	elliptic.Curve
+	done bool
+}
+
+func (c *faultyCurve) ScalarBaseMult(k []byte) (x, y *big.Int) {
+	x, y = c.Curve.ScalarBaseMult(k)
+	if !c.done {
+		c.done = true
+		x = new(big.Int).Xor(x, big.NewInt(1))
+	}
+	return x, y
+}
+
+func TestSignAndVerify(t *testing.T) {
+	for _, f := range fixtures {
+		h := f.alg()
+		h.Write([]byte(f.message))
+		digest := h.Sum(nil)
+
+		sig, err := rfc6979.SignAndVerify(f.key.key, digest, f.alg)
+		if err != nil {
+			t.Fatalf("%s: %v", f.name, err)
+		}
+		if sig.R.Cmp(ecdsaLoadInt(f.r)) != 0 || sig.S.Cmp(ecdsaLoadInt(f.s)) != 0 {
+			t.Errorf("%s: Expected the RFC signature", f.name)
+		}
+	}
+
+	key := *p256.key
+	key.Curve = &faultyCurve{Curve: key.Curve}
+	digest := sha256.Sum256([]byte("sample"))
+	if sig, err := rfc6979.SignAndVerify(&key, digest[:], sha256.New); err != rfc6979.ErrFault || sig.R != nil || sig.S != nil {
+		t.Errorf("Expected %v and no signature, got %v", rfc6979.ErrFault, err)
+	}
+
+	// A corrupted private scalar yields a valid signature for another key,
+	// which the public key must reject.
+	key = *p256.key
+	key.D = new(big.Int).Xor(key.D, big.NewInt(1))
+	if sig, err := rfc6979.SignAndVerify(&key, digest[:], sha256.New); err != rfc6979.ErrFault || sig.R != nil || sig.S != nil {
+		t.Errorf("Corrupted D: expected %v and no signature, got %v", rfc6979.ErrFault, err)
+	}
+}
+
+func TestSignAndVerifyAllocs(t *testing.T) {
+	// Allocation counts depend on the Go release and on crypto/ecdsa
+	// internals, so only compare them when benchmarks are requested.
+	if f := flag.Lookup("test.bench"); f == nil || f.Value.String() == "" {
+		t.Skip("run with -bench to compare allocations")
+	}
+	digest := sha256.Sum256([]byte("sample"))
+	combined := testing.AllocsPerRun(10, func() {
+		_, _ = rfc6979.SignAndVerify(p256.key, digest[:], sha256.New)
+	})
+	separate := testing.AllocsPerRun(10, func() {
+		r, s := rfc6979.SignECDSA(p256.key, digest[:], sha256.New)
+		ecdsa.Verify(&p256.key.PublicKey, digest[:], r, s)
+	})
+	if combined >= separate {
+		t.Errorf("Expected less than %v allocations, got %v", separate, combined)
+	}
+}
+
+// The combined call encodes the signature for crypto/ecdsa in a local buffer,
+// so it must allocate less than the separate calls.
+func BenchmarkSignAndVerify(b *testing.B) {
+	digest := sha256.Sum256([]byte("sample"))
+
+	b.Run("combined", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := rfc6979.SignAndVerify(p256.key, digest[:], sha256.New); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("separate", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			r, s := rfc6979.SignECDSA(p256.key, digest[:], sha256.New)
+			if !ecdsa.Verify(&p256.key.PublicKey, digest[:], r, s) {
+				b.Fatal("invalid signature")
+			}
+		}
+	})
+}
@@ -0,0 +1,302 @@
+/*
+Package sshsig produces OpenSSH signatures (the SSHSIG format of OpenSSH's
+PROTOCOL.sshsig) with ECDSA keys, using RFC 6979 deterministic signatures.
+They're compatible with "ssh-keygen -Y sign" and "ssh-keygen -Y verify", so
+they can be used for git commits and file signing, but unlike the ones made
+by ssh-keygen, signatures over the same message are identical.
+*/
+package sshsig
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	_ "crypto/sha256" // nistp256, sha256
+	_ "crypto/sha512" // nistp384, nistp521, sha512
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"math/big"
+	"strings"
+
+	"github.com/nspcc-dev/rfc6979"
+)
+
+const (
+	magic   = "SSHSIG"
+	version = 1
+
+	header = "-----BEGIN SSH SIGNATURE-----"
+	footer = "-----END SSH SIGNATURE-----"
+
+	// lineLength is the width of armored lines, as ssh-keygen makes them.
+	lineLength = 70
+)
+
+var (
+	// ErrInvalidKey is returned for keys on curves OpenSSH doesn't support,
+	// only P-256, P-384 and P-521 are.
+	ErrInvalidKey = errors.New("sshsig: invalid key")
+
+	// ErrInvalidHash is returned for message hashes other than SHA-256
+	// and SHA-512.
+	ErrInvalidHash = errors.New("sshsig: invalid hash")
+
+	// ErrInvalidNamespace is returned for empty namespaces and signatures
+	// made for a different one.
+	ErrInvalidNamespace = errors.New("sshsig: invalid namespace")
+
+	// ErrInvalidSignature is returned for malformed signatures.
+	ErrInvalidSignature = errors.New("sshsig: invalid signature")
+)
+
+// curve describes an OpenSSH ECDSA key type, RFC 5656 section 6.2.
+type curve struct {
+	curve func() elliptic.Curve
+	name  string
+	hash  crypto.Hash
+}
+
+// curves lists the supported key types.
+var curves = []curve{
+	{elliptic.P256, "nistp256", crypto.SHA256},
+	{elliptic.P384, "nistp384", crypto.SHA384},
+	{elliptic.P521, "nistp521", crypto.SHA512},
+}
+
+// lookup returns the key type of the curve c.
+func lookup(c elliptic.Curve) (curve, error) {
+	for _, k := range curves {
+		if k.curve().Params().Name == c.Params().Name {
+			return k, nil
+		}
+	}
+	return curve{}, ErrInvalidKey
+}
+
+// keyType returns the OpenSSH name of the key type.
+func (k curve) keyType() string {
+	return "ecdsa-sha2-" + k.name
+}
+
+// hashNames are the message hashes allowed by PROTOCOL.sshsig.
+var hashNames = map[crypto.Hash]string{
+	crypto.SHA256: "sha256",
+	crypto.SHA512: "sha512",
+}
+
+// AuthorizedKey returns the public key, pub, in the authorized_keys format,
+// e.g. "ecdsa-sha2-nistp256 AAAA...", as used in allowed signers files.
+func AuthorizedKey(pub *ecdsa.PublicKey) (string, error) {
+	k, err := lookup(pub.Curve)
+	if err != nil {
+		return "", err
+	}
+	return k.keyType() + " " + base64.StdEncoding.EncodeToString(marshalKey(k, pub)), nil
+}
+
+// marshalKey returns the wire encoding of the public key, RFC 5656 section
+// 3.1.
+func marshalKey(k curve, pub *ecdsa.PublicKey) []byte {
+	b := appendString(nil, []byte(k.keyType()))
+	b = appendString(b, []byte(k.name))
+	return appendString(b, elliptic.Marshal(pub.Curve, pub.X, pub.Y))
+}
+
+// Sign signs the message with the private key, priv, for the namespace,
+// e.g. "git" or "file", and returns the armored signature. The message is
+// hashed with h, SHA-256 or SHA-512 (ssh-keygen's default), the signature
+// itself uses the hash of the key's curve, as in RFC 5656.
+func Sign(priv *ecdsa.PrivateKey, namespace string, h crypto.Hash, message []byte) ([]byte, error) {
+	k, err := lookup(priv.Curve)
+	if err != nil {
+		return nil, err
+	}
+	hashName, ok := hashNames[h]
+	if !ok {
+		return nil, ErrInvalidHash
+	}
+	if namespace == "" {
+		return nil, ErrInvalidNamespace
+	}
+
+	r, s := rfc6979.SignECDSA(priv, digest(k.hash, signedData(namespace, hashName, h, message)), k.hash.New)
+	var sig []byte
+	sig = appendString(sig, []byte(k.keyType()))
+	sig = appendString(sig, appendMPInt(appendMPInt(nil, r), s))
+
+	blob := []byte(magic)
+	blob = appendUint32(blob, version)
+	blob = appendString(blob, marshalKey(k, &priv.PublicKey))
+	blob = appendString(blob, []byte(namespace))
+	blob = appendString(blob, nil)
+	blob = appendString(blob, []byte(hashName))
+	blob = appendString(blob, sig)
+	return armor(blob), nil
+}
+
+// Verify verifies an armored signature of the message made with the public
+// key, pub, for the namespace. The signature must be made with exactly
+// that key, ErrInvalidSignature is returned for malformed signatures and
+// ones made with other keys, rfc6979.ErrInvalidSignature for signatures that
+// don't match the message.
+func Verify(pub *ecdsa.PublicKey, namespace string, message, signature []byte) error {
+	k, err := lookup(pub.Curve)
+	if err != nil {
+		return err
+	}
+	blob, err := dearmor(signature)
+	if err != nil {
+		return err
+	}
+
+	if !bytes.HasPrefix(blob, []byte(magic)) {
+		return ErrInvalidSignature
+	}
+	d := decoder{b: blob[len(magic):]}
+	ver := d.uint32()
+	key := d.string()
+	ns := d.string()
+	d.string() // reserved
+	hashName := d.string()
+	sig := d.string()
+	if !d.done() || ver != version || !bytes.Equal(key, marshalKey(k, pub)) {
+		return ErrInvalidSignature
+	}
+	if string(ns) != namespace {
+		return ErrInvalidNamespace
+	}
+	var h crypto.Hash
+	for hash, name := range hashNames {
+		if name == string(hashName) {
+			h = hash
+		}
+	}
+	if h == 0 {
+		return ErrInvalidHash
+	}
+
+	d = decoder{b: sig}
+	sigType := d.string()
+	d = decoder{b: d.string()}
+	r := d.mpint()
+	s := d.mpint()
+	if !d.done() || string(sigType) != k.keyType() || r == nil || s == nil {
+		return ErrInvalidSignature
+	}
+	if !rfc6979.VerifyECDSA(pub, digest(k.hash, signedData(namespace, string(hashName), h, message)), r, s) {
+		return rfc6979.ErrInvalidSignature
+	}
+	return nil
+}
+
+// signedData returns the data signed for the message.
+func signedData(namespace, hashName string, h crypto.Hash, message []byte) []byte {
+	b := []byte(magic)
+	b = appendString(b, []byte(namespace))
+	b = appendString(b, nil)
+	b = appendString(b, []byte(hashName))
+	return appendString(b, digest(h, message))
+}
+
+// digest hashes the data with h.
+func digest(h crypto.Hash, data []byte) []byte {
+	d := h.New()
+	d.Write(data)
+	return d.Sum(nil)
+}
+
+// armor wraps the base64 encoded blob into the SSH SIGNATURE block.
+func armor(blob []byte) []byte {
+	enc := base64.StdEncoding.EncodeToString(blob)
+	var b strings.Builder
+	b.WriteString(header + "\n")
+	for len(enc) > lineLength {
+		b.WriteString(enc[:lineLength] + "\n")
+		enc = enc[lineLength:]
+	}
+	b.WriteString(enc + "\n" + footer + "\n")
+	return []byte(b.String())
+}
+
+// dearmor returns the blob of an SSH SIGNATURE block, which may be
+// surrounded by whitespace.
+func dearmor(data []byte) ([]byte, error) {
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) < 3 || strings.TrimSpace(lines[0]) != header || strings.TrimSpace(lines[len(lines)-1]) != footer {
+		return nil, ErrInvalidSignature
+	}
+	var enc strings.Builder
+	for _, l := range lines[1 : len(lines)-1] {
+		enc.WriteString(strings.TrimSpace(l))
+	}
+	blob, err := base64.StdEncoding.Strict().DecodeString(enc.String())
+	if err != nil {
+		return nil, ErrInvalidSignature
+	}
+	return blob, nil
+}
+
+// appendUint32 appends a big-endian uint32.
+func appendUint32(b []byte, v uint32) []byte {
+	var buf [4]byte
+	binary.BigEndian.PutUint32(buf[:], v)
+	return append(b, buf[:]...)
+}
+
+// appendString appends an SSH string, RFC 4251 section 5.
+func appendString(b, s []byte) []byte {
+	return append(appendUint32(b, uint32(len(s))), s...)
+}
+
+// appendMPInt appends a non-negative SSH mpint, RFC 4251 section 5.
+func appendMPInt(b []byte, v *big.Int) []byte {
+	raw := v.Bytes()
+	if len(raw) > 0 && raw[0]&0x80 != 0 {
+		raw = append([]byte{0}, raw...)
+	}
+	return appendString(b, raw)
+}
+
+// decoder reads SSH wire types, it stops at the first error.
+type decoder struct {
+	b   []byte
+	err bool
+}
+
+func (d *decoder) uint32() uint32 {
+	if d.err || len(d.b) < 4 {
+		d.err = true
+		return 0
+	}
+	v := binary.BigEndian.Uint32(d.b)
+	d.b = d.b[4:]
+	return v
+}
+
+func (d *decoder) string() []byte {
+	n := d.uint32()
+	if d.err || uint64(len(d.b)) < uint64(n) {
+		d.err = true
+		return nil
+	}
+	s := d.b[:n]
+	d.b = d.b[n:]
+	return s
+}
+
+// mpint reads a positive mpint in its minimal encoding, nil is returned for
+// others.
+func (d *decoder) mpint() *big.Int {
+	raw := d.string()
+	if len(raw) == 0 || raw[0]&0x80 != 0 || (raw[0] == 0 && (len(raw) == 1 || raw[1]&0x80 == 0)) {
+		return nil
+	}
+	return new(big.Int).SetBytes(raw)
+}
+
+// done reports whether everything was read without errors.
+func (d *decoder) done() bool {
+	return !d.err && len(d.b) == 0
+}
@@ -0,0 +1,135 @@
+package sshsig_test
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/nspcc-dev/rfc6979"
+	"github.com/nspcc-dev/rfc6979/sshsig"
+)
+
+func newKey(c elliptic.Curve, d string) *ecdsa.PrivateKey {
+	priv := &ecdsa.PrivateKey{D: new(big.Int).SetBytes([]byte(d))}
+	priv.Curve = c
+	priv.X, priv.Y = c.ScalarBaseMult(priv.D.Bytes())
+	return priv
+}
+
+var (
+	key256 = newKey(elliptic.P256(), "some fixed key material 256")
+	key384 = newKey(elliptic.P384(), "some fixed key material 384")
+)
+
+var message = []byte("hello\n")
+
+func TestAuthorizedKey(t *testing.T) {
+	for _, tc := range []struct {
+		key      *ecdsa.PrivateKey
+		expected string
+	}{
+		{key256, "ecdsa-sha2-nistp256 AAAAE2VjZHNhLXNoYTItbmlzdHAyNTYAAAAIbmlzdHAyNTYAAABBBK1oRRH1LcOg2vRZ8U7eCSzN+OD0lJe1o+rm2EdxO9SDV1omMMcENkXys6MhVyexr62EFnaBswb51NX0meJbOWI="},
+		{key384, "ecdsa-sha2-nistp384 AAAAE2VjZHNhLXNoYTItbmlzdHAzODQAAAAIbmlzdHAzODQAAABhBAbJvQyJ/zvqxv+4JOKeBrKvNZgOGqWT3nyhrYejVO+acHW4T3r6TNumXiLewd9bXTrdj5FXaZO9atr7xAwxDWRWEFz3ZStDvJ8huu6n0mayZqpym1wMdgC55y1VCjX3tA=="},
+	} {
+		if key, err := sshsig.AuthorizedKey(&tc.key.PublicKey); err != nil || key != tc.expected {
+			t.Errorf("Expected %s, got %s, %v", tc.expected, key, err)
+		}
+	}
+
+	if _, err := sshsig.AuthorizedKey(&newKey(elliptic.P224(), "key").PublicKey); err != sshsig.ErrInvalidKey {
+		t.Errorf("Expected %v, got %v", sshsig.ErrInvalidKey, err)
+	}
+}
+
+func TestSign(t *testing.T) {
+	// Verified with "ssh-keygen -Y verify -n file".
+	expected := `-----BEGIN SSH SIGNATURE-----
+U1NIU0lHAAAAAQAAAGgAAAATZWNkc2Etc2hhMi1uaXN0cDI1NgAAAAhuaXN0cDI1NgAAAE
+EErWhFEfUtw6Da9FnxTt4JLM344PSUl7Wj6ubYR3E71INXWiYwxwQ2RfKzoyFXJ7GvrYQW
+doGzBvnU1fSZ4ls5YgAAAARmaWxlAAAAAAAAAAZzaGEyNTYAAABkAAAAE2VjZHNhLXNoYT
+ItbmlzdHAyNTYAAABJAAAAIQCtuYpC/pqiPi/FPCD3fPbGdqaSAtv71OSf7NlMmtCe5QAA
+ACAHuZHUN62kVnqlVE3a/t4wqZllGY3J17uOWT8aRdAhlg==
+-----END SSH SIGNATURE-----
+`
+	sig, err := sshsig.Sign(key256, "file", crypto.SHA256, message)
+	if err != nil || string(sig) != expected {
+		t.Errorf("Expected\n%s, got\n%s, %v", expected, sig, err)
+	}
+
+	for _, c := range []elliptic.Curve{elliptic.P256(), elliptic.P384(), elliptic.P521()} {
+		key := newKey(c, "another key")
+		for _, h := range []crypto.Hash{crypto.SHA256, crypto.SHA512} {
+			sig, err := sshsig.Sign(key, "git", h, message)
+			if err != nil {
+				t.Fatalf("%s, %v: %v", c.Params().Name, h, err)
+			}
+			again, _ := sshsig.Sign(key, "git", h, message)
+			if !bytes.Equal(sig, again) {
+				t.Errorf("%s, %v: Expected a deterministic signature", c.Params().Name, h)
+			}
+			if err := sshsig.Verify(&key.PublicKey, "git", message, sig); err != nil {
+				t.Errorf("%s, %v: %v", c.Params().Name, h, err)
+			}
+		}
+	}
+
+	if _, err := sshsig.Sign(key256, "file", crypto.SHA384, message); err != sshsig.ErrInvalidHash {
+		t.Errorf("Expected %v, got %v", sshsig.ErrInvalidHash, err)
+	}
+	if _, err := sshsig.Sign(key256, "", crypto.SHA256, message); err != sshsig.ErrInvalidNamespace {
+		t.Errorf("Expected %v, got %v", sshsig.ErrInvalidNamespace, err)
+	}
+	if _, err := sshsig.Sign(newKey(elliptic.P224(), "key"), "file", crypto.SHA256, message); err != sshsig.ErrInvalidKey {
+		t.Errorf("Expected %v, got %v", sshsig.ErrInvalidKey, err)
+	}
+}
+
+func TestVerify(t *testing.T) {
+	// Made with "ssh-keygen -Y sign -n file", SHA-512.
+	sig := []byte(`-----BEGIN SSH SIGNATURE-----
+U1NIU0lHAAAAAQAAAIgAAAATZWNkc2Etc2hhMi1uaXN0cDM4NAAAAAhuaXN0cDM4NAAAAG
+EEBsm9DIn/O+rG/7gk4p4Gsq81mA4apZPefKGth6NU75pwdbhPevpM26ZeIt7B31tdOt2P
+kVdpk71q2vvEDDENZFYQXPdlK0O8nyG67qfSZrJmqnKbXAx2ALnnLVUKNfe0AAAABGZpbG
+UAAAAAAAAABnNoYTUxMgAAAIMAAAATZWNkc2Etc2hhMi1uaXN0cDM4NAAAAGgAAAAwCI6l
+6iJfoJy/LKMdaZYxwzrlDfAtXpypGn0ozFb0svWrd0p8MgVPCDt1HTiAah6PAAAAMFbF4v
+MBWoYL6Ci+v2tZO8aVY9ld6FCaK/g8zTcO+Pez0MBvg3pek5M20Q83ovei4w==
+-----END SSH SIGNATURE-----
+`)
+	if err := sshsig.Verify(&key384.PublicKey, "file", message, sig); err != nil {
+		t.Fatal(err)
+	}
+	crlf := bytes.Replace(sig, []byte("\n"), []byte("\r\n"), -1)
+	if err := sshsig.Verify(&key384.PublicKey, "file", message, crlf); err != nil {
+		t.Errorf("CRLF: %v", err)
+	}
+
+	if err := sshsig.Verify(&key384.PublicKey, "file", []byte("hello"), sig); err != rfc6979.ErrInvalidSignature {
+		t.Errorf("Expected %v, got %v", rfc6979.ErrInvalidSignature, err)
+	}
+	if err := sshsig.Verify(&key384.PublicKey, "git", message, sig); err != sshsig.ErrInvalidNamespace {
+		t.Errorf("Expected %v, got %v", sshsig.ErrInvalidNamespace, err)
+	}
+	other := newKey(elliptic.P384(), "another key")
+	if err := sshsig.Verify(&other.PublicKey, "file", message, sig); err != sshsig.ErrInvalidSignature {
+		t.Errorf("Expected %v, got %v", sshsig.ErrInvalidSignature, err)
+	}
+	if err := sshsig.Verify(&key256.PublicKey, "file", message, sig); err != sshsig.ErrInvalidSignature {
+		t.Errorf("Expected %v, got %v", sshsig.ErrInvalidSignature, err)
+	}
+
+	for name, bad := range map[string]string{
+		"no header":   strings.Replace(string(sig), "-----BEGIN SSH SIGNATURE-----\n", "", 1),
+		"other block": strings.Replace(string(sig), "SSH SIGNATURE", "SIGNATURE", -1),
+		"bad base64":  strings.Replace(string(sig), "U1NIU0lH", "U1NIU0l!", 1),
+		"truncated":   strings.Replace(string(sig), "MBWoYL6Ci+v2tZO8aVY9ld6FCaK/g8zTcO+Pez0MBvg3pek5M20Q83ovei4w==", "MBWo", 1),
+		"magic":       strings.Replace(string(sig), "U1NIU0lH", "U1NIU0lI", 1),
+	} {
+		if err := sshsig.Verify(&key384.PublicKey, "file", message, []byte(bad)); err != sshsig.ErrInvalidSignature {
+			t.Errorf("%s: Expected %v, got %v", name, sshsig.ErrInvalidSignature, err)
+		}
+	}
+}
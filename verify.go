@@ -0,0 +1,184 @@
+package rfc6979
+
+import (
+	"crypto/ecdsa"
+	"crypto/x509"
+	"errors"
+	"hash"
+	"math/big"
+	"runtime"
+	"sync"
+)
+
+var (
+	// ErrInvalidSignature is returned when a signature doesn't verify.
+	ErrInvalidSignature = errors.New("rfc6979: invalid signature")
+
+	// ErrNotDeterministic is returned by AssertDeterministic for valid
+	// signatures that differ from the RFC 6979 one.
+	ErrNotDeterministic = errors.New("rfc6979: valid signature is not deterministic")
+
+	// ErrUnknownFormat is returned when a signature is neither DER nor raw,
+	// or a format name isn't registered.
+	ErrUnknownFormat = errors.New("rfc6979: unknown signature format")
+
+	// ErrAmbiguousFormat is returned when a signature is both valid DER and
+	// of the raw signature length, so its format can't be told.
+	ErrAmbiguousFormat = errors.New("rfc6979: ambiguous signature format")
+
+	// ErrNotECDSAKey is returned when a public key is not an ECDSA one.
+	ErrNotECDSAKey = errors.New("rfc6979: not an ECDSA public key")
+)
+
+// BatchItem is a single signature to be checked by VerifyECDSABatch.
+type BatchItem struct {
+	Digest []byte
+	R, S   *big.Int
+}
+
+// VerifyECDSABatch verifies every item against the public key, pub, and
+// returns a slice of results aligned with items by index. Items are verified
+// independently and concurrently, this is not signature aggregation. The key
+// is validated once for the whole batch and every worker encodes signatures
+// for crypto/ecdsa into a single reused buffer, so a batch allocates less
+// than calling VerifyECDSA for each item.
+func VerifyECDSABatch(pub *ecdsa.PublicKey, items []BatchItem) []bool {
+	res := make([]bool, len(items))
+	if len(items) == 0 || pub.X == nil || pub.Y == nil || !pub.Curve.IsOnCurve(pub.X, pub.Y) {
+		return res
+	}
+	N := pub.Curve.Params().N
+	size := 2*(OrderSize(pub.Curve)+4) + 4
+
+	workers := runtime.GOMAXPROCS(0)
+	if workers > len(items) {
+		workers = len(items)
+	}
+
+	var wg sync.WaitGroup
+	next := make(chan int)
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			buf := make([]byte, 0, size)
+			for i := range next {
+				it := &items[i]
+				if it.R == nil || it.S == nil || it.R.Sign() <= 0 || it.S.Sign() <= 0 || it.R.Cmp(N) >= 0 || it.S.Cmp(N) >= 0 {
+					continue
+				}
+				buf = appendSignatureDER(buf[:0], it.R, it.S, 0)
+				res[i] = ecdsa.VerifyASN1(pub, it.Digest, buf)
+			}
+		}()
+	}
+
+	for i := range items {
+		next <- i
+	}
+	close(next)
+	wg.Wait()
+
+	return res
+}
+
+// VerifyECDSAAuto verifies sig over the digest with the public key, pub,
+// detecting whether sig is DER encoded or raw (r || s, both padded to the byte
+// length of the curve order, as produced by SignECDSAP1363).
+//
+// sig is considered DER if it is a well-formed ASN.1 SEQUENCE of two positive
+// INTEGERs, which implies it starts with 0x30 and its length header matches
+// the input length. It is considered raw if it is exactly twice the size of
+// the curve order. An input satisfying both conditions is rejected with
+// ErrAmbiguousFormat rather than guessed; this can only happen for raw
+// signatures that accidentally form valid DER, which is very unlikely, or for
+// DER signatures with unusually short components.
+func VerifyECDSAAuto(pub *ecdsa.PublicKey, digest, sig []byte) error {
+	size := OrderSize(pub.Curve)
+	isRaw := len(sig) == 2*size
+	r, s, err := decodeDER(sig)
+	isDER := err == nil
+
+	switch {
+	case isDER && isRaw:
+		return ErrAmbiguousFormat
+	case isRaw:
+		r, s, _ = DecodeP1363(pub.Curve, sig)
+	case !isDER:
+		return ErrUnknownFormat
+	}
+
+	if !VerifyECDSA(pub, digest, r, s) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+// VerifyECDSA verifies the signature (r, s) of the digest with the public key,
+// pub, truncating the digest like SignECDSA does. Nil or out of range
+// components, i.e. outside [1, N-1], are rejected before doing any curve
+// arithmetic.
+func VerifyECDSA(pub *ecdsa.PublicKey, digest []byte, r, s *big.Int) bool {
+	N := pub.Curve.Params().N
+	if r == nil || s == nil || r.Sign() <= 0 || s.Sign() <= 0 || r.Cmp(N) >= 0 || s.Cmp(N) >= 0 {
+		return false
+	}
+	return ecdsa.Verify(pub, digest, r, s)
+}
+
+// VerifyECDSAPKIX verifies the signature (r, s) of the digest with the public
+// key encoded in PKIX, ASN.1 DER form, as found in certificates. The curve is
+// taken from the key and the digest is truncated to its order the usual way.
+// It returns the parsing error for malformed keys, ErrNotECDSAKey for keys of
+// other types and ErrInvalidSignature if the signature doesn't verify.
+func VerifyECDSAPKIX(pubDER []byte, digest []byte, r, s *big.Int) error {
+	key, err := x509.ParsePKIXPublicKey(pubDER)
+	if err != nil {
+		return err
+	}
+	pub, ok := key.(*ecdsa.PublicKey)
+	if !ok {
+		return ErrNotECDSAKey
+	}
+
+	if !VerifyECDSA(pub, digest, r, s) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+// VerifyASN1 verifies the DER encoded signature, sig, of the hash with the
+// public key, pub, like crypto/ecdsa.VerifyASN1. Signatures with trailing
+// data or non-positive components are rejected.
+func VerifyASN1(pub *ecdsa.PublicKey, hash, sig []byte) bool {
+	r, s, err := decodeDER(sig)
+	return err == nil && VerifyECDSA(pub, hash, r, s)
+}
+
+// VerifyECDSADetailed verifies the signature (r, s) of the digest with the
+// public key, pub, like VerifyCurve does. When the signature is invalid it
+// also returns a human-readable reason: "r out of range", "s out of range",
+// "public key not on curve", "computed point at infinity" or "computed point
+// mismatch". It is meant for diagnostics, callers must not rely on the exact
+// reasons.
+func VerifyECDSADetailed(pub *ecdsa.PublicKey, digest []byte, r, s *big.Int) (bool, string) {
+	reason := verifyCurve(pub.Curve, pub.X, pub.Y, digest, r, s)
+	return reason == "", reason
+}
+
+// AssertDeterministic checks that (r, s) is exactly the signature SignECDSA
+// produces for the digest with the private key, priv. It returns
+// ErrNotDeterministic if the signature is valid, but differs, which suggests
+// the signer used another nonce (e.g. a random one), and ErrInvalidSignature
+// if it doesn't verify at all.
+func AssertDeterministic(priv *ecdsa.PrivateKey, digest []byte, r, s *big.Int, alg func() hash.Hash) error {
+	er, es := SignECDSA(priv, digest, alg)
+	if r != nil && s != nil && r.Cmp(er) == 0 && s.Cmp(es) == 0 {
+		return nil
+	}
+
+	if !VerifyCurve(priv.Curve, priv.X, priv.Y, digest, r, s) {
+		return ErrInvalidSignature
+	}
+	return ErrNotDeterministic
+}
@@ -0,0 +1,428 @@
+package rfc6979_test
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/x509"
+	"encoding/asn1"
+	"hash"
+	"math/big"
+	"testing"
+
+	"github.com/nspcc-dev/rfc6979"
+)
+
+func TestVerifyECDSABatch(t *testing.T) {
+	key := p256.key
+
+	var (
+		items    []rfc6979.BatchItem
+		expected []bool
+	)
+	for i := 0; i < 64; i++ {
+		digest := sha256.Sum256([]byte{byte(i)})
+		r, s := rfc6979.SignECDSA(key, digest[:], sha256.New)
+
+		valid := i%3 != 0
+		if !valid {
+			s = new(big.Int).Add(s, big.NewInt(1))
+		}
+
+		items = append(items, rfc6979.BatchItem{Digest: digest[:], R: r, S: s})
+		expected = append(expected, valid)
+	}
+	items = append(items, rfc6979.BatchItem{Digest: []byte{1}})
+	expected = append(expected, false)
+
+	res := rfc6979.VerifyECDSABatch(&key.PublicKey, items)
+	if len(res) != len(expected) {
+		t.Fatalf("Expected %d results, got %d", len(expected), len(res))
+	}
+
+	for i := range res {
+		if res[i] != expected[i] {
+			t.Errorf("Item %d: expected %t, got %t", i, expected[i], res[i])
+		}
+	}
+
+	if res := rfc6979.VerifyECDSABatch(&key.PublicKey, nil); len(res) != 0 {
+		t.Errorf("Expected no results for an empty batch, got %d", len(res))
+	}
+
+	offCurve := key.PublicKey
+	offCurve.Y = new(big.Int).Add(offCurve.Y, big.NewInt(1))
+	for i, ok := range rfc6979.VerifyECDSABatch(&offCurve, items) {
+		if ok {
+			t.Errorf("Item %d: expected an off-curve key to fail", i)
+		}
+	}
+
+	perItem := testing.AllocsPerRun(10, func() {
+		for _, it := range items {
+			rfc6979.VerifyECDSA(&key.PublicKey, it.Digest, it.R, it.S)
+		}
+	})
+	batch := testing.AllocsPerRun(10, func() {
+		rfc6979.VerifyECDSABatch(&key.PublicKey, items)
+	})
+	if batch >= perItem {
+		t.Errorf("Expected less than %v allocations, got %v", perItem, batch)
+	}
+}
+
+func TestVerifyECDSAAuto(t *testing.T) {
+	key := p256.key
+	digest := sha256.Sum256([]byte("sample"))
+
+	raw, err := rfc6979.SignECDSAP1363(key, digest[:], sha256.New)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r, s := rfc6979.SignECDSA(key, digest[:], sha256.New)
+	der, err := asn1.Marshal(struct{ R, S *big.Int }{r, s})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, sig := range map[string][]byte{"raw": raw, "DER": der} {
+		if err := rfc6979.VerifyECDSAAuto(&key.PublicKey, digest[:], sig); err != nil {
+			t.Errorf("%s: %v", name, err)
+		}
+	}
+
+	other := sha256.Sum256([]byte("test"))
+	if err := rfc6979.VerifyECDSAAuto(&key.PublicKey, other[:], raw); err != rfc6979.ErrInvalidSignature {
+		t.Errorf("Expected %v, got %v", rfc6979.ErrInvalidSignature, err)
+	}
+
+	if err := rfc6979.VerifyECDSAAuto(&key.PublicKey, digest[:], raw[1:]); err != rfc6979.ErrUnknownFormat {
+		t.Errorf("Expected %v, got %v", rfc6979.ErrUnknownFormat, err)
+	}
+
+	// 64 bytes that are also a valid DER sequence of two 29-byte integers.
+	ambiguous := []byte{0x30, 0x3e, 0x02, 0x1d}
+	ambiguous = append(ambiguous, bytes.Repeat([]byte{0x11}, 29)...)
+	ambiguous = append(ambiguous, 0x02, 0x1d)
+	ambiguous = append(ambiguous, bytes.Repeat([]byte{0x22}, 29)...)
+	if err := rfc6979.VerifyECDSAAuto(&key.PublicKey, digest[:], ambiguous); err != rfc6979.ErrAmbiguousFormat {
+		t.Errorf("Expected %v, got %v", rfc6979.ErrAmbiguousFormat, err)
+	}
+}
+
+func TestVerifyECDSADetailed(t *testing.T) {
+	key := p256.key
+	N := key.Curve.Params().N
+	digest := sha256.Sum256([]byte("sample"))
+	r, s := rfc6979.SignECDSA(key, digest[:], sha256.New)
+
+	offCurve := key.PublicKey
+	offCurve.Y = new(big.Int).Add(key.Y, big.NewInt(1))
+
+	// With Q = G, s = 1 and e = -r the verifier computes (e + r)·G = O.
+	gen := key.PublicKey
+	gen.X, gen.Y = key.Curve.Params().Gx, key.Curve.Params().Gy
+	gr := new(big.Int).Set(key.Curve.Params().Gx)
+	negR := new(big.Int).Sub(N, gr)
+	infDigest := make([]byte, 32)
+	negR.FillBytes(infDigest)
+
+	tests := []struct {
+		name   string
+		pub    *ecdsa.PublicKey
+		digest []byte
+		r, s   *big.Int
+		reason string
+	}{
+		{"valid", &key.PublicKey, digest[:], r, s, ""},
+		{"zero r", &key.PublicKey, digest[:], big.NewInt(0), s, "r out of range"},
+		{"big r", &key.PublicKey, digest[:], N, s, "r out of range"},
+		{"zero s", &key.PublicKey, digest[:], r, big.NewInt(0), "s out of range"},
+		{"big s", &key.PublicKey, digest[:], r, N, "s out of range"},
+		{"off curve", &offCurve, digest[:], r, s, "public key not on curve"},
+		{"infinity", &gen, infDigest, gr, big.NewInt(1), "computed point at infinity"},
+		{"mismatch", &key.PublicKey, digest[1:], r, s, "computed point mismatch"},
+	}
+
+	for _, tc := range tests {
+		ok, reason := rfc6979.VerifyECDSADetailed(tc.pub, tc.digest, tc.r, tc.s)
+		if ok != (tc.reason == "") || reason != tc.reason {
+			t.Errorf("%s: Expected %q, got %t and %q", tc.name, tc.reason, ok, reason)
+		}
+	}
+}
+
+func TestAssertDeterministic(t *testing.T) {
+	key := p256.key
+	digest := sha256.Sum256([]byte("sample"))
+
+	r, s := rfc6979.SignECDSA(key, digest[:], sha256.New)
+	if err := rfc6979.AssertDeterministic(key, digest[:], r, s, sha256.New); err != nil {
+		t.Errorf("Expected deterministic signature to pass, got %v", err)
+	}
+
+	rr, rs, err := ecdsa.Sign(rand.Reader, key, digest[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := rfc6979.AssertDeterministic(key, digest[:], rr, rs, sha256.New); err != rfc6979.ErrNotDeterministic {
+		t.Errorf("Expected %v, got %v", rfc6979.ErrNotDeterministic, err)
+	}
+
+	if err := rfc6979.AssertDeterministic(key, digest[:], r, rs, sha256.New); err != rfc6979.ErrInvalidSignature {
+		t.Errorf("Expected %v, got %v", rfc6979.ErrInvalidSignature, err)
+	}
+}
+
+func TestVerifyECDSAPKIX(t *testing.T) {
+	digest := sha512.Sum512([]byte("sample"))
+
+	for _, c := range []elliptic.Curve{elliptic.P224(), elliptic.P256(), elliptic.P384(), elliptic.P521()} {
+		name := c.Params().Name
+		key, err := ecdsa.GenerateKey(c, rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		pubDER, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		r, s := rfc6979.SignECDSA(key, digest[:], sha512.New)
+		if err := rfc6979.VerifyECDSAPKIX(pubDER, digest[:], r, s); err != nil {
+			t.Errorf("%s: %v", name, err)
+		}
+
+		s.Add(s, big.NewInt(1))
+		if err := rfc6979.VerifyECDSAPKIX(pubDER, digest[:], r, s); err != rfc6979.ErrInvalidSignature {
+			t.Errorf("%s: Expected %v, got %v", name, rfc6979.ErrInvalidSignature, err)
+		}
+	}
+
+	edPub, _, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	edDER, err := x509.MarshalPKIXPublicKey(edPub)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, s := rfc6979.SignECDSA(p256.key, digest[:], sha512.New)
+	if err := rfc6979.VerifyECDSAPKIX(edDER, digest[:], r, s); err != rfc6979.ErrNotECDSAKey {
+		t.Errorf("Expected %v, got %v", rfc6979.ErrNotECDSAKey, err)
+	}
+
+	if err := rfc6979.VerifyECDSAPKIX([]byte{0x30, 0x00}, digest[:], r, s); err == nil {
+		t.Error("Expected malformed key to fail")
+	}
+}
+
+type verifyVector struct {
+	name    string
+	key     *ecdsaKey
+	alg     func() hash.Hash
+	message string
+	r, s    string
+	valid   bool
+}
+
+// verifyVectors are derived from the RFC 6979 appendix A.2 signatures.
+var verifyVectors = []verifyVector{
+	{
+		name:    "P256/SHA-256 valid",
+		key:     p256,
+		alg:     sha256.New,
+		message: "sample",
+		r:       "EFD48B2AACB6A8FD1140DD9CD45E81D69D2C877B56AAF991C34D0EA84EAF3716",
+		s:       "F7CB1C942D657C41D436C7A1B6E29F65F3E900DBB9AFF4064DC4AB2F843ACDA8",
+		valid:   true,
+	},
+	{
+		name:    "P256/SHA-256 high s negated",
+		key:     p256,
+		alg:     sha256.New,
+		message: "sample",
+		r:       "EFD48B2AACB6A8FD1140DD9CD45E81D69D2C877B56AAF991C34D0EA84EAF3716",
+		s:       "834E36AD29A83BF2BC9385E491D6099C8FDF9D1ED67AA7EA5F51F93782857A9",
+		valid:   true,
+	},
+	{
+		name:    "P256/SHA-256 tweaked s",
+		key:     p256,
+		alg:     sha256.New,
+		message: "sample",
+		r:       "EFD48B2AACB6A8FD1140DD9CD45E81D69D2C877B56AAF991C34D0EA84EAF3716",
+		s:       "F7CB1C942D657C41D436C7A1B6E29F65F3E900DBB9AFF4064DC4AB2F843ACDA9",
+	},
+	{
+		name:    "P256/SHA-256 tweaked r",
+		key:     p256,
+		alg:     sha256.New,
+		message: "sample",
+		r:       "EFD48B2AACB6A8FD1140DD9CD45E81D69D2C877B56AAF991C34D0EA84EAF3717",
+		s:       "F7CB1C942D657C41D436C7A1B6E29F65F3E900DBB9AFF4064DC4AB2F843ACDA8",
+	},
+	{
+		name:    "P256/SHA-256 wrong message",
+		key:     p256,
+		alg:     sha256.New,
+		message: "test",
+		r:       "EFD48B2AACB6A8FD1140DD9CD45E81D69D2C877B56AAF991C34D0EA84EAF3716",
+		s:       "F7CB1C942D657C41D436C7A1B6E29F65F3E900DBB9AFF4064DC4AB2F843ACDA8",
+	},
+	{
+		name:    "P256/SHA-256 wrong key",
+		key:     p384,
+		alg:     sha256.New,
+		message: "sample",
+		r:       "EFD48B2AACB6A8FD1140DD9CD45E81D69D2C877B56AAF991C34D0EA84EAF3716",
+		s:       "F7CB1C942D657C41D436C7A1B6E29F65F3E900DBB9AFF4064DC4AB2F843ACDA8",
+	},
+	{
+		name:    "P256/SHA-256 zero r",
+		key:     p256,
+		alg:     sha256.New,
+		message: "sample",
+		r:       "00",
+		s:       "F7CB1C942D657C41D436C7A1B6E29F65F3E900DBB9AFF4064DC4AB2F843ACDA8",
+	},
+	{
+		name:    "P256/SHA-256 zero s",
+		key:     p256,
+		alg:     sha256.New,
+		message: "sample",
+		r:       "EFD48B2AACB6A8FD1140DD9CD45E81D69D2C877B56AAF991C34D0EA84EAF3716",
+		s:       "00",
+	},
+	{
+		name:    "P256/SHA-256 r = N",
+		key:     p256,
+		alg:     sha256.New,
+		message: "sample",
+		r:       "FFFFFFFF00000000FFFFFFFFFFFFFFFFBCE6FAADA7179E84F3B9CAC2FC632551",
+		s:       "F7CB1C942D657C41D436C7A1B6E29F65F3E900DBB9AFF4064DC4AB2F843ACDA8",
+	},
+	{
+		name:    "P256/SHA-256 s = N",
+		key:     p256,
+		alg:     sha256.New,
+		message: "sample",
+		r:       "EFD48B2AACB6A8FD1140DD9CD45E81D69D2C877B56AAF991C34D0EA84EAF3716",
+		s:       "FFFFFFFF00000000FFFFFFFFFFFFFFFFBCE6FAADA7179E84F3B9CAC2FC632551",
+	},
+	{
+		name:    "P256/SHA-256 s + N",
+		key:     p256,
+		alg:     sha256.New,
+		message: "sample",
+		r:       "EFD48B2AACB6A8FD1140DD9CD45E81D69D2C877B56AAF991C34D0EA84EAF3716",
+		s:       "1F7CB1C932D657C42D436C7A1B6E29F65B0CFFB8960C7928B417E75F2809DF2F9",
+	},
+	{
+		name:    "P256/SHA-256 swapped r and s",
+		key:     p256,
+		alg:     sha256.New,
+		message: "sample",
+		r:       "F7CB1C942D657C41D436C7A1B6E29F65F3E900DBB9AFF4064DC4AB2F843ACDA8",
+		s:       "EFD48B2AACB6A8FD1140DD9CD45E81D69D2C877B56AAF991C34D0EA84EAF3716",
+	},
+	{
+		name:    "P224/SHA-224 valid",
+		key:     p224,
+		alg:     sha256.New224,
+		message: "sample",
+		r:       "1CDFE6662DDE1E4A1EC4CDEDF6A1F5A2FB7FBD9145C12113E6ABFD3E",
+		s:       "A6694FD7718A21053F225D3F46197CA699D45006C06F871808F43EBC",
+		valid:   true,
+	},
+	{
+		name:    "P224/SHA-224 wrong hash",
+		key:     p224,
+		alg:     sha256.New,
+		message: "sample",
+		r:       "1CDFE6662DDE1E4A1EC4CDEDF6A1F5A2FB7FBD9145C12113E6ABFD3E",
+		s:       "A6694FD7718A21053F225D3F46197CA699D45006C06F871808F43EBC",
+	},
+	{
+		name:    "P384/SHA-384 valid",
+		key:     p384,
+		alg:     sha512.New384,
+		message: "test",
+		r:       "8203B63D3C853E8D77227FB377BCF7B7B772E97892A80F36AB775D509D7A5FEB0542A7F0812998DA8F1DD3CA3CF023DB",
+		s:       "DDD0760448D42D8A43AF45AF836FCE4DE8BE06B485E9B61B827C2F13173923E06A739F040649A667BF3B828246BAA5A5",
+		valid:   true,
+	},
+	{
+		name:    "P521/SHA-512 valid",
+		key:     p521,
+		alg:     sha512.New,
+		message: "test",
+		r:       "13E99020ABF5CEE7525D16B69B229652AB6BDF2AFFCAEF38773B4B7D08725F10CDB93482FDCC54EDCEE91ECA4166B2A7C6265EF0CE2BD7051B7CEF945BABD47EE6D",
+		s:       "1FBD0013C674AA79CB39849527916CE301C66EA7CE8B80682786AD60F98F7E78A19CA69EFF5C57400E3B3A0AD66CE0978214D13BAF4E9AC60752F7B155E2DE4DCE3",
+		valid:   true,
+	},
+	{
+		name:    "P521/SHA-512 wrong message",
+		key:     p521,
+		alg:     sha512.New,
+		message: "sample",
+		r:       "13E99020ABF5CEE7525D16B69B229652AB6BDF2AFFCAEF38773B4B7D08725F10CDB93482FDCC54EDCEE91ECA4166B2A7C6265EF0CE2BD7051B7CEF945BABD47EE6D",
+		s:       "1FBD0013C674AA79CB39849527916CE301C66EA7CE8B80682786AD60F98F7E78A19CA69EFF5C57400E3B3A0AD66CE0978214D13BAF4E9AC60752F7B155E2DE4DCE3",
+	},
+}
+
+func TestVerifyVectors(t *testing.T) {
+	for _, v := range verifyVectors {
+		h := v.alg()
+		h.Write([]byte(v.message))
+		digest := h.Sum(nil)
+
+		pub := &v.key.key.PublicKey
+		r, s := ecdsaLoadInt(v.r), ecdsaLoadInt(v.s)
+
+		if valid := rfc6979.VerifyCurve(pub.Curve, pub.X, pub.Y, digest, r, s); valid != v.valid {
+			t.Errorf("%s: Expected %t, got %t", v.name, v.valid, valid)
+		}
+
+		if valid := ecdsa.Verify(pub, digest, r, s); valid != v.valid {
+			t.Errorf("%s: Expected crypto/ecdsa to return %t, got %t", v.name, v.valid, valid)
+		}
+	}
+}
+
+func TestVerifyECDSA(t *testing.T) {
+	key := p256.key
+	N := key.Curve.Params().N
+	digest := sha256.Sum256([]byte("sample"))
+	r, s := rfc6979.SignECDSA(key, digest[:], sha256.New)
+
+	if !rfc6979.VerifyECDSA(&key.PublicKey, digest[:], r, s) {
+		t.Errorf("Invalid signature")
+	}
+
+	tests := []struct {
+		name string
+		r, s *big.Int
+	}{
+		{"nil r", nil, s},
+		{"nil s", r, nil},
+		{"zero r", new(big.Int), s},
+		{"zero s", r, new(big.Int)},
+		{"negative r", new(big.Int).Neg(r), s},
+		{"negative s", r, new(big.Int).Neg(s)},
+		{"r + N", new(big.Int).Add(r, N), s},
+		{"s + N", r, new(big.Int).Add(s, N)},
+		{"N", N, N},
+		{"other s", r, new(big.Int).Add(s, big.NewInt(1))},
+	}
+	for _, tc := range tests {
+		if rfc6979.VerifyECDSA(&key.PublicKey, digest[:], tc.r, tc.s) {
+			t.Errorf("%s: Expected signature to be rejected", tc.name)
+		}
+	}
+}
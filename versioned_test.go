@@ -0,0 +1,88 @@
+package rfc6979_test
+
+import (
+	"bytes"
+	"crypto/elliptic"
+	"crypto/sha256"
+	"testing"
+
+	"github.com/nspcc-dev/rfc6979"
+)
+
+func TestVersioned(t *testing.T) {
+	digest := sha256.Sum256([]byte("sample"))
+
+	for id, key := range []*ecdsaKey{p224, p256, p384, p521, {key: secp256k1Key("1")}, {key: curveKey(rfc6979.BrainpoolP256r1(), "1")}, {key: curveKey(rfc6979.BrainpoolP384r1(), "1")}} {
+		name := key.key.Curve.Params().Name
+		r, s := rfc6979.SignECDSA(key.key, digest[:], sha256.New)
+
+		data, err := rfc6979.MarshalVersioned(key.key.Curve, r, s)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+
+		size := rfc6979.OrderSize(key.key.Curve)
+		raw, _ := rfc6979.SignECDSAP1363(key.key, digest[:], sha256.New)
+		if expected := append([]byte{1, byte(id + 1)}, raw...); !bytes.Equal(data, expected) {
+			t.Errorf("%s: Expected %X, got %X", name, expected, data)
+		}
+		if len(data) != 2+2*size {
+			t.Errorf("%s: Unexpected length %d", name, len(data))
+		}
+
+		c, r2, s2, err := rfc6979.UnmarshalVersioned(data)
+		if err != nil || c.Params().Name != name || r2.Cmp(r) != 0 || s2.Cmp(s) != 0 {
+			t.Errorf("%s: Expected round trip, got %v", name, err)
+		}
+
+		if err := rfc6979.VerifyVersioned(&key.key.PublicKey, digest[:], data); err != nil {
+			t.Errorf("%s: %v", name, err)
+		}
+
+		// A future version must be rejected, not misread as version 1.
+		future := append([]byte{2}, data[1:]...)
+		if _, _, _, err := rfc6979.UnmarshalVersioned(future); err != rfc6979.ErrUnknownVersion {
+			t.Errorf("%s: Expected %v, got %v", name, rfc6979.ErrUnknownVersion, err)
+		}
+		if err := rfc6979.VerifyVersioned(&key.key.PublicKey, digest[:], future); err != rfc6979.ErrUnknownVersion {
+			t.Errorf("%s: Expected %v, got %v", name, rfc6979.ErrUnknownVersion, err)
+		}
+
+		if err := rfc6979.VerifyVersioned(&key.key.PublicKey, digest[:], data[:len(data)-1]); err != rfc6979.ErrInvalidVersioned {
+			t.Errorf("%s: Expected %v, got %v", name, rfc6979.ErrInvalidVersioned, err)
+		}
+	}
+
+	for _, data := range [][]byte{nil, {1}, {1, 0}, {1, 13}} {
+		if _, _, _, err := rfc6979.UnmarshalVersioned(data); err != rfc6979.ErrInvalidVersioned {
+			t.Errorf("%X: Expected %v, got %v", data, rfc6979.ErrInvalidVersioned, err)
+		}
+	}
+	for _, data := range [][]byte{{0}, {0xff, 2}} {
+		if _, _, _, err := rfc6979.UnmarshalVersioned(data); err != rfc6979.ErrUnknownVersion {
+			t.Errorf("%X: Expected %v, got %v", data, rfc6979.ErrUnknownVersion, err)
+		}
+	}
+
+	r, s := rfc6979.SignECDSA(p256.key, digest[:], sha256.New)
+	data, _ := rfc6979.MarshalVersioned(p256.key.Curve, r, s)
+	if err := rfc6979.VerifyVersioned(&p384.key.PublicKey, digest[:], data); err != rfc6979.ErrInvalidVersioned {
+		t.Errorf("Expected curve mismatch to fail with %v, got %v", rfc6979.ErrInvalidVersioned, err)
+	}
+
+	// The curve is identified by its parameters, not by its name.
+	renamed := p256.key.PublicKey
+	renamed.Curve = renamedCurve{elliptic.P256(), "P-384"}
+	if err := rfc6979.VerifyVersioned(&renamed, digest[:], data); err != nil {
+		t.Errorf("Renamed P-256: %v", err)
+	}
+	impostor := secp256k1Key("1")
+	impostor.Curve = renamedCurve{rfc6979.Secp256k1(), "P-256"}
+	r, s = rfc6979.SignECDSA(impostor, digest[:], sha256.New)
+	if data, _ = rfc6979.MarshalVersioned(impostor.Curve, r, s); data[1] != 5 {
+		t.Errorf("Expected secp256k1 named P-256 to be identified as secp256k1, got %X", data)
+	}
+	if err := rfc6979.VerifyVersioned(&p256.key.PublicKey, digest[:], data); err != rfc6979.ErrInvalidVersioned {
+		t.Errorf("Expected secp256k1 named P-256 to be rejected, got %v", err)
+	}
+}
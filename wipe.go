@@ -0,0 +1,30 @@
+package rfc6979
+
+import (
+	"crypto/ecdsa"
+	"math/big"
+)
+
+// WipePrivateKey makes a best-effort attempt to scrub the secret scalar of
+// priv from memory, leaving priv.D equal to zero.
+//
+// Go's math/big gives no guarantee that earlier copies of the value were not
+// left behind by arithmetic or by the garbage collector moving memory, and
+// any byte slices the caller derived from priv.D (e.g. via D.Bytes()) must be
+// cleared by the caller. This function only overwrites the words currently
+// backing priv.D.
+func WipePrivateKey(priv *ecdsa.PrivateKey) {
+	if priv == nil || priv.D == nil {
+		return
+	}
+	wipeInt(priv.D)
+}
+
+// wipeInt zeros the words backing v and sets it to zero.
+func wipeInt(v *big.Int) {
+	words := v.Bits()
+	for i := range words {
+		words[i] = 0
+	}
+	v.SetInt64(0)
+}
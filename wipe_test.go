@@ -0,0 +1,34 @@
+package rfc6979_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"testing"
+
+	"github.com/nspcc-dev/rfc6979"
+)
+
+func TestWipePrivateKey(t *testing.T) {
+	k, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	words := k.D.Bits()
+	rfc6979.WipePrivateKey(k)
+
+	if k.D.Sign() != 0 {
+		t.Errorf("Expected D to be zero, got %X", k.D)
+	}
+
+	for i, w := range words {
+		if w != 0 {
+			t.Errorf("Expected word %d of D to be wiped, got %X", i, w)
+		}
+	}
+
+	// Wiping nil keys or already wiped keys must not panic.
+	rfc6979.WipePrivateKey(nil)
+	rfc6979.WipePrivateKey(k)
+}
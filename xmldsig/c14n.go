@@ -0,0 +1,159 @@
+package xmldsig
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/beevik/etree"
+)
+
+// xmlNamespace is the namespace bound to the xml prefix.
+const xmlNamespace = "http://www.w3.org/XML/1998/namespace"
+
+// Canonicalize returns the Exclusive XML Canonicalization (without
+// comments) of the element, http://www.w3.org/2001/10/xml-exc-c14n#.
+// Namespaces declared by the ancestors of the element are taken into
+// account, but only the ones visibly utilized are rendered. The
+// InclusiveNamespaces PrefixList isn't supported.
+func Canonicalize(el *etree.Element) []byte {
+	var b strings.Builder
+	canonicalize(&b, el, nil, nil)
+	return []byte(b.String())
+}
+
+// canonicalize writes the canonical form of the element, leaving out skip.
+// rendered holds the namespace declarations in effect in the output.
+func canonicalize(b *strings.Builder, el, skip *etree.Element, rendered map[string]string) {
+	// Visibly utilized namespaces: the element's and its prefixed
+	// attributes' ones.
+	used := map[string]string{el.Space: lookupNamespace(el, el.Space)}
+	type attr struct{ uri, key, full, value string }
+	var attrs []attr
+	for _, a := range el.Attr {
+		if a.Space == "xmlns" || (a.Space == "" && a.Key == "xmlns") {
+			continue
+		}
+		uri := ""
+		if a.Space != "" {
+			uri = lookupNamespace(el, a.Space)
+			used[a.Space] = uri
+		}
+		attrs = append(attrs, attr{uri, a.Key, a.FullKey(), a.Value})
+	}
+	sort.Slice(attrs, func(i, j int) bool {
+		if attrs[i].uri != attrs[j].uri {
+			return attrs[i].uri < attrs[j].uri
+		}
+		return attrs[i].key < attrs[j].key
+	})
+
+	var prefixes []string
+	scope := make(map[string]string, len(rendered)+len(used))
+	for p, uri := range rendered {
+		scope[p] = uri
+	}
+	for p, uri := range used {
+		if p == "xml" || scope[p] == uri {
+			continue
+		}
+		// The empty default namespace is only declared to undo a
+		// rendered one.
+		if _, ok := scope[p]; !ok && p == "" && uri == "" {
+			continue
+		}
+		prefixes = append(prefixes, p)
+		scope[p] = uri
+	}
+	sort.Strings(prefixes)
+
+	b.WriteString("<" + el.FullTag())
+	for _, p := range prefixes {
+		if p == "" {
+			b.WriteString(` xmlns="`)
+		} else {
+			b.WriteString(" xmlns:" + p + `="`)
+		}
+		escapeAttr(b, scope[p])
+		b.WriteByte('"')
+	}
+	for _, a := range attrs {
+		b.WriteString(" " + a.full + `="`)
+		escapeAttr(b, a.value)
+		b.WriteByte('"')
+	}
+	b.WriteByte('>')
+
+	for _, t := range el.Child {
+		switch t := t.(type) {
+		case *etree.Element:
+			if t != skip {
+				canonicalize(b, t, skip, scope)
+			}
+		case *etree.CharData:
+			escapeText(b, t.Data)
+		case *etree.ProcInst:
+			b.WriteString("<?" + t.Target)
+			if t.Inst != "" {
+				b.WriteString(" " + t.Inst)
+			}
+			b.WriteString("?>")
+		}
+	}
+	b.WriteString("</" + el.FullTag() + ">")
+}
+
+// lookupNamespace returns the namespace the prefix is bound to in the scope
+// of the element, the empty prefix is the default namespace.
+func lookupNamespace(el *etree.Element, prefix string) string {
+	if prefix == "xml" {
+		return xmlNamespace
+	}
+	for ; el != nil; el = el.Parent() {
+		for _, a := range el.Attr {
+			if (prefix == "" && a.Space == "" && a.Key == "xmlns") || (prefix != "" && a.Space == "xmlns" && a.Key == prefix) {
+				return a.Value
+			}
+		}
+	}
+	return ""
+}
+
+// escapeAttr writes an attribute value, C14N section 2.3.
+func escapeAttr(b *strings.Builder, s string) {
+	for _, c := range s {
+		switch c {
+		case '&':
+			b.WriteString("&amp;")
+		case '<':
+			b.WriteString("&lt;")
+		case '"':
+			b.WriteString("&quot;")
+		case '\t':
+			b.WriteString("&#x9;")
+		case '\n':
+			b.WriteString("&#xA;")
+		case '\r':
+			b.WriteString("&#xD;")
+		default:
+			b.WriteRune(c)
+		}
+	}
+}
+
+// escapeText writes text, C14N section 2.3.
+func escapeText(b *strings.Builder, s string) {
+	for _, c := range s {
+		switch c {
+		case '&':
+			b.WriteString("&amp;")
+		case '<':
+			b.WriteString("&lt;")
+		case '>':
+			b.WriteString("&gt;")
+		case '\r':
+			b.WriteString("&#xD;")
+		default:
+			b.WriteRune(c)
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package xmldsig_test
+
+import (
+	"testing"
+
+	"github.com/beevik/etree"
+	"github.com/nspcc-dev/rfc6979/xmldsig"
+)
+
+const document = `<?xml version="1.0"?>
+<root xmlns="urn:default" xmlns:a="urn:a" xmlns:b="urn:b" xmlns:unused="urn:u">
+  <!-- comment -->
+  <a:child b:attr="1" z="q&quot;&lt;" a:y="2" xml:lang="en">text &amp; &gt; more</a:child>
+  <plain xmlns="">
+    <inner xmlns="urn:other" c="3" b="2">x</inner>
+    <empty/>
+  </plain>
+  <?pi data?>
+  <b:n xmlns:b="urn:b2" attr="a&#9;b&#10;c"><x/></b:n>
+</root>`
+
+func TestCanonicalize(t *testing.T) {
+	doc := etree.NewDocument()
+	if err := doc.ReadFromString(document); err != nil {
+		t.Fatal(err)
+	}
+
+	for path, expected := range map[string]string{
+		// The output of xmllint --exc-c14n without the comment.
+		"/root": `<root xmlns="urn:default">
+  
+  <a:child xmlns:a="urn:a" xmlns:b="urn:b" z="q&quot;&lt;" xml:lang="en" a:y="2" b:attr="1">text &amp; &gt; more</a:child>
+  <plain xmlns="">
+    <inner xmlns="urn:other" b="2" c="3">x</inner>
+    <empty></empty>
+  </plain>
+  <?pi data?>
+  <b:n xmlns:b="urn:b2" attr="a&#x9;b&#xA;c"><x></x></b:n>
+</root>`,
+		// No default namespace is in effect in the output to undo.
+		"/root/plain": `<plain>
+    <inner xmlns="urn:other" b="2" c="3">x</inner>
+    <empty></empty>
+  </plain>`,
+		// The inherited default namespace is visibly utilized by x only.
+		"/root/b:n": `<b:n xmlns:b="urn:b2" attr="a&#x9;b&#xA;c"><x xmlns="urn:default"></x></b:n>`,
+	} {
+		if got := string(xmldsig.Canonicalize(doc.FindElement(path))); got != expected {
+			t.Errorf("%s: Expected\n%s\ngot\n%s", path, expected, got)
+		}
+	}
+}
@@ -0,0 +1,10 @@
+module github.com/nspcc-dev/rfc6979/xmldsig
+
+go 1.24
+
+require (
+	github.com/beevik/etree v1.6.0
+	github.com/nspcc-dev/rfc6979 v0.0.0
+)
+
+replace github.com/nspcc-dev/rfc6979 => ../
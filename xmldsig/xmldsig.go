@@ -0,0 +1,304 @@
+/*
+Package xmldsig produces enveloped XML Signatures (XML-DSig 1.1) with ECDSA
+using RFC 6979 deterministic signatures, for SAML and ebXML documents. The
+signed element and the SignedInfo are canonicalized with Exclusive XML
+Canonicalization, so signing the same element twice yields the same
+Signature. SignatureValue holds r || s as XML-DSig requires (RFC 4050
+section 3.3), not the ASN.1 form some libraries use.
+*/
+package xmldsig
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	_ "crypto/sha256" // ecdsa-sha256
+	_ "crypto/sha512" // ecdsa-sha384, ecdsa-sha512
+	"encoding/base64"
+	"errors"
+	"strings"
+
+	"github.com/beevik/etree"
+	"github.com/nspcc-dev/rfc6979"
+)
+
+// Namespace is the XML-DSig namespace, its elements are created with the
+// "ds" prefix.
+const Namespace = "http://www.w3.org/2000/09/xmldsig#"
+
+// Algorithm identifiers.
+const (
+	ExcC14N   = "http://www.w3.org/2001/10/xml-exc-c14n#"
+	Enveloped = "http://www.w3.org/2000/09/xmldsig#enveloped-signature"
+
+	ECDSASHA256 = "http://www.w3.org/2001/04/xmldsig-more#ecdsa-sha256"
+	ECDSASHA384 = "http://www.w3.org/2001/04/xmldsig-more#ecdsa-sha384"
+	ECDSASHA512 = "http://www.w3.org/2001/04/xmldsig-more#ecdsa-sha512"
+
+	SHA256 = "http://www.w3.org/2001/04/xmlenc#sha256"
+	SHA384 = "http://www.w3.org/2001/04/xmldsig-more#sha384"
+	SHA512 = "http://www.w3.org/2001/04/xmlenc#sha512"
+)
+
+var (
+	// ErrInvalidKey is returned for keys on curves other than P-256, P-384
+	// and P-521 and for signatures made with another algorithm.
+	ErrInvalidKey = errors.New("xmldsig: invalid key")
+
+	// ErrInvalidSignature is returned for missing and malformed Signature
+	// elements and ones using unsupported algorithms or transforms.
+	ErrInvalidSignature = errors.New("xmldsig: invalid signature")
+)
+
+// algorithm describes the signature and digest methods used with a curve.
+type algorithm struct {
+	curve     func() elliptic.Curve
+	hash      crypto.Hash
+	signature string
+	digest    string
+}
+
+// algorithms lists the supported curves.
+var algorithms = []algorithm{
+	{elliptic.P256, crypto.SHA256, ECDSASHA256, SHA256},
+	{elliptic.P384, crypto.SHA384, ECDSASHA384, SHA384},
+	{elliptic.P521, crypto.SHA512, ECDSASHA512, SHA512},
+}
+
+// lookup returns the algorithm of the curve c.
+func lookup(c elliptic.Curve) (algorithm, error) {
+	for _, a := range algorithms {
+		if a.curve().Params().Name == c.Params().Name {
+			return a, nil
+		}
+	}
+	return algorithm{}, ErrInvalidKey
+}
+
+// Options configure SignEnveloped and Verify, nil means the defaults.
+type Options struct {
+	// IDAttribute is the attribute the Reference URI points to, "ID"
+	// (as in SAML) if empty. Elements without it are referenced with the
+	// empty URI, i.e. as the whole document.
+	IDAttribute string
+
+	// Certificates are DER encoded certificates put into KeyInfo, the
+	// signer's one first. KeyInfo is omitted if there are none.
+	Certificates [][]byte
+}
+
+// idAttribute returns the name of the ID attribute.
+func (o *Options) idAttribute() string {
+	if o == nil || o.IDAttribute == "" {
+		return "ID"
+	}
+	return o.IDAttribute
+}
+
+// SignEnveloped returns a copy of the element with an enveloped ds:Signature
+// appended as its last child, signed with the private key, priv. The
+// element is digested and the signature made with the hash of the key's
+// curve: SHA-256 for P-256, SHA-384 for P-384 and SHA-512 for P-521. The
+// Signature may be moved within the element afterwards, e.g. after
+// saml:Issuer, without invalidating it.
+func SignEnveloped(priv *ecdsa.PrivateKey, el *etree.Element, opts *Options) (*etree.Element, error) {
+	a, err := lookup(priv.Curve)
+	if err != nil {
+		return nil, err
+	}
+
+	// The copy is detached from the original parent, keep its namespace
+	// declarations in effect.
+	ret := el.Copy()
+	for _, ns := range inheritedNamespaces(el) {
+		if ret.SelectAttr(ns.FullKey()) == nil {
+			ret.CreateAttr(ns.FullKey(), ns.Value)
+		}
+	}
+	uri := ""
+	if id := el.SelectAttrValue(opts.idAttribute(), ""); id != "" {
+		uri = "#" + id
+	}
+
+	sig := ret.CreateElement("ds:Signature")
+	sig.CreateAttr("xmlns:ds", Namespace)
+	signedInfo := sig.CreateElement("ds:SignedInfo")
+	signedInfo.CreateElement("ds:CanonicalizationMethod").CreateAttr("Algorithm", ExcC14N)
+	signedInfo.CreateElement("ds:SignatureMethod").CreateAttr("Algorithm", a.signature)
+	ref := signedInfo.CreateElement("ds:Reference")
+	ref.CreateAttr("URI", uri)
+	transforms := ref.CreateElement("ds:Transforms")
+	transforms.CreateElement("ds:Transform").CreateAttr("Algorithm", Enveloped)
+	transforms.CreateElement("ds:Transform").CreateAttr("Algorithm", ExcC14N)
+	ref.CreateElement("ds:DigestMethod").CreateAttr("Algorithm", a.digest)
+	ref.CreateElement("ds:DigestValue").SetText(base64.StdEncoding.EncodeToString(digest(a.hash, ret, sig)))
+
+	r, s := rfc6979.SignECDSA(priv, digest(a.hash, signedInfo, nil), a.hash.New)
+	value, err := rfc6979.EncodeP1363(priv.Curve, r, s)
+	if err != nil {
+		return nil, err
+	}
+	sig.CreateElement("ds:SignatureValue").SetText(base64.StdEncoding.EncodeToString(value))
+
+	if opts != nil && len(opts.Certificates) != 0 {
+		x509Data := sig.CreateElement("ds:KeyInfo").CreateElement("ds:X509Data")
+		for _, cert := range opts.Certificates {
+			x509Data.CreateElement("ds:X509Certificate").SetText(base64.StdEncoding.EncodeToString(cert))
+		}
+	}
+	return ret, nil
+}
+
+// Verify verifies the enveloped ds:Signature child of the element with the
+// public key, pub, KeyInfo is ignored. The signature must be made the way
+// SignEnveloped makes it: a single Reference to the element with the
+// enveloped signature and exclusive canonicalization transforms, the
+// algorithms of the key's curve and any digest method of this package.
+// ErrInvalidSignature is returned for signatures that don't fit, while
+// rfc6979.ErrInvalidSignature is returned if the element or the SignedInfo
+// were modified.
+func Verify(pub *ecdsa.PublicKey, el *etree.Element, opts *Options) error {
+	a, err := lookup(pub.Curve)
+	if err != nil {
+		return err
+	}
+
+	var sig *etree.Element
+	for _, child := range el.ChildElements() {
+		if child.Tag == "Signature" && child.NamespaceURI() == Namespace {
+			if sig != nil {
+				return ErrInvalidSignature
+			}
+			sig = child
+		}
+	}
+	if sig == nil {
+		return ErrInvalidSignature
+	}
+	signedInfo := dsChild(sig, "SignedInfo")
+	value := dsChild(sig, "SignatureValue")
+	if signedInfo == nil || value == nil ||
+		algorithmOf(dsChild(signedInfo, "CanonicalizationMethod")) != ExcC14N {
+		return ErrInvalidSignature
+	}
+	if algorithmOf(dsChild(signedInfo, "SignatureMethod")) != a.signature {
+		return ErrInvalidKey
+	}
+
+	refs := dsChildren(signedInfo, "Reference")
+	if len(refs) != 1 {
+		return ErrInvalidSignature
+	}
+	ref := refs[0]
+	uri := ""
+	if id := el.SelectAttrValue(opts.idAttribute(), ""); id != "" {
+		uri = "#" + id
+	}
+	if attr := ref.SelectAttr("URI"); attr == nil || attr.Value != uri {
+		return ErrInvalidSignature
+	}
+	transforms := dsChildren(dsChild(ref, "Transforms"), "Transform")
+	if len(transforms) != 2 || algorithmOf(transforms[0]) != Enveloped || algorithmOf(transforms[1]) != ExcC14N {
+		return ErrInvalidSignature
+	}
+	h := crypto.Hash(0)
+	digestMethod := algorithmOf(dsChild(ref, "DigestMethod"))
+	for _, alg := range algorithms {
+		if alg.digest == digestMethod {
+			h = alg.hash
+		}
+	}
+	digestValue := dsChild(ref, "DigestValue")
+	if h == 0 || digestValue == nil {
+		return ErrInvalidSignature
+	}
+	expected, err := decodeBase64(digestValue.Text())
+	if err != nil {
+		return ErrInvalidSignature
+	}
+	if !bytes.Equal(expected, digest(h, el, sig)) {
+		return rfc6979.ErrInvalidSignature
+	}
+
+	raw, err := decodeBase64(value.Text())
+	if err != nil {
+		return ErrInvalidSignature
+	}
+	r, s, err := rfc6979.DecodeP1363(pub.Curve, raw)
+	if err != nil {
+		return ErrInvalidSignature
+	}
+	if !rfc6979.VerifyECDSA(pub, digest(a.hash, signedInfo, nil), r, s) {
+		return rfc6979.ErrInvalidSignature
+	}
+	return nil
+}
+
+// digest hashes the canonical form of the element without skip.
+func digest(h crypto.Hash, el, skip *etree.Element) []byte {
+	var b strings.Builder
+	canonicalize(&b, el, skip, nil)
+	d := h.New()
+	d.Write([]byte(b.String()))
+	return d.Sum(nil)
+}
+
+// inheritedNamespaces returns the namespace declarations of the ancestors
+// of the element that are in effect for it.
+func inheritedNamespaces(el *etree.Element) []etree.Attr {
+	var (
+		decls []etree.Attr
+		seen  = make(map[string]bool)
+	)
+	for _, a := range el.Attr {
+		seen[a.FullKey()] = true
+	}
+	for p := el.Parent(); p != nil; p = p.Parent() {
+		for _, a := range p.Attr {
+			if (a.Space == "xmlns" || (a.Space == "" && a.Key == "xmlns")) && !seen[a.FullKey()] {
+				seen[a.FullKey()] = true
+				decls = append(decls, a)
+			}
+		}
+	}
+	return decls
+}
+
+// dsChildren returns the children of the element in the XML-DSig namespace
+// with the tag.
+func dsChildren(el *etree.Element, tag string) []*etree.Element {
+	if el == nil {
+		return nil
+	}
+	var children []*etree.Element
+	for _, child := range el.ChildElements() {
+		if child.Tag == tag && child.NamespaceURI() == Namespace {
+			children = append(children, child)
+		}
+	}
+	return children
+}
+
+// dsChild returns the only child of the element in the XML-DSig namespace
+// with the tag, or nil.
+func dsChild(el *etree.Element, tag string) *etree.Element {
+	children := dsChildren(el, tag)
+	if len(children) != 1 {
+		return nil
+	}
+	return children[0]
+}
+
+// algorithmOf returns the Algorithm attribute of the element.
+func algorithmOf(el *etree.Element) string {
+	if el == nil {
+		return ""
+	}
+	return el.SelectAttrValue("Algorithm", "")
+}
+
+// decodeBase64 decodes base64 text, which may be wrapped.
+func decodeBase64(s string) ([]byte, error) {
+	return base64.StdEncoding.DecodeString(strings.Join(strings.Fields(s), ""))
+}
@@ -0,0 +1,152 @@
+package xmldsig_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"encoding/base64"
+	"math/big"
+	"testing"
+
+	"github.com/beevik/etree"
+	"github.com/nspcc-dev/rfc6979"
+	"github.com/nspcc-dev/rfc6979/xmldsig"
+)
+
+const assertion = `<samlp:Response xmlns:samlp="urn:oasis:names:tc:SAML:2.0:protocol" xmlns:saml="urn:oasis:names:tc:SAML:2.0:assertion" ID="_r1">
+  <saml:Assertion ID="_a1" Version="2.0">
+    <saml:Issuer>https://idp.example.org</saml:Issuer>
+    <saml:Subject><saml:NameID>alice@example.org</saml:NameID></saml:Subject>
+  </saml:Assertion>
+</samlp:Response>`
+
+func newKey(c elliptic.Curve) *ecdsa.PrivateKey {
+	key := &ecdsa.PrivateKey{D: big.NewInt(0x1234567)}
+	key.Curve = c
+	key.X, key.Y = c.ScalarBaseMult(key.D.Bytes())
+	return key
+}
+
+func parse(t *testing.T, s string) *etree.Element {
+	doc := etree.NewDocument()
+	if err := doc.ReadFromString(s); err != nil {
+		t.Fatal(err)
+	}
+	return doc.Root()
+}
+
+func TestSignEnveloped(t *testing.T) {
+	for _, c := range []elliptic.Curve{elliptic.P256(), elliptic.P384(), elliptic.P521()} {
+		name := c.Params().Name
+		key := newKey(c)
+		el := parse(t, assertion).SelectElement("Assertion")
+
+		signed, err := xmldsig.SignEnveloped(key, el, &xmldsig.Options{Certificates: [][]byte{{1, 2, 3}}})
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		again, _ := xmldsig.SignEnveloped(key, el, nil)
+		if string(xmldsig.Canonicalize(signed.SelectElement("Signature").SelectElement("SignedInfo"))) !=
+			string(xmldsig.Canonicalize(again.SelectElement("Signature").SelectElement("SignedInfo"))) ||
+			signed.FindElement("Signature/SignatureValue").Text() != again.FindElement("Signature/SignatureValue").Text() {
+			t.Errorf("%s: Expected deterministic signatures", name)
+		}
+		if len(el.ChildElements()) != 2 {
+			t.Errorf("%s: Expected the original element to be intact", name)
+		}
+		if uri := signed.FindElement("Signature/SignedInfo/Reference").SelectAttrValue("URI", ""); uri != "#_a1" {
+			t.Errorf("%s: Unexpected reference %q", name, uri)
+		}
+		value, _ := base64.StdEncoding.DecodeString(signed.FindElement("Signature/SignatureValue").Text())
+		if len(value) != 2*rfc6979.OrderSize(c) {
+			t.Errorf("%s: Unexpected signature length %d", name, len(value))
+		}
+		if cert := signed.FindElement("Signature/KeyInfo/X509Data/X509Certificate"); cert == nil || cert.Text() != "AQID" {
+			t.Errorf("%s: Expected the certificate in KeyInfo", name)
+		}
+
+		// Serialize and parse to check the namespaces, saml: declared by
+		// the parent, survive it.
+		doc := etree.NewDocument()
+		doc.SetRoot(signed)
+		s, _ := doc.WriteToString()
+		if err := xmldsig.Verify(&key.PublicKey, parse(t, s), nil); err != nil {
+			t.Errorf("%s: Expected round trip, got %v", name, err)
+		}
+
+		// The Signature may be moved after the Issuer.
+		moved := parse(t, s)
+		sig := moved.SelectElement("Signature")
+		moved.RemoveChild(sig)
+		moved.InsertChildAt(moved.SelectElement("Issuer").Index()+1, sig)
+		if err := xmldsig.Verify(&key.PublicKey, moved, nil); err != nil {
+			t.Errorf("%s: Expected a moved signature to verify, got %v", name, err)
+		}
+
+		tampered := parse(t, s)
+		tampered.FindElement("Subject/NameID").SetText("mallory@example.org")
+		if err := xmldsig.Verify(&key.PublicKey, tampered, nil); err != rfc6979.ErrInvalidSignature {
+			t.Errorf("%s: Expected %v for a tampered element, got %v", name, rfc6979.ErrInvalidSignature, err)
+		}
+
+		other := newKey(c)
+		other.X, other.Y = c.ScalarBaseMult([]byte{7})
+		if err := xmldsig.Verify(&other.PublicKey, parse(t, s), nil); err != rfc6979.ErrInvalidSignature {
+			t.Errorf("%s: Expected %v for another key, got %v", name, rfc6979.ErrInvalidSignature, err)
+		}
+	}
+}
+
+func TestVerifyInvalid(t *testing.T) {
+	key := newKey(elliptic.P256())
+	signed, err := xmldsig.SignEnveloped(key, parse(t, assertion), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if uri := signed.FindElement("Signature/SignedInfo/Reference").SelectAttrValue("URI", ""); uri != "#_r1" {
+		t.Errorf("Unexpected reference %q", uri)
+	}
+	if err := xmldsig.Verify(&key.PublicKey, signed, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := xmldsig.SignEnveloped(newKey(rfc6979.Secp256k1()), signed, nil); err != xmldsig.ErrInvalidKey {
+		t.Errorf("Expected %v for secp256k1, got %v", xmldsig.ErrInvalidKey, err)
+	}
+	if err := xmldsig.Verify(&newKey(elliptic.P384()).PublicKey, signed, nil); err != xmldsig.ErrInvalidKey {
+		t.Errorf("Expected %v for a P-384 key, got %v", xmldsig.ErrInvalidKey, err)
+	}
+	if err := xmldsig.Verify(&key.PublicKey, signed, &xmldsig.Options{IDAttribute: "Version"}); err != xmldsig.ErrInvalidSignature {
+		t.Errorf("Expected %v for another ID attribute, got %v", xmldsig.ErrInvalidSignature, err)
+	}
+
+	for name, modify := range map[string]func(sig *etree.Element){
+		"no signature": func(sig *etree.Element) { sig.Parent().RemoveChild(sig) },
+		"c14n": func(sig *etree.Element) {
+			sig.FindElement("SignedInfo/CanonicalizationMethod").CreateAttr("Algorithm", "http://www.w3.org/TR/2001/REC-xml-c14n-20010315")
+		},
+		"transforms": func(sig *etree.Element) {
+			transforms := sig.FindElement("SignedInfo/Reference/Transforms")
+			transforms.RemoveChild(transforms.SelectElement("Transform"))
+		},
+		"digest method": func(sig *etree.Element) {
+			sig.FindElement("SignedInfo/Reference/DigestMethod").CreateAttr("Algorithm", "http://www.w3.org/2000/09/xmldsig#sha1")
+		},
+		"signature value": func(sig *etree.Element) { sig.SelectElement("SignatureValue").SetText("AQID") },
+		"two references": func(sig *etree.Element) {
+			info := sig.SelectElement("SignedInfo")
+			info.AddChild(info.SelectElement("Reference").Copy())
+		},
+	} {
+		el := signed.Copy()
+		modify(el.SelectElement("Signature"))
+		if err := xmldsig.Verify(&key.PublicKey, el, nil); err != xmldsig.ErrInvalidSignature {
+			t.Errorf("%s: Expected %v, got %v", name, xmldsig.ErrInvalidSignature, err)
+		}
+	}
+
+	el := signed.Copy()
+	el.FindElement("Signature/SignedInfo/Reference/DigestValue").SetText(base64.StdEncoding.EncodeToString(make([]byte, 32)))
+	if err := xmldsig.Verify(&key.PublicKey, el, nil); err != rfc6979.ErrInvalidSignature {
+		t.Errorf("Expected %v for a wrong digest, got %v", rfc6979.ErrInvalidSignature, err)
+	}
+}